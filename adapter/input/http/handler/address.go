@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -21,6 +20,8 @@ const (
 	ErrorToGetAddress      = "error to get and address by id"
 	AddressNotFound        = "address not found"
 	AddressNotFoundMessage = "the address with id %d wasn't found"
+	SuccessToListAddresses = "addresses found with success"
+	ErrorToListAddresses   = "error to list the addresses"
 )
 
 type Address struct {
@@ -53,9 +54,7 @@ type AddressResponse struct {
 }
 
 func (c *Address) Create(w http.ResponseWriter, r *http.Request) {
-	contextControl := domain.ContextControl{
-		Context: context.Background(),
-	}
+	contextControl := getContextControl(r)
 
 	var addressRequest AddressRequest
 	if err := json.NewDecoder(r.Body).Decode(&addressRequest); err != nil {
@@ -77,7 +76,7 @@ func (c *Address) Create(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.LoggerSugar.Errorw(ErrorToCreateAddress, "error", err.Error())
 		response := objectResponse(ErrorToCreateAddress, err.Error())
-		responseReturn(w, http.StatusInternalServerError, response.Bytes())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
 		return
 	}
 
@@ -94,9 +93,7 @@ func (c *Address) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (c *Address) GetByID(w http.ResponseWriter, r *http.Request) {
-	contextControl := domain.ContextControl{
-		Context: context.Background(),
-	}
+	contextControl := getContextControl(r)
 
 	var IDRequest, err = strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
@@ -110,7 +107,7 @@ func (c *Address) GetByID(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.LoggerSugar.Errorw(ErrorToGetAddress, "error", err.Error())
 		response := objectResponse(ErrorToGetAddress, err.Error())
-		responseReturn(w, http.StatusInternalServerError, response.Bytes())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
 		return
 	}
 
@@ -131,3 +128,35 @@ func (c *Address) GetByID(w http.ResponseWriter, r *http.Request) {
 	response := objectResponse(addressResponse, SuccessToGetAddress)
 	responseReturn(w, http.StatusOK, response.Bytes())
 }
+
+// List returns a page of the addresses filtered by the zip_code and city of the query.
+func (c *Address) List(w http.ResponseWriter, r *http.Request) {
+	contextControl := getContextControl(r)
+
+	page, err := pageFromRequest(r)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToListAddresses, "error", err.Error())
+		response := objectResponse(ErrorToListAddresses, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	query := r.URL.Query()
+	addresses, err := c.AddressService.List(contextControl, domain.AddressFilterDomain{
+		ZipCode: query.Get("zip_code"),
+		City:    query.Get("city"),
+	}, page)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToListAddresses, "error", err.Error())
+		response := objectResponse(ErrorToListAddresses, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	response := objectResponse(newPageResponse(addresses, func(addressDomain domain.AddressDomain) AddressResponse {
+		var addressResponse AddressResponse
+		copier.Copy(&addressResponse, &addressDomain)
+		return addressResponse
+	}), SuccessToListAddresses)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
@@ -194,3 +194,87 @@ func TestAddress_Create(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, res.StatusCode)
 	})
 }
+
+var pathAddressList = "/address"
+
+func TestAddress_List(t *testing.T) {
+	t.Run("WithFilters_ReturnsThePageAndTheNextCursor", func(t *testing.T) {
+		var filterAsked domain.AddressFilterDomain
+		var pageAsked domain.PageDomain
+		mockRepo := output.AddressDomainDataBaseRepositoryMock{
+			ListMock: func(ctx domain.ContextControl, filter domain.AddressFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.AddressDomain], error) {
+				filterAsked, pageAsked = filter, page
+				mocked := utils.GetMockAddress()
+				mocked.ID = 7
+				return domain.PageResultDomain[domain.AddressDomain]{
+					Items: []domain.AddressDomain{mocked}, NextCursor: 7, TotalEstimate: 3}, nil
+			},
+		}
+
+		addressService := service.AddressService{
+			LoggerSugar:                     zap.NewNop().Sugar(),
+			AddressDomainDataBaseRepository: mockRepo,
+		}
+		handler := Address{AddressService: addressService, LoggerSugar: zap.NewNop().Sugar()}
+
+		req := httptest.NewRequest(http.MethodGet, pathAddressList+"?zip_code=36025-200&city=Juiz+de+Fora&page_size=1&cursor=4", nil)
+		w := httptest.NewRecorder()
+		handler.List(w, req)
+
+		res := w.Result()
+		defer func() { _ = res.Body.Close() }()
+
+		var body struct {
+			Result PageResponse[AddressResponse] `json:"result"`
+		}
+		_ = json.NewDecoder(res.Body).Decode(&body)
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, domain.AddressFilterDomain{ZipCode: "36025200", City: "juiz de fora"}, filterAsked)
+		assert.Equal(t, domain.PageDomain{Size: 1, Cursor: 4}, pageAsked)
+		assert.Len(t, body.Result.Items, 1)
+		assert.Equal(t, int64(7), body.Result.Items[0].ID)
+		assert.Equal(t, int64(7), *body.Result.NextCursor)
+		assert.Equal(t, int64(3), body.Result.TotalEstimate)
+	})
+
+	t.Run("WithLastPage_ReturnsNullNextCursor", func(t *testing.T) {
+		addressService := service.AddressService{
+			LoggerSugar:                     zap.NewNop().Sugar(),
+			AddressDomainDataBaseRepository: output.AddressDomainDataBaseRepositoryMock{},
+		}
+		handler := Address{AddressService: addressService, LoggerSugar: zap.NewNop().Sugar()}
+
+		req := httptest.NewRequest(http.MethodGet, pathAddressList, nil)
+		w := httptest.NewRecorder()
+		handler.List(w, req)
+
+		res := w.Result()
+		defer func() { _ = res.Body.Close() }()
+
+		var body struct {
+			Result map[string]any `json:"result"`
+		}
+		_ = json.NewDecoder(res.Body).Decode(&body)
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, []any{}, body.Result["items"])
+		assert.Contains(t, body.Result, "next_cursor")
+		assert.Nil(t, body.Result["next_cursor"])
+	})
+
+	t.Run("WithInvalidPage_ReturnsBadRequest", func(t *testing.T) {
+		handler := Address{AddressService: service.AddressService{}, LoggerSugar: zap.NewNop().Sugar()}
+
+		for _, query := range []string{"?page_size=abc", "?cursor=abc", "?page_size=500", "?cursor=-1"} {
+			req := httptest.NewRequest(http.MethodGet, pathAddressList+query, nil)
+			w := httptest.NewRecorder()
+			handler.List(w, req)
+
+			res := w.Result()
+			_ = res.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, query)
+		}
+	})
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/input"
+	"go.uber.org/zap"
+)
+
+const (
+	SuccessToCreateAttentionTime     = "attention time created with success"
+	SuccessToGetAttentionTime        = "attention time found with success"
+	SuccessToListAttentionTimes      = "attention times listed with success"
+	SuccessToActivateAttentionTime   = "attention time activated with success"
+	SuccessToDeactivateAttentionTime = "attention time deactivated with success"
+	ErrorToCreateAttentionTime       = "error to create and process the request"
+	ErrorToGetAttentionTime          = "error to get an attention time by id"
+	ErrorToListAttentionTimes        = "error to list the attention times of the employee"
+	ErrorToUpdateAttentionTime       = "error to update the attention time"
+	AttentionTimeNotFound            = "attention time not found"
+	AttentionTimeNotFoundMessage     = "the attention time with id %d wasn't found"
+)
+
+type AttentionTime struct {
+	AttentionTimeService input.IAttentionTimeService
+	LoggerSugar          *zap.SugaredLogger
+}
+
+type AttentionTimeRequest struct {
+	InitialTime string `json:"initial_time"`
+	FinalTime   string `json:"final_time"`
+	ServiceID   int64  `json:"service_id"`
+	EmployeeID  int64  `json:"employee_id"`
+}
+
+type AttentionTimeResponse struct {
+	ID          int64  `json:"id"`
+	InitialTime string `json:"initial_time"`
+	FinalTime   string `json:"final_time"`
+	Active      bool   `json:"active"`
+	ServiceID   int64  `json:"service_id"`
+	EmployeeID  int64  `json:"employee_id"`
+	ContractID  int64  `json:"contract_id"`
+}
+
+func (a AttentionTimeRequest) toAttentionTimeDomain() domain.AttentionTimeDomain {
+	return domain.AttentionTimeDomain{
+		InitialTime: a.InitialTime,
+		FinalTime:   a.FinalTime,
+		ServiceID:   a.ServiceID,
+		EmployeeID:  a.EmployeeID,
+	}
+}
+
+func newAttentionTimeResponse(attentionTimeDomain domain.AttentionTimeDomain) AttentionTimeResponse {
+	return AttentionTimeResponse{
+		ID:          attentionTimeDomain.ID,
+		InitialTime: attentionTimeDomain.InitialTime,
+		FinalTime:   attentionTimeDomain.FinalTime,
+		Active:      attentionTimeDomain.Active,
+		ServiceID:   attentionTimeDomain.ServiceID,
+		EmployeeID:  attentionTimeDomain.EmployeeID,
+		ContractID:  attentionTimeDomain.ContractID,
+	}
+}
+
+func (a *AttentionTime) Create(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	var attentionTimeRequest AttentionTimeRequest
+	if err := json.NewDecoder(r.Body).Decode(&attentionTimeRequest); err != nil {
+		a.LoggerSugar.Errorw(ErrorToCreateAttentionTime, "error", err.Error())
+		response := objectResponse(ErrorToCreateAttentionTime, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	attentionTimeDomain, err := a.AttentionTimeService.Create(contextControl, attentionTimeRequest.toAttentionTimeDomain())
+	if err != nil {
+		a.LoggerSugar.Errorw(ErrorToCreateAttentionTime, "error", err.Error())
+		response := objectResponse(ErrorToCreateAttentionTime, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	response := objectResponse(newAttentionTimeResponse(attentionTimeDomain), SuccessToCreateAttentionTime)
+	responseReturn(w, http.StatusCreated, response.Bytes())
+}
+
+func (a *AttentionTime) GetByID(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		a.LoggerSugar.Errorw(ErrorToGetAttentionTime, "error", err.Error())
+		response := objectResponse(ErrorToGetAttentionTime, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	attentionTimeDomain, exists, err := a.AttentionTimeService.GetByID(contextControl, IDRequest)
+	if err != nil {
+		a.LoggerSugar.Errorw(ErrorToGetAttentionTime, "error", err.Error())
+		response := objectResponse(ErrorToGetAttentionTime, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		a.LoggerSugar.Infow(AttentionTimeNotFound, "attention_time_id", IDRequest)
+		response := objectResponse(AttentionTimeNotFound, fmt.Sprintf(AttentionTimeNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newAttentionTimeResponse(attentionTimeDomain), SuccessToGetAttentionTime)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// GetByEmployeeID lists the attention times of an employee, active or not.
+func (a *AttentionTime) GetByEmployeeID(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	employeeID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		a.LoggerSugar.Errorw(ErrorToListAttentionTimes, "error", err.Error())
+		response := objectResponse(ErrorToListAttentionTimes, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	attentionTimesDomain, exists, err := a.AttentionTimeService.GetByEmployeeID(contextControl, employeeID)
+	if err != nil {
+		a.LoggerSugar.Errorw(ErrorToListAttentionTimes, "error", err.Error())
+		response := objectResponse(ErrorToListAttentionTimes, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		a.LoggerSugar.Infow(EmployeeNotFound, "employee_id", employeeID)
+		response := objectResponse(EmployeeNotFound, fmt.Sprintf(EmployeeNotFoundMessage, employeeID))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	attentionTimesResponse := make([]AttentionTimeResponse, 0, len(attentionTimesDomain))
+	for _, attentionTimeDomain := range attentionTimesDomain {
+		attentionTimesResponse = append(attentionTimesResponse, newAttentionTimeResponse(attentionTimeDomain))
+	}
+
+	response := objectResponse(attentionTimesResponse, SuccessToListAttentionTimes)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+func (a *AttentionTime) Activate(w http.ResponseWriter, r *http.Request) {
+	a.setActive(w, r, true, SuccessToActivateAttentionTime)
+}
+
+func (a *AttentionTime) Deactivate(w http.ResponseWriter, r *http.Request) {
+	a.setActive(w, r, false, SuccessToDeactivateAttentionTime)
+}
+
+func (a *AttentionTime) setActive(w http.ResponseWriter, r *http.Request, active bool, successMessage string) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		a.LoggerSugar.Errorw(ErrorToUpdateAttentionTime, "error", err.Error())
+		response := objectResponse(ErrorToUpdateAttentionTime, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	attentionTimeDomain, exists, err := a.AttentionTimeService.SetActive(contextControl, IDRequest, active)
+	if err != nil {
+		a.LoggerSugar.Errorw(ErrorToUpdateAttentionTime, "error", err.Error())
+		response := objectResponse(ErrorToUpdateAttentionTime, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		a.LoggerSugar.Infow(AttentionTimeNotFound, "attention_time_id", IDRequest)
+		response := objectResponse(AttentionTimeNotFound, fmt.Sprintf(AttentionTimeNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newAttentionTimeResponse(attentionTimeDomain), successMessage)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/input"
+	"go.uber.org/zap"
+)
+
+const (
+	SuccessToGetSpecies      = "species found with success"
+	SuccessToGetBreeds       = "breeds found with success"
+	SuccessToCreateSpecies   = "species created with success"
+	SuccessToCreateBreed     = "breed created with success"
+	ErrorToGetSpecies        = "error to get the species catalog"
+	ErrorToGetBreeds         = "error to get the breeds of a species"
+	ErrorToCreateSpecies     = "error to create the species"
+	ErrorToCreateBreed       = "error to create the breed"
+	SpeciesNotFound          = "species not found"
+	SpeciesNotFoundMessage   = "the species with id %d wasn't found"
+	ErrorToDecodeCatalogBody = "error to decode the request body"
+)
+
+type Catalog struct {
+	CatalogService input.ICatalogService
+	LoggerSugar    *zap.SugaredLogger
+}
+
+type SpeciesRequest struct {
+	Name string `json:"name"`
+}
+
+type BreedRequest struct {
+	Name      string `json:"name"`
+	SpeciesID int64  `json:"species_id"`
+}
+
+type BreedResponse struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	SpeciesID int64  `json:"species_id"`
+}
+
+type SpeciesResponse struct {
+	ID     int64           `json:"id"`
+	Name   string          `json:"name"`
+	Breeds []BreedResponse `json:"breeds,omitempty"`
+}
+
+func newBreedResponse(breed domain.BreedDomain) BreedResponse {
+	return BreedResponse{
+		ID:        breed.ID,
+		Name:      breed.Name,
+		SpeciesID: breed.SpeciesID,
+	}
+}
+
+func newSpeciesResponse(species domain.SpeciesDomain) SpeciesResponse {
+
+	speciesResponse := SpeciesResponse{
+		ID:   species.ID,
+		Name: species.Name,
+	}
+
+	for _, breed := range species.Breeds {
+		speciesResponse.Breeds = append(speciesResponse.Breeds, newBreedResponse(breed))
+	}
+
+	return speciesResponse
+}
+
+func (c *Catalog) GetSpecies(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	tree, err := c.CatalogService.GetSpeciesTree(contextControl)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetSpecies, "error", err.Error())
+		response := objectResponse(ErrorToGetSpecies, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	speciesResponse := make([]SpeciesResponse, 0, len(tree))
+	for _, species := range tree {
+		speciesResponse = append(speciesResponse, newSpeciesResponse(species))
+	}
+
+	response := objectResponse(speciesResponse, SuccessToGetSpecies)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+func (c *Catalog) GetBreedsBySpeciesID(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	var speciesIDRequest, err = strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetBreeds, "error", err.Error())
+		response := objectResponse(ErrorToGetBreeds, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	breeds, exists, err := c.CatalogService.GetBreedsBySpeciesID(contextControl, speciesIDRequest)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetBreeds, "error", err.Error())
+		response := objectResponse(ErrorToGetBreeds, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		c.LoggerSugar.Errorw(SpeciesNotFound, "species_id", speciesIDRequest)
+		response := objectResponse(SpeciesNotFound, fmt.Sprintf(SpeciesNotFoundMessage, speciesIDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	breedsResponse := make([]BreedResponse, 0, len(breeds))
+	for _, breed := range breeds {
+		breedsResponse = append(breedsResponse, newBreedResponse(breed))
+	}
+
+	response := objectResponse(breedsResponse, SuccessToGetBreeds)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+func (c *Catalog) CreateSpecies(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	var speciesRequest SpeciesRequest
+	if err := json.NewDecoder(r.Body).Decode(&speciesRequest); err != nil {
+		c.LoggerSugar.Errorw(ErrorToDecodeCatalogBody, "error", err.Error())
+		response := objectResponse(ErrorToCreateSpecies, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	species, err := c.CatalogService.CreateSpecies(contextControl, domain.SpeciesDomain{Name: speciesRequest.Name})
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToCreateSpecies, "error", err.Error())
+		response := objectResponse(ErrorToCreateSpecies, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	response := objectResponse(newSpeciesResponse(species), SuccessToCreateSpecies)
+	responseReturn(w, http.StatusCreated, response.Bytes())
+}
+
+func (c *Catalog) CreateBreed(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	var breedRequest BreedRequest
+	if err := json.NewDecoder(r.Body).Decode(&breedRequest); err != nil {
+		c.LoggerSugar.Errorw(ErrorToDecodeCatalogBody, "error", err.Error())
+		response := objectResponse(ErrorToCreateBreed, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	breed, err := c.CatalogService.CreateBreed(contextControl, domain.BreedDomain{
+		Name:      breedRequest.Name,
+		SpeciesID: breedRequest.SpeciesID,
+	})
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToCreateBreed, "error", err.Error())
+		response := objectResponse(ErrorToCreateBreed, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	response := objectResponse(newBreedResponse(breed), SuccessToCreateBreed)
+	responseReturn(w, http.StatusCreated, response.Bytes())
+}
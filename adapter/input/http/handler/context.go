@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+// HeaderContractID carries the contract (tenant) the request acts on. The gateway sets it
+// from the access token, so the services never trust a contract_id sent in the body.
+const HeaderContractID = "X-Contract-ID"
+
+// HeaderActor carries who makes the request, such as the user of the access token, set by the
+// gateway as well. It is recorded in the audit trails.
+const HeaderActor = "X-Actor"
+
+const (
+	ErrorContractIDRequired = "the header X-Contract-ID is required"
+	ErrorContractIDInvalid  = "the header X-Contract-ID must be a positive number"
+)
+
+// ErrRequestFinished is the cause set on the request context once the handler returns.
+var ErrRequestFinished = errors.New("request finished")
+
+type contextControlKey struct{}
+
+// ContextRequest derives the request context from r.Context(), so a client that goes away
+// cancels the work, and bounds it with timeout. The resulting ContextControl is read by the
+// handlers through getContextControl.
+func ContextRequest(timeout time.Duration) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			ctx, cancelCause := context.WithCancelCause(r.Context())
+			cancel := context.CancelFunc(func() {})
+			if timeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+			}
+
+			// cancelCause runs first so the cause reaches the timeout context as well
+			defer cancel()
+			defer cancelCause(ErrRequestFinished)
+
+			contextControl := domain.ContextControl{
+				Context:         ctx,
+				CancelCauseFunc: cancelCause,
+				RequestID:       middleware.GetReqID(r.Context()),
+				Actor:           r.Header.Get(HeaderActor),
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, contextControlKey{}, contextControl)))
+		})
+	}
+}
+
+// getContextControl returns the ContextControl built by ContextRequest, falling back
+// to the plain request context when the middleware isn't in the chain.
+func getContextControl(r *http.Request) domain.ContextControl {
+
+	if contextControl, ok := r.Context().Value(contextControlKey{}).(domain.ContextControl); ok {
+		return contextControl
+	}
+
+	return domain.ContextControl{
+		Context:   r.Context(),
+		RequestID: middleware.GetReqID(r.Context()),
+		Actor:     r.Header.Get(HeaderActor),
+	}
+}
+
+// ContractScope reads the contract of the request from HeaderContractID into the ContextControl,
+// rejecting requests without it. Repositories and cache keys are scoped to that contract.
+func ContractScope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		header := r.Header.Get(HeaderContractID)
+		if len(header) == 0 {
+			response := objectResponse(ErrorContractIDRequired, ErrorContractIDRequired)
+			responseReturn(w, http.StatusBadRequest, response.Bytes())
+			return
+		}
+
+		contractID, err := strconv.ParseInt(header, 10, 64)
+		if err != nil || contractID <= 0 {
+			response := objectResponse(ErrorContractIDInvalid, ErrorContractIDInvalid)
+			responseReturn(w, http.StatusBadRequest, response.Bytes())
+			return
+		}
+
+		contextControl := getContextControl(r)
+		contextControl.ContractID = contractID
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextControlKey{}, contextControl)))
+	})
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/petshop-system/petshop-api/application/service"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestContextRequest(t *testing.T) {
+
+	t.Run("WithMiddleware_PropagatesDeadlineAndRequestID", func(t *testing.T) {
+
+		var contextControl domain.ContextControl
+		router := chi.NewRouter()
+		router.With(middleware.RequestID, ContextRequest(time.Second)).
+			Get("/", func(w http.ResponseWriter, r *http.Request) {
+				contextControl = getContextControl(r)
+			})
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.NotEmpty(t, contextControl.RequestID)
+		assert.NotNil(t, contextControl.CancelCauseFunc)
+		_, hasDeadline := contextControl.Context.Deadline()
+		assert.True(t, hasDeadline)
+		assert.ErrorIs(t, context.Cause(contextControl.Context), ErrRequestFinished)
+	})
+
+	t.Run("WithSlowDatabase_ReturnsGatewayTimeout", func(t *testing.T) {
+
+		addressService := service.AddressService{
+			LoggerSugar: zap.NewNop().Sugar(),
+			AddressDomainDataBaseRepository: output.AddressDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.AddressDomain, bool, error) {
+					<-contextControl.Context.Done()
+					return domain.AddressDomain{}, false, contextControl.Context.Err()
+				},
+			},
+			AddressDomainCacheRepository: output.AddressDomainCacheRepositoryMock{},
+		}
+		handler := Address{AddressService: addressService, LoggerSugar: zap.NewNop().Sugar()}
+
+		router := chi.NewRouter()
+		router.With(ContextRequest(10*time.Millisecond)).Get("/address/search/{id}", handler.GetByID)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/address/search/1", nil))
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	})
+}
+
+func TestContractScope(t *testing.T) {
+
+	tests := []struct {
+		Name               string
+		Header             string
+		ExpectedStatusCode int
+		ExpectedContractID int64
+	}{
+		{Name: "WithContractHeader_ScopesTheRequest", Header: "7", ExpectedStatusCode: http.StatusOK, ExpectedContractID: 7},
+		{Name: "WithoutContractHeader_ReturnsBadRequest", ExpectedStatusCode: http.StatusBadRequest},
+		{Name: "WithInvalidContractHeader_ReturnsBadRequest", Header: "abc", ExpectedStatusCode: http.StatusBadRequest},
+		{Name: "WithZeroContractHeader_ReturnsBadRequest", Header: "0", ExpectedStatusCode: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			var contextControl domain.ContextControl
+			router := chi.NewRouter()
+			router.With(ContextRequest(time.Second), ContractScope).
+				Get("/", func(w http.ResponseWriter, r *http.Request) {
+					contextControl = getContextControl(r)
+				})
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if len(test.Header) > 0 {
+				request.Header.Set(HeaderContractID, test.Header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, request)
+
+			assert.Equal(t, test.ExpectedStatusCode, w.Code)
+			assert.Equal(t, test.ExpectedContractID, contextControl.ContractID)
+		})
+	}
+}
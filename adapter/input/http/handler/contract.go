@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jinzhu/copier"
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/input"
+	"go.uber.org/zap"
+)
+
+const (
+	SuccessToCreateContract = "contract created with success"
+	SuccessToGetContract    = "contract found with success"
+	SuccessToUpdateContract = "contract updated with success"
+	ErrorToCreateContract   = "error to create and process the request"
+	ErrorToGetContract      = "error to get a contract by id"
+	ErrorToUpdateContract   = "error to update the contract"
+	ContractNotFound        = "contract not found"
+	ContractNotFoundMessage = "the contract with id %d wasn't found"
+)
+
+type Contract struct {
+	ContractService input.IContractService
+	LoggerSugar     *zap.SugaredLogger
+}
+
+type ContractRequest struct {
+	Name       string         `json:"name"`
+	Email      string         `json:"email"`
+	Document   string         `json:"document"`
+	PersonType string         `json:"person_type"`
+	Address    AddressRequest `json:"address"`
+}
+
+type ContractResponse struct {
+	ID          int64           `json:"id"`
+	Name        string          `json:"name"`
+	Email       string          `json:"email"`
+	Document    string          `json:"document"`
+	PersonType  string          `json:"person_type"`
+	DateCreated time.Time       `json:"date_created"`
+	AddressID   int64           `json:"address_id"`
+	Address     AddressResponse `json:"address"`
+}
+
+func (c ContractRequest) toContractDomain() domain.ContractDomain {
+
+	contractDomain := domain.ContractDomain{
+		Name:       c.Name,
+		Email:      c.Email,
+		Document:   c.Document,
+		PersonType: c.PersonType,
+	}
+	copier.Copy(&contractDomain.Address, &c.Address)
+
+	return contractDomain
+}
+
+func newContractResponse(contractDomain domain.ContractDomain) ContractResponse {
+
+	contractResponse := ContractResponse{
+		ID:          contractDomain.ID,
+		Name:        contractDomain.Name,
+		Email:       contractDomain.Email,
+		Document:    contractDomain.Document,
+		PersonType:  contractDomain.PersonType,
+		DateCreated: contractDomain.DateCreated,
+		AddressID:   contractDomain.AddressID,
+	}
+	copier.Copy(&contractResponse.Address, &contractDomain.Address)
+
+	return contractResponse
+}
+
+func (c *Contract) Create(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	var contractRequest ContractRequest
+	if err := json.NewDecoder(r.Body).Decode(&contractRequest); err != nil {
+		c.LoggerSugar.Errorw(ErrorToCreateContract, "error", err.Error())
+		response := objectResponse(ErrorToCreateContract, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	contractDomain, err := c.ContractService.Create(contextControl, contractRequest.toContractDomain())
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToCreateContract, "error", err.Error())
+		response := objectResponse(ErrorToCreateContract, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	response := objectResponse(newContractResponse(contractDomain), SuccessToCreateContract)
+	responseReturn(w, http.StatusCreated, response.Bytes())
+}
+
+func (c *Contract) GetByID(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetContract, "error", err.Error())
+		response := objectResponse(ErrorToGetContract, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	contractDomain, exists, err := c.ContractService.GetByID(contextControl, IDRequest)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetContract, "error", err.Error())
+		response := objectResponse(ErrorToGetContract, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		c.LoggerSugar.Infow(ContractNotFound, "contract_id", IDRequest)
+		response := objectResponse(ContractNotFound, fmt.Sprintf(ContractNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newContractResponse(contractDomain), SuccessToGetContract)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// Update replaces the name, email, document and person type of a contract. The address is kept.
+func (c *Contract) Update(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToUpdateContract, "error", err.Error())
+		response := objectResponse(ErrorToUpdateContract, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	var contractRequest ContractRequest
+	if err = json.NewDecoder(r.Body).Decode(&contractRequest); err != nil {
+		c.LoggerSugar.Errorw(ErrorToUpdateContract, "error", err.Error())
+		response := objectResponse(ErrorToUpdateContract, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	contractDomain := contractRequest.toContractDomain()
+	contractDomain.ID = IDRequest
+
+	contractDomain, exists, err := c.ContractService.Update(contextControl, contractDomain)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToUpdateContract, "error", err.Error())
+		response := objectResponse(ErrorToUpdateContract, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		c.LoggerSugar.Infow(ContractNotFound, "contract_id", IDRequest)
+		response := objectResponse(ContractNotFound, fmt.Sprintf(ContractNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newContractResponse(contractDomain), SuccessToUpdateContract)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
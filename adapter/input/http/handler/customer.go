@@ -1,10 +1,13 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/jinzhu/copier"
 	"github.com/petshop-system/petshop-api/application/domain"
 	"github.com/petshop-system/petshop-api/application/port/input"
@@ -13,14 +16,31 @@ import (
 
 const (
 	SuccessToCreateCustomer       = "user created with success"
+	SuccessToGetCustomer          = "customer found with success"
 	ErrorToCreateCustomer         = "error to create and process the request"
+	ErrorToGetCustomer            = "error to get a customer by id"
+	CustomerNotFound              = "customer not found"
+	CustomerNotFoundMessage       = "the customer with id %d wasn't found"
 	ErrorValidateCreateCustomer   = "validation got some mistakes"
 	SuccessValidateCreateCustomer = "success to validate create customer"
+	SuccessToUpdateCustomer       = "customer updated with success"
+	SuccessToDeleteCustomer       = "customer deleted with success"
+	SuccessToRestoreCustomer      = "customer restored with success"
+	ErrorToUpdateCustomer         = "error to update the customer"
+	ErrorToDeleteCustomer         = "error to delete the customer"
+	ErrorToRestoreCustomer        = "error to restore the customer"
+	SuccessToListCustomers        = "customers found with success"
+	ErrorToListCustomers          = "error to list the customers"
+	SuccessToGetCustomerHistory   = "history of the customer found with success"
+	ErrorToGetCustomerHistory     = "error to get the history of the customer"
+	SuccessToOnboardCustomer      = "customer onboarded with success"
+	ErrorToOnboardCustomer        = "error to onboard the customer"
 )
 
 type Customer struct {
-	CustomerService input.ICustomerService
-	LoggerSugar     *zap.SugaredLogger
+	CustomerService           input.ICustomerService
+	CustomerOnboardingService input.ICustomerOnboardingService
+	LoggerSugar               *zap.SugaredLogger
 }
 
 type CustomerRequest struct {
@@ -33,6 +53,62 @@ type CustomerRequest struct {
 	AddressID  int64  `json:"address_id"`
 }
 
+// CustomerOnboardRequest is a customer with its address and phones, created together.
+type CustomerOnboardRequest struct {
+	Customer CustomerRequest               `json:"customer"`
+	Address  AddressRequest                `json:"address"`
+	Phones   []CustomerOnboardPhoneRequest `json:"phones"`
+}
+
+// CustomerOnboardPhoneRequest is a phone of the customer; the primary one, or else the first, becomes
+// its contact number.
+type CustomerOnboardPhoneRequest struct {
+	Number    string `json:"number"`
+	CodeArea  string `json:"code_area"`
+	PhoneType string `json:"phone_type"`
+	Primary   bool   `json:"primary"`
+}
+
+type CustomerOnboardResponse struct {
+	Customer CustomerResponse    `json:"customer"`
+	Address  AddressResponse     `json:"address"`
+	Phones   []PhoneUserResponse `json:"phones"`
+}
+
+// CustomerPatchRequest carries the fields of a partial update; the absent ones are kept.
+type CustomerPatchRequest struct {
+	Name       *string `json:"name"`
+	Email      *string `json:"email"`
+	Document   *string `json:"document"`
+	PersonType *string `json:"person_type"`
+}
+
+// CustomerHistoryResponse is a change of a customer; changes holds the value before and after of
+// every field changed, keyed by its name.
+type CustomerHistoryResponse struct {
+	ID          int64                                       `json:"id"`
+	CustomerID  int64                                       `json:"customer_id"`
+	Action      string                                      `json:"action"`
+	Actor       string                                      `json:"actor"`
+	RequestID   string                                      `json:"request_id"`
+	Description string                                      `json:"description"`
+	Changes     map[string]domain.CustomerFieldChangeDomain `json:"changes"`
+	Date        time.Time                                   `json:"date"`
+}
+
+func newCustomerHistoryResponse(historyDomain domain.CustomerHistoryDomain) CustomerHistoryResponse {
+	return CustomerHistoryResponse{
+		ID:          historyDomain.ID,
+		CustomerID:  historyDomain.CustomerID,
+		Action:      historyDomain.Action,
+		Actor:       historyDomain.Actor,
+		RequestID:   historyDomain.RequestID,
+		Description: historyDomain.Description,
+		Changes:     historyDomain.Changes,
+		Date:        historyDomain.Date,
+	}
+}
+
 type CustomerResponse struct {
 	ID         int64  `json:"id"`
 	Name       string `json:"name"`
@@ -45,9 +121,7 @@ type CustomerResponse struct {
 
 func (c *Customer) Create(w http.ResponseWriter, r *http.Request) {
 
-	contextControl := domain.ContextControl{
-		Context: context.Background(),
-	}
+	contextControl := getContextControl(r)
 
 	var customerRequest CustomerRequest
 	json.NewDecoder(r.Body).Decode(&customerRequest)
@@ -59,7 +133,7 @@ func (c *Customer) Create(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.LoggerSugar.Errorw(ErrorToCreateCustomer, "error", err.Error())
 		response := objectResponse(ErrorToCreateCustomer, err.Error())
-		responseReturn(w, http.StatusInternalServerError, response.Bytes())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
 		return
 	}
 
@@ -89,3 +163,307 @@ func (c *Customer) ValidateCreate(w http.ResponseWriter, r *http.Request) {
 	response := objectResponse(customerResponse, SuccessValidateCreateCustomer)
 	responseReturn(w, http.StatusOK, response.Bytes())
 }
+
+func (c *Customer) GetByID(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	var IDRequest, err = strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetCustomer, "error", err.Error())
+		response := objectResponse(ErrorToGetCustomer, err.Error())
+		responseReturn(w, http.StatusInternalServerError, response.Bytes())
+		return
+	}
+
+	customerDomain, exists, err := c.CustomerService.GetByID(contextControl, IDRequest)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetCustomer, "error", err.Error())
+		response := objectResponse(ErrorToGetCustomer, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		c.LoggerSugar.Errorw(CustomerNotFound, "customer_id", IDRequest)
+		response := objectResponse(CustomerNotFound, fmt.Sprintf(CustomerNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	var customerResponse CustomerResponse
+	copier.Copy(&customerResponse, &customerDomain)
+
+	response := objectResponse(customerResponse, SuccessToGetCustomer)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// Update replaces the name, email, document and person type of the customer.
+func (c *Customer) Update(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToUpdateCustomer, "error", err.Error())
+		response := objectResponse(ErrorToUpdateCustomer, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	var customerRequest CustomerRequest
+	if err = json.NewDecoder(r.Body).Decode(&customerRequest); err != nil {
+		c.LoggerSugar.Errorw(ErrorToUpdateCustomer, "error", err.Error())
+		response := objectResponse(ErrorToUpdateCustomer, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	customerDomain, exists, err := c.CustomerService.Update(contextControl, domain.CustomerDomain{
+		ID:         IDRequest,
+		Name:       customerRequest.Name,
+		Email:      customerRequest.Email,
+		Document:   customerRequest.Document,
+		PersonType: customerRequest.PersonType,
+	})
+	c.updateResponse(w, IDRequest, customerDomain, exists, err)
+}
+
+// Patch changes only the fields sent in the body.
+func (c *Customer) Patch(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToUpdateCustomer, "error", err.Error())
+		response := objectResponse(ErrorToUpdateCustomer, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	var patchRequest CustomerPatchRequest
+	if err = json.NewDecoder(r.Body).Decode(&patchRequest); err != nil {
+		c.LoggerSugar.Errorw(ErrorToUpdateCustomer, "error", err.Error())
+		response := objectResponse(ErrorToUpdateCustomer, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	customerDomain, exists, err := c.CustomerService.Patch(contextControl, IDRequest, domain.CustomerPatchDomain{
+		Name:       patchRequest.Name,
+		Email:      patchRequest.Email,
+		Document:   patchRequest.Document,
+		PersonType: patchRequest.PersonType,
+	})
+	c.updateResponse(w, IDRequest, customerDomain, exists, err)
+}
+
+func (c *Customer) updateResponse(w http.ResponseWriter, IDRequest int64, customerDomain domain.CustomerDomain, exists bool, err error) {
+
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToUpdateCustomer, "error", err.Error())
+		response := objectResponse(ErrorToUpdateCustomer, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		c.LoggerSugar.Infow(CustomerNotFound, "customer_id", IDRequest)
+		response := objectResponse(CustomerNotFound, fmt.Sprintf(CustomerNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	var customerResponse CustomerResponse
+	copier.Copy(&customerResponse, &customerDomain)
+	response := objectResponse(customerResponse, SuccessToUpdateCustomer)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// Delete soft deletes the customer; it is no longer found until restored.
+func (c *Customer) Delete(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToDeleteCustomer, "error", err.Error())
+		response := objectResponse(ErrorToDeleteCustomer, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	exists, err := c.CustomerService.Delete(contextControl, IDRequest)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToDeleteCustomer, "error", err.Error())
+		response := objectResponse(ErrorToDeleteCustomer, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		c.LoggerSugar.Infow(CustomerNotFound, "customer_id", IDRequest)
+		response := objectResponse(CustomerNotFound, fmt.Sprintf(CustomerNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(nil, SuccessToDeleteCustomer)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// Restore brings a deleted customer back.
+func (c *Customer) Restore(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToRestoreCustomer, "error", err.Error())
+		response := objectResponse(ErrorToRestoreCustomer, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	customerDomain, exists, err := c.CustomerService.Restore(contextControl, IDRequest)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToRestoreCustomer, "error", err.Error())
+		response := objectResponse(ErrorToRestoreCustomer, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		c.LoggerSugar.Infow(CustomerNotFound, "customer_id", IDRequest)
+		response := objectResponse(CustomerNotFound, fmt.Sprintf(CustomerNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	var customerResponse CustomerResponse
+	copier.Copy(&customerResponse, &customerDomain)
+	response := objectResponse(customerResponse, SuccessToRestoreCustomer)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// List returns a page of the customers filtered by the name, document and email of the query.
+func (c *Customer) List(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	page, err := pageFromRequest(r)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToListCustomers, "error", err.Error())
+		response := objectResponse(ErrorToListCustomers, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	query := r.URL.Query()
+	customers, err := c.CustomerService.List(contextControl, domain.CustomerFilterDomain{
+		Name:     query.Get("name"),
+		Document: query.Get("document"),
+		Email:    query.Get("email"),
+	}, page)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToListCustomers, "error", err.Error())
+		response := objectResponse(ErrorToListCustomers, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	response := objectResponse(newPageResponse(customers, func(customerDomain domain.CustomerDomain) CustomerResponse {
+		var customerResponse CustomerResponse
+		copier.Copy(&customerResponse, &customerDomain)
+		return customerResponse
+	}), SuccessToListCustomers)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// GetHistory returns a page of the changes of the customer, the oldest first.
+func (c *Customer) GetHistory(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetCustomerHistory, "error", err.Error())
+		response := objectResponse(ErrorToGetCustomerHistory, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	page, err := pageFromRequest(r)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetCustomerHistory, "error", err.Error())
+		response := objectResponse(ErrorToGetCustomerHistory, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	history, exists, err := c.CustomerService.GetHistory(contextControl, IDRequest, page)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetCustomerHistory, "error", err.Error())
+		response := objectResponse(ErrorToGetCustomerHistory, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		c.LoggerSugar.Infow(CustomerNotFound, "customer_id", IDRequest)
+		response := objectResponse(CustomerNotFound, fmt.Sprintf(CustomerNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newPageResponse(history, newCustomerHistoryResponse), SuccessToGetCustomerHistory)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// Onboard creates the customer, its address and its phones at once; nothing is created when any of
+// them fails, and every validation failure is answered together.
+func (c *Customer) Onboard(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	var onboardRequest CustomerOnboardRequest
+	if err := json.NewDecoder(r.Body).Decode(&onboardRequest); err != nil {
+		c.LoggerSugar.Errorw(ErrorToOnboardCustomer, "error", err.Error())
+		response := objectResponse(ErrorToOnboardCustomer, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	var onboarding domain.CustomerOnboardingDomain
+	copier.Copy(&onboarding.Customer, &onboardRequest.Customer)
+	copier.Copy(&onboarding.Address, &onboardRequest.Address)
+	for _, phoneRequest := range onboardRequest.Phones {
+		onboarding.Phones = append(onboarding.Phones, domain.PhoneUserDomain{
+			Primary: phoneRequest.Primary,
+			Phone: domain.PhoneDomain{
+				Number:    phoneRequest.Number,
+				CodeArea:  phoneRequest.CodeArea,
+				PhoneType: phoneRequest.PhoneType,
+			},
+		})
+	}
+
+	onboarded, err := c.CustomerOnboardingService.Onboard(contextControl, onboarding)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToOnboardCustomer, "error", err.Error())
+		response := objectResponse(ErrorToOnboardCustomer, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	onboardResponse := CustomerOnboardResponse{Phones: make([]PhoneUserResponse, 0, len(onboarded.Phones))}
+	copier.Copy(&onboardResponse.Customer, &onboarded.Customer)
+	copier.Copy(&onboardResponse.Address, &onboarded.Address)
+	for _, phoneUser := range onboarded.Phones {
+		onboardResponse.Phones = append(onboardResponse.Phones, newPhoneUserResponse(phoneUser))
+	}
+
+	response := objectResponse(onboardResponse, SuccessToOnboardCustomer)
+	responseReturn(w, http.StatusCreated, response.Bytes())
+}
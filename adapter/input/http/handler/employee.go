@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/input"
+	"go.uber.org/zap"
+)
+
+const (
+	SuccessToCreateEmployee     = "employee created with success"
+	SuccessToGetEmployee        = "employee found with success"
+	SuccessToListEmployees      = "employees listed with success"
+	SuccessToUpdateEmployee     = "employee updated with success"
+	SuccessToActivateEmployee   = "employee activated with success"
+	SuccessToDeactivateEmployee = "employee deactivated with success"
+	ErrorToCreateEmployee       = "error to create and process the request"
+	ErrorToGetEmployee          = "error to get an employee by id"
+	ErrorToListEmployees        = "error to list the employees"
+	ErrorToUpdateEmployee       = "error to update the employee"
+	EmployeeNotFound            = "employee not found"
+	EmployeeNotFoundMessage     = "the employee with id %d wasn't found"
+)
+
+type Employee struct {
+	EmployeeService input.IEmployeeService
+	LoggerSugar     *zap.SugaredLogger
+}
+
+type EmployeeRequest struct {
+	Name     string `json:"name"`
+	Register string `json:"register"`
+	Document string `json:"document"`
+}
+
+type EmployeeResponse struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Register    string    `json:"register"`
+	Document    string    `json:"document"`
+	DateCreated time.Time `json:"date_created"`
+	Active      bool      `json:"active"`
+	ContractID  int64     `json:"contract_id"`
+}
+
+func (e EmployeeRequest) toEmployeeDomain() domain.EmployeeDomain {
+	return domain.EmployeeDomain{
+		Name:     e.Name,
+		Register: e.Register,
+		Document: e.Document,
+	}
+}
+
+func newEmployeeResponse(employeeDomain domain.EmployeeDomain) EmployeeResponse {
+	return EmployeeResponse{
+		ID:          employeeDomain.ID,
+		Name:        employeeDomain.Name,
+		Register:    employeeDomain.Register,
+		Document:    employeeDomain.Document,
+		DateCreated: employeeDomain.DateCreated,
+		Active:      employeeDomain.Active,
+		ContractID:  employeeDomain.ContractID,
+	}
+}
+
+func (e *Employee) Create(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	var employeeRequest EmployeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&employeeRequest); err != nil {
+		e.LoggerSugar.Errorw(ErrorToCreateEmployee, "error", err.Error())
+		response := objectResponse(ErrorToCreateEmployee, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	employeeDomain, err := e.EmployeeService.Create(contextControl, employeeRequest.toEmployeeDomain())
+	if err != nil {
+		e.LoggerSugar.Errorw(ErrorToCreateEmployee, "error", err.Error())
+		response := objectResponse(ErrorToCreateEmployee, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	response := objectResponse(newEmployeeResponse(employeeDomain), SuccessToCreateEmployee)
+	responseReturn(w, http.StatusCreated, response.Bytes())
+}
+
+func (e *Employee) GetByID(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		e.LoggerSugar.Errorw(ErrorToGetEmployee, "error", err.Error())
+		response := objectResponse(ErrorToGetEmployee, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	employeeDomain, exists, err := e.EmployeeService.GetByID(contextControl, IDRequest)
+	if err != nil {
+		e.LoggerSugar.Errorw(ErrorToGetEmployee, "error", err.Error())
+		response := objectResponse(ErrorToGetEmployee, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		e.LoggerSugar.Infow(EmployeeNotFound, "employee_id", IDRequest)
+		response := objectResponse(EmployeeNotFound, fmt.Sprintf(EmployeeNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newEmployeeResponse(employeeDomain), SuccessToGetEmployee)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+func (e *Employee) GetAll(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	employeesDomain, err := e.EmployeeService.GetAll(contextControl)
+	if err != nil {
+		e.LoggerSugar.Errorw(ErrorToListEmployees, "error", err.Error())
+		response := objectResponse(ErrorToListEmployees, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	employeesResponse := make([]EmployeeResponse, 0, len(employeesDomain))
+	for _, employeeDomain := range employeesDomain {
+		employeesResponse = append(employeesResponse, newEmployeeResponse(employeeDomain))
+	}
+
+	response := objectResponse(employeesResponse, SuccessToListEmployees)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// Update replaces the name, register and document of an employee. The activation is kept.
+func (e *Employee) Update(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		e.LoggerSugar.Errorw(ErrorToUpdateEmployee, "error", err.Error())
+		response := objectResponse(ErrorToUpdateEmployee, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	var employeeRequest EmployeeRequest
+	if err = json.NewDecoder(r.Body).Decode(&employeeRequest); err != nil {
+		e.LoggerSugar.Errorw(ErrorToUpdateEmployee, "error", err.Error())
+		response := objectResponse(ErrorToUpdateEmployee, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	employeeDomain := employeeRequest.toEmployeeDomain()
+	employeeDomain.ID = IDRequest
+
+	employeeDomain, exists, err := e.EmployeeService.Update(contextControl, employeeDomain)
+	if err != nil {
+		e.LoggerSugar.Errorw(ErrorToUpdateEmployee, "error", err.Error())
+		response := objectResponse(ErrorToUpdateEmployee, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		e.LoggerSugar.Infow(EmployeeNotFound, "employee_id", IDRequest)
+		response := objectResponse(EmployeeNotFound, fmt.Sprintf(EmployeeNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newEmployeeResponse(employeeDomain), SuccessToUpdateEmployee)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+func (e *Employee) Activate(w http.ResponseWriter, r *http.Request) {
+	e.setActive(w, r, true, SuccessToActivateEmployee)
+}
+
+func (e *Employee) Deactivate(w http.ResponseWriter, r *http.Request) {
+	e.setActive(w, r, false, SuccessToDeactivateEmployee)
+}
+
+func (e *Employee) setActive(w http.ResponseWriter, r *http.Request, active bool, successMessage string) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		e.LoggerSugar.Errorw(ErrorToUpdateEmployee, "error", err.Error())
+		response := objectResponse(ErrorToUpdateEmployee, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	employeeDomain, exists, err := e.EmployeeService.SetActive(contextControl, IDRequest, active)
+	if err != nil {
+		e.LoggerSugar.Errorw(ErrorToUpdateEmployee, "error", err.Error())
+		response := objectResponse(ErrorToUpdateEmployee, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		e.LoggerSugar.Infow(EmployeeNotFound, "employee_id", IDRequest)
+		response := objectResponse(EmployeeNotFound, fmt.Sprintf(EmployeeNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newEmployeeResponse(employeeDomain), successMessage)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
@@ -3,11 +3,22 @@ package handler
 import (
 	"net/http"
 
+	"github.com/petshop-system/petshop-api/application/port/input"
 	"go.uber.org/zap"
 )
 
+const (
+	SuccessToGetCacheStats = "cache stats found with success"
+)
+
 type Generic struct {
-	LoggerSugar *zap.SugaredLogger
+	LoggerSugar       *zap.SugaredLogger
+	CacheStatsService input.ICacheStatsService
+}
+
+type CacheStatsResponse struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
 }
 
 func (h *Generic) HealthCheck(w http.ResponseWriter, r *http.Request) {
@@ -19,3 +30,15 @@ func (h *Generic) NotFound(w http.ResponseWriter, r *http.Request) {
 	h.LoggerSugar.Warnw("resource not found")
 	responseReturn(w, http.StatusNotFound, nil)
 }
+
+// CacheStats returns the read-through cache hits and misses counted since the process started.
+func (h *Generic) CacheStats(w http.ResponseWriter, r *http.Request) {
+
+	stats := make(map[string]CacheStatsResponse)
+	for name, cacheStats := range h.CacheStatsService.GetCacheStats() {
+		stats[name] = CacheStatsResponse{Hits: cacheStats.Hits, Misses: cacheStats.Misses}
+	}
+
+	response := objectResponse(stats, SuccessToGetCacheStats)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
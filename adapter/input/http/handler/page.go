@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+const (
+	QueryPageSize = "page_size"
+	QueryCursor   = "cursor"
+
+	PageQueryIsInvalid = "the query parameter %s must be a number"
+)
+
+// PageResponse is a page of a list. NextCursor is passed as the cursor of the query to read the
+// next page and is null on the last one.
+type PageResponse[T any] struct {
+	Items         []T    `json:"items"`
+	NextCursor    *int64 `json:"next_cursor"`
+	TotalEstimate int64  `json:"total_estimate"`
+	TotalCapped   bool   `json:"total_capped"`
+}
+
+func newPageResponse[T, R any](page domain.PageResultDomain[T], convert func(T) R) PageResponse[R] {
+
+	response := PageResponse[R]{
+		Items:         make([]R, 0, len(page.Items)),
+		TotalEstimate: page.TotalEstimate,
+		TotalCapped:   page.TotalCapped,
+	}
+	for _, item := range page.Items {
+		response.Items = append(response.Items, convert(item))
+	}
+	if page.NextCursor != 0 {
+		response.NextCursor = &page.NextCursor
+	}
+
+	return response
+}
+
+// pageFromRequest reads the page_size and cursor of the query, both optional.
+func pageFromRequest(r *http.Request) (domain.PageDomain, error) {
+
+	var page domain.PageDomain
+	query := r.URL.Query()
+
+	if size := query.Get(QueryPageSize); size != "" {
+		value, err := strconv.Atoi(size)
+		if err != nil {
+			return domain.PageDomain{}, domain.NewValidationError(PageQueryIsInvalid, QueryPageSize)
+		}
+		page.Size = value
+	}
+
+	if cursor := query.Get(QueryCursor); cursor != "" {
+		value, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			return domain.PageDomain{}, domain.NewValidationError(PageQueryIsInvalid, QueryCursor)
+		}
+		page.Cursor = value
+	}
+
+	return page, nil
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/input"
+	"go.uber.org/zap"
+)
+
+const (
+	SuccessToCreatePet         = "pet created with success"
+	SuccessToGetPet            = "pet found with success"
+	SuccessToGetPetsByCustomer = "pets of the customer found with success"
+	ErrorToCreatePet           = "error to create and process the request"
+	ErrorToGetPet              = "error to get a pet by id"
+	ErrorToGetPetsByCustomer   = "error to get the pets of a customer"
+	PetNotFound                = "pet not found"
+	PetNotFoundMessage         = "the pet with id %d wasn't found"
+)
+
+// PetDateLayout is the layout used to exchange pet dates with the clients.
+const PetDateLayout = "2006-01-02"
+
+type Pet struct {
+	PetService  input.IPetService
+	LoggerSugar *zap.SugaredLogger
+}
+
+type PetRequest struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Birthday   string `json:"date_birthday"`
+	CustomerID int64  `json:"customer_id"`
+	BreedID    int64  `json:"breed_id"`
+	ContractID int64  `json:"contract_id"`
+}
+
+type PetResponse struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	DateCreated time.Time `json:"date_created"`
+	Birthday    string    `json:"date_birthday"`
+	CustomerID  int64     `json:"customer_id"`
+	BreedID     int64     `json:"breed_id"`
+	ContractID  int64     `json:"contract_id"`
+}
+
+func (p PetRequest) toPetDomain() (domain.PetDomain, error) {
+
+	petDomain := domain.PetDomain{
+		ID:         p.ID,
+		Name:       p.Name,
+		CustomerID: p.CustomerID,
+		BreedID:    p.BreedID,
+		ContractID: p.ContractID,
+	}
+
+	if len(p.Birthday) > 0 {
+		birthday, err := time.Parse(PetDateLayout, p.Birthday)
+		if err != nil {
+			return domain.PetDomain{}, err
+		}
+		petDomain.DateBirthday = birthday
+	}
+
+	return petDomain, nil
+}
+
+func newPetResponse(petDomain domain.PetDomain) PetResponse {
+
+	petResponse := PetResponse{
+		ID:          petDomain.ID,
+		Name:        petDomain.Name,
+		DateCreated: petDomain.DateCreated,
+		CustomerID:  petDomain.CustomerID,
+		BreedID:     petDomain.BreedID,
+		ContractID:  petDomain.ContractID,
+	}
+
+	if !petDomain.DateBirthday.IsZero() {
+		petResponse.Birthday = petDomain.DateBirthday.Format(PetDateLayout)
+	}
+
+	return petResponse
+}
+
+func (c *Pet) Create(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	var petRequest PetRequest
+	if err := json.NewDecoder(r.Body).Decode(&petRequest); err != nil {
+		c.LoggerSugar.Errorw(ErrorToCreatePet, "error", err.Error())
+		response := objectResponse(ErrorToCreatePet, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	petDomain, err := petRequest.toPetDomain()
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToCreatePet, "error", err.Error())
+		response := objectResponse(ErrorToCreatePet, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	petDomain, err = c.PetService.Create(contextControl, petDomain)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToCreatePet, "error", err.Error())
+		response := objectResponse(ErrorToCreatePet, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	response := objectResponse(newPetResponse(petDomain), SuccessToCreatePet)
+	responseReturn(w, http.StatusCreated, response.Bytes())
+}
+
+func (c *Pet) GetByID(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	var IDRequest, err = strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetPet, "error", err.Error())
+		response := objectResponse(ErrorToGetPet, err.Error())
+		responseReturn(w, http.StatusInternalServerError, response.Bytes())
+		return
+	}
+
+	petDomain, exists, err := c.PetService.GetByID(contextControl, IDRequest)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetPet, "error", err.Error())
+		response := objectResponse(ErrorToGetPet, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		c.LoggerSugar.Errorw(PetNotFound, "pet_id", IDRequest)
+		response := objectResponse(PetNotFound, fmt.Sprintf(PetNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newPetResponse(petDomain), SuccessToGetPet)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+func (c *Pet) GetByCustomerID(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	var customerIDRequest, err = strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetPetsByCustomer, "error", err.Error())
+		response := objectResponse(ErrorToGetPetsByCustomer, err.Error())
+		responseReturn(w, http.StatusInternalServerError, response.Bytes())
+		return
+	}
+
+	pets, err := c.PetService.GetByCustomerID(contextControl, customerIDRequest)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToGetPetsByCustomer, "error", err.Error())
+		response := objectResponse(ErrorToGetPetsByCustomer, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	petsResponse := make([]PetResponse, 0, len(pets))
+	for _, pet := range pets {
+		petsResponse = append(petsResponse, newPetResponse(pet))
+	}
+
+	response := objectResponse(petsResponse, SuccessToGetPetsByCustomer)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
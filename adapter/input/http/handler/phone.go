@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -21,6 +20,21 @@ const (
 	ErrorToGetPhone      = "error retrieving phone by ID" //TODO: Adjust error messages to this model
 	PhoneNotFound        = "phone not found"
 	PhoneNotFoundMessage = "the phone with id %d wasn't found"
+
+	SuccessToAttachPhone      = "phone attached with success"
+	SuccessToDetachPhone      = "phone detached with success"
+	SuccessToListOwnerPhones  = "phones of the owner found with success"
+	SuccessToSetPrimaryPhone  = "primary phone set with success"
+	ErrorToAttachPhone        = "error to attach the phone"
+	ErrorToDetachPhone        = "error to detach the phone"
+	ErrorToListOwnerPhones    = "error to list the phones of the owner"
+	ErrorToSetPrimaryPhone    = "error to set the primary phone"
+	PhoneNotLinked            = "phone not linked"
+	PhoneNotLinkedMessage     = "the phone with id %d isn't linked to any owner"
+	PhoneOwnerNotFound        = "phone owner not found"
+	PhoneOwnerNotFoundMessage = "the %s with id %d wasn't found"
+	SuccessToListPhones       = "phones found with success"
+	ErrorToListPhones         = "error to list the phones"
 )
 
 type Phone struct {
@@ -42,11 +56,43 @@ type PhoneResponse struct {
 	PhoneType      string `json:"phone_type"`
 }
 
-func (c *Phone) Create(w http.ResponseWriter, r *http.Request) {
+// PhoneUserRequest is the owner a phone is attached to, a contract, customer or employee.
+type PhoneUserRequest struct {
+	UserID   int64  `json:"user_id"`
+	UserType string `json:"user_type"`
+	Primary  bool   `json:"primary"`
+}
 
-	contextControl := domain.ContextControl{
-		Context: context.Background(),
+type PhoneUserResponse struct {
+	PhoneID  int64         `json:"phone_id"`
+	UserID   int64         `json:"user_id"`
+	UserType string        `json:"user_type"`
+	Primary  bool          `json:"primary"`
+	Phone    PhoneResponse `json:"phone"`
+}
+
+func newPhoneUserResponse(phoneUserDomain domain.PhoneUserDomain) PhoneUserResponse {
+	return PhoneUserResponse{
+		PhoneID:  phoneUserDomain.PhoneID,
+		UserID:   phoneUserDomain.UserID,
+		UserType: phoneUserDomain.UserType,
+		Primary:  phoneUserDomain.Primary,
+		Phone:    newPhoneResponse(phoneUserDomain.Phone),
 	}
+}
+
+func newPhoneResponse(phoneDomain domain.PhoneDomain) PhoneResponse {
+	return PhoneResponse{
+		ID:             phoneDomain.ID,
+		Number:         phoneDomain.Number,
+		CodeAreaNumber: phoneDomain.CodeArea,
+		PhoneType:      phoneDomain.PhoneType,
+	}
+}
+
+func (c *Phone) Create(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
 
 	var phoneRequest PhoneRequest
 	json.NewDecoder(r.Body).Decode(&phoneRequest)
@@ -58,7 +104,7 @@ func (c *Phone) Create(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.LoggerSugar.Errorw(ErrorToCreatePhone, "error", err.Error())
 		response := objectResponse(ErrorToCreatePhone, err.Error())
-		responseReturn(w, http.StatusInternalServerError, response.Bytes())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
 		return
 	}
 
@@ -69,9 +115,7 @@ func (c *Phone) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (c *Phone) GetByID(w http.ResponseWriter, r *http.Request) {
-	contextControl := domain.ContextControl{
-		Context: context.Background(),
-	}
+	contextControl := getContextControl(r)
 
 	var IDRequest, err = strconv.ParseInt(chi.URLParam(r, "id"), 10, 64) //TODO: I will create a function to streamline this step in an upcoming PR.
 	if err != nil {
@@ -85,7 +129,7 @@ func (c *Phone) GetByID(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.LoggerSugar.Errorw(ErrorToGetPhone, "error", err.Error())
 		response := objectResponse(ErrorToGetPhone, err.Error())
-		responseReturn(w, http.StatusInternalServerError, response.Bytes())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
 		return
 	}
 
@@ -101,3 +145,177 @@ func (c *Phone) GetByID(w http.ResponseWriter, r *http.Request) {
 	response := objectResponse(phoneResponse, SuccessToGetPhone)
 	responseReturn(w, http.StatusOK, response.Bytes())
 }
+
+// Attach links the phone to the owner of the body.
+func (c *Phone) Attach(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToAttachPhone, "error", err.Error())
+		response := objectResponse(ErrorToAttachPhone, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	var phoneUserRequest PhoneUserRequest
+	if err = json.NewDecoder(r.Body).Decode(&phoneUserRequest); err != nil {
+		c.LoggerSugar.Errorw(ErrorToAttachPhone, "error", err.Error())
+		response := objectResponse(ErrorToAttachPhone, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	phoneUserDomain, exists, err := c.PhoneService.Attach(contextControl, domain.PhoneUserDomain{
+		PhoneID:  IDRequest,
+		UserID:   phoneUserRequest.UserID,
+		UserType: phoneUserRequest.UserType,
+		Primary:  phoneUserRequest.Primary,
+	})
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToAttachPhone, "error", err.Error())
+		response := objectResponse(ErrorToAttachPhone, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		c.LoggerSugar.Infow(PhoneNotFound, "phone_id", IDRequest)
+		response := objectResponse(PhoneNotFound, fmt.Sprintf(PhoneNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newPhoneUserResponse(phoneUserDomain), SuccessToAttachPhone)
+	responseReturn(w, http.StatusCreated, response.Bytes())
+}
+
+// Detach unlinks the phone from its owner.
+func (c *Phone) Detach(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToDetachPhone, "error", err.Error())
+		response := objectResponse(ErrorToDetachPhone, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	linked, err := c.PhoneService.Detach(contextControl, IDRequest)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToDetachPhone, "error", err.Error())
+		response := objectResponse(ErrorToDetachPhone, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !linked {
+		c.LoggerSugar.Infow(PhoneNotLinked, "phone_id", IDRequest)
+		response := objectResponse(PhoneNotLinked, fmt.Sprintf(PhoneNotLinkedMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(nil, SuccessToDetachPhone)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// GetByOwner lists the phones of an owner, the primary one first.
+func (c *Phone) GetByOwner(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	userType := chi.URLParam(r, "user_type")
+	userID, err := strconv.ParseInt(chi.URLParam(r, "user_id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToListOwnerPhones, "error", err.Error())
+		response := objectResponse(ErrorToListOwnerPhones, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	phonesUserDomain, exists, err := c.PhoneService.GetByOwner(contextControl, userType, userID)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToListOwnerPhones, "error", err.Error())
+		response := objectResponse(ErrorToListOwnerPhones, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		c.LoggerSugar.Infow(PhoneOwnerNotFound, "user_type", userType, "user_id", userID)
+		response := objectResponse(PhoneOwnerNotFound, fmt.Sprintf(PhoneOwnerNotFoundMessage, userType, userID))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	phonesUserResponse := make([]PhoneUserResponse, 0, len(phonesUserDomain))
+	for _, phoneUserDomain := range phonesUserDomain {
+		phonesUserResponse = append(phonesUserResponse, newPhoneUserResponse(phoneUserDomain))
+	}
+
+	response := objectResponse(phonesUserResponse, SuccessToListOwnerPhones)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// SetPrimary makes the phone the primary contact number of its owner.
+func (c *Phone) SetPrimary(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToSetPrimaryPhone, "error", err.Error())
+		response := objectResponse(ErrorToSetPrimaryPhone, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	phoneUserDomain, linked, err := c.PhoneService.SetPrimary(contextControl, IDRequest)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToSetPrimaryPhone, "error", err.Error())
+		response := objectResponse(ErrorToSetPrimaryPhone, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !linked {
+		c.LoggerSugar.Infow(PhoneNotLinked, "phone_id", IDRequest)
+		response := objectResponse(PhoneNotLinked, fmt.Sprintf(PhoneNotLinkedMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newPhoneUserResponse(phoneUserDomain), SuccessToSetPrimaryPhone)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// List returns a page of the phones filtered by the number of the query.
+func (c *Phone) List(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	page, err := pageFromRequest(r)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToListPhones, "error", err.Error())
+		response := objectResponse(ErrorToListPhones, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	phones, err := c.PhoneService.List(contextControl, domain.PhoneFilterDomain{
+		Number: r.URL.Query().Get("number"),
+	}, page)
+	if err != nil {
+		c.LoggerSugar.Errorw(ErrorToListPhones, "error", err.Error())
+		response := objectResponse(ErrorToListPhones, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	response := objectResponse(newPageResponse(phones, newPhoneResponse), SuccessToListPhones)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
@@ -0,0 +1,325 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/input"
+	"go.uber.org/zap"
+)
+
+// AvailabilityDateLayout is the layout of the dates of the availability, the same of the bookings.
+const AvailabilityDateLayout = "2006-01-02"
+
+const (
+	SuccessToGetAvailability    = "availability found with success"
+	SuccessToGetSchedule        = "schedule found with success"
+	SuccessToGetScheduleHistory = "schedule history listed with success"
+	SuccessToConfirmSchedule    = "schedule confirmed with success"
+	SuccessToRescheduleSchedule = "schedule rescheduled with success"
+	SuccessToDeclineSchedule    = "schedule declined with success"
+	SuccessToCancelSchedule     = "schedule cancelled with success"
+	SuccessToCompleteSchedule   = "schedule completed with success"
+	SuccessToNoShowSchedule     = "schedule marked as no-show with success"
+	ErrorToGetAvailability      = "error to get the availability"
+	ErrorAvailabilityParameters = "service_id, from and to are required, with the dates as YYYY-MM-DD"
+	ErrorToGetSchedule          = "error to get a schedule by id"
+	ErrorToGetScheduleHistory   = "error to get the history of the schedule"
+	ErrorToChangeSchedule       = "error to change the status of the schedule"
+	ScheduleNotFound            = "schedule not found"
+	ScheduleNotFoundMessage     = "the schedule with id %d wasn't found"
+)
+
+type Schedule struct {
+	ScheduleService     input.IScheduleService
+	AvailabilityService input.IAvailabilityService
+	LoggerSugar         *zap.SugaredLogger
+}
+
+// ScheduleCommandRequest is the body of the lifecycle actions. Booking and attention_time_id are
+// only read by the reschedule. The actor comes from the X-Actor header, not from the body.
+type ScheduleCommandRequest struct {
+	Reason          string `json:"reason"`
+	Booking         string `json:"booking"`
+	AttentionTimeID int64  `json:"attention_time_id"`
+}
+
+type ScheduleResponse struct {
+	ID              int64        `json:"id"`
+	Number          string       `json:"number"`
+	Status          string       `json:"status"`
+	BookedAt        string       `json:"booked_at"`
+	Price           domain.Money `json:"price"`
+	PetID           int64        `json:"pet_id"`
+	AttentionTimeID int64        `json:"attention_time_id"`
+	DateCreated     time.Time    `json:"date_created"`
+	DateDeclined    *time.Time   `json:"date_declined,omitempty"`
+}
+
+type ScheduleHistoryResponse struct {
+	ID                      int64     `json:"id"`
+	Action                  string    `json:"action"`
+	FromStatus              string    `json:"from_status,omitempty"`
+	ToStatus                string    `json:"to_status"`
+	Actor                   string    `json:"actor"`
+	Reason                  string    `json:"reason,omitempty"`
+	PreviousBookedAt        string    `json:"previous_booked_at,omitempty"`
+	PreviousAttentionTimeID int64     `json:"previous_attention_time_id,omitempty"`
+	DateCreated             time.Time `json:"date_created"`
+}
+
+func (s ScheduleCommandRequest) toScheduleCommand(ID int64, action domain.ScheduleAction, actor string) domain.ScheduleCommand {
+	return domain.ScheduleCommand{
+		ScheduleID:      ID,
+		Action:          action,
+		Actor:           actor,
+		Reason:          s.Reason,
+		Booking:         s.Booking,
+		AttentionTimeID: s.AttentionTimeID,
+	}
+}
+
+func newScheduleResponse(scheduleDomain domain.ScheduleDomain) ScheduleResponse {
+	return ScheduleResponse{
+		ID:              scheduleDomain.ID,
+		Number:          scheduleDomain.Number,
+		Status:          string(scheduleDomain.Status),
+		BookedAt:        scheduleDomain.BookedAt.Format(AvailabilityDateLayout),
+		Price:           scheduleDomain.Price,
+		PetID:           scheduleDomain.PetID,
+		AttentionTimeID: scheduleDomain.AttentionTimeID,
+		DateCreated:     scheduleDomain.DateCreated,
+		DateDeclined:    scheduleDomain.DateDeclined,
+	}
+}
+
+func newScheduleHistoryResponse(historyDomain domain.ScheduleHistoryDomain) ScheduleHistoryResponse {
+
+	historyResponse := ScheduleHistoryResponse{
+		ID:                      historyDomain.ID,
+		Action:                  string(historyDomain.Action),
+		FromStatus:              string(historyDomain.FromStatus),
+		ToStatus:                string(historyDomain.ToStatus),
+		Actor:                   historyDomain.Actor,
+		Reason:                  historyDomain.Reason,
+		PreviousAttentionTimeID: historyDomain.PreviousAttentionTimeID,
+		DateCreated:             historyDomain.DateCreated,
+	}
+
+	if historyDomain.PreviousBookedAt != nil {
+		historyResponse.PreviousBookedAt = historyDomain.PreviousBookedAt.Format(AvailabilityDateLayout)
+	}
+
+	return historyResponse
+}
+
+type AvailableSlotResponse struct {
+	Date            string `json:"date"`
+	AttentionTimeID int64  `json:"attention_time_id"`
+	InitialTime     string `json:"initial_time"`
+	FinalTime       string `json:"final_time"`
+}
+
+type EmployeeAvailabilityResponse struct {
+	EmployeeID   int64                   `json:"employee_id"`
+	EmployeeName string                  `json:"employee_name"`
+	Slots        []AvailableSlotResponse `json:"slots"`
+}
+
+func newEmployeeAvailabilityResponse(availabilityDomain domain.EmployeeAvailabilityDomain) EmployeeAvailabilityResponse {
+
+	availabilityResponse := EmployeeAvailabilityResponse{
+		EmployeeID:   availabilityDomain.EmployeeID,
+		EmployeeName: availabilityDomain.EmployeeName,
+		Slots:        make([]AvailableSlotResponse, 0, len(availabilityDomain.Slots)),
+	}
+
+	for _, slot := range availabilityDomain.Slots {
+		availabilityResponse.Slots = append(availabilityResponse.Slots, AvailableSlotResponse{
+			Date:            slot.Date.Format(AvailabilityDateLayout),
+			AttentionTimeID: slot.AttentionTimeID,
+			InitialTime:     slot.InitialTime,
+			FinalTime:       slot.FinalTime,
+		})
+	}
+
+	return availabilityResponse
+}
+
+// Availability lists, per employee, the free attention times of a service between two dates,
+// e.g. /schedule/availability?service_id=2&from=2024-03-04&to=2024-03-10.
+func (s *Schedule) Availability(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	query := r.URL.Query()
+	serviceID, errID := strconv.ParseInt(query.Get("service_id"), 10, 64)
+	from, errFrom := time.Parse(AvailabilityDateLayout, query.Get("from"))
+	to, errTo := time.Parse(AvailabilityDateLayout, query.Get("to"))
+	if errID != nil || errFrom != nil || errTo != nil {
+		s.LoggerSugar.Infow(ErrorToGetAvailability, "query", r.URL.RawQuery)
+		response := objectResponse(ErrorToGetAvailability, ErrorAvailabilityParameters)
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	availabilityDomain, exists, err := s.AvailabilityService.GetAvailability(contextControl, serviceID, from, to)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToGetAvailability, "error", err.Error())
+		response := objectResponse(ErrorToGetAvailability, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		s.LoggerSugar.Infow(ServiceNotFound, "service_id", serviceID)
+		response := objectResponse(ServiceNotFound, fmt.Sprintf(ServiceNotFoundMessage, serviceID))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	availabilityResponse := make([]EmployeeAvailabilityResponse, 0, len(availabilityDomain))
+	for _, employeeAvailability := range availabilityDomain {
+		availabilityResponse = append(availabilityResponse, newEmployeeAvailabilityResponse(employeeAvailability))
+	}
+
+	response := objectResponse(availabilityResponse, SuccessToGetAvailability)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+func (s *Schedule) GetByID(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToGetSchedule, "error", err.Error())
+		response := objectResponse(ErrorToGetSchedule, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	scheduleDomain, exists, err := s.ScheduleService.GetByID(contextControl, IDRequest)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToGetSchedule, "error", err.Error())
+		response := objectResponse(ErrorToGetSchedule, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		s.LoggerSugar.Infow(ScheduleNotFound, "schedule_id", IDRequest)
+		response := objectResponse(ScheduleNotFound, fmt.Sprintf(ScheduleNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newScheduleResponse(scheduleDomain), SuccessToGetSchedule)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// GetHistory lists the transitions of a schedule, oldest first.
+func (s *Schedule) GetHistory(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToGetScheduleHistory, "error", err.Error())
+		response := objectResponse(ErrorToGetScheduleHistory, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	historiesDomain, exists, err := s.ScheduleService.GetHistory(contextControl, IDRequest)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToGetScheduleHistory, "error", err.Error())
+		response := objectResponse(ErrorToGetScheduleHistory, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		s.LoggerSugar.Infow(ScheduleNotFound, "schedule_id", IDRequest)
+		response := objectResponse(ScheduleNotFound, fmt.Sprintf(ScheduleNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	historiesResponse := make([]ScheduleHistoryResponse, 0, len(historiesDomain))
+	for _, historyDomain := range historiesDomain {
+		historiesResponse = append(historiesResponse, newScheduleHistoryResponse(historyDomain))
+	}
+
+	response := objectResponse(historiesResponse, SuccessToGetScheduleHistory)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+func (s *Schedule) Confirm(w http.ResponseWriter, r *http.Request) {
+	s.applyCommand(w, r, domain.ScheduleActionConfirm, SuccessToConfirmSchedule)
+}
+
+// Reschedule moves the schedule to the booking and attention_time_id of the body.
+func (s *Schedule) Reschedule(w http.ResponseWriter, r *http.Request) {
+	s.applyCommand(w, r, domain.ScheduleActionReschedule, SuccessToRescheduleSchedule)
+}
+
+func (s *Schedule) Decline(w http.ResponseWriter, r *http.Request) {
+	s.applyCommand(w, r, domain.ScheduleActionDecline, SuccessToDeclineSchedule)
+}
+
+func (s *Schedule) Cancel(w http.ResponseWriter, r *http.Request) {
+	s.applyCommand(w, r, domain.ScheduleActionCancel, SuccessToCancelSchedule)
+}
+
+func (s *Schedule) Complete(w http.ResponseWriter, r *http.Request) {
+	s.applyCommand(w, r, domain.ScheduleActionComplete, SuccessToCompleteSchedule)
+}
+
+func (s *Schedule) NoShow(w http.ResponseWriter, r *http.Request) {
+	s.applyCommand(w, r, domain.ScheduleActionNoShow, SuccessToNoShowSchedule)
+}
+
+func (s *Schedule) applyCommand(w http.ResponseWriter, r *http.Request, action domain.ScheduleAction, successMessage string) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToChangeSchedule, "error", err.Error())
+		response := objectResponse(ErrorToChangeSchedule, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	var commandRequest ScheduleCommandRequest
+	if err = json.NewDecoder(r.Body).Decode(&commandRequest); err != nil {
+		s.LoggerSugar.Errorw(ErrorToChangeSchedule, "error", err.Error())
+		response := objectResponse(ErrorToChangeSchedule, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	scheduleDomain, exists, err := s.ScheduleService.ApplyCommand(contextControl, commandRequest.toScheduleCommand(IDRequest, action, contextControl.Actor))
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToChangeSchedule, "schedule_id", IDRequest, "action", action, "error", err.Error())
+		response := objectResponse(ErrorToChangeSchedule, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		s.LoggerSugar.Infow(ScheduleNotFound, "schedule_id", IDRequest)
+		response := objectResponse(ScheduleNotFound, fmt.Sprintf(ScheduleNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newScheduleResponse(scheduleDomain), successMessage)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
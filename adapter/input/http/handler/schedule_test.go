@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/service"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestSchedule_Confirm(t *testing.T) {
+
+	t.Run("WithActorHeader_RecordsItAndIgnoresTheBody", func(t *testing.T) {
+
+		var command domain.ScheduleCommand
+		handler := Schedule{
+			ScheduleService: service.ScheduleMock{
+				ApplyCommandMock: func(contextControl domain.ContextControl, received domain.ScheduleCommand) (domain.ScheduleDomain, bool, error) {
+					command = received
+					return domain.ScheduleDomain{ID: received.ScheduleID, Status: domain.ScheduleStatusConfirmed}, true, nil
+				},
+			},
+			LoggerSugar: zap.NewNop().Sugar(),
+		}
+
+		router := chi.NewRouter()
+		router.With(ContextRequest(time.Second)).Put("/schedule/confirm/{id}", handler.Confirm)
+
+		request := httptest.NewRequest(http.MethodPut, "/schedule/confirm/10",
+			bytes.NewBufferString(`{"actor": "someone-else", "reason": "called"}`))
+		request.Header.Set(HeaderActor, "fulana")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, domain.ScheduleCommand{ScheduleID: 10, Action: domain.ScheduleActionConfirm,
+			Actor: "fulana", Reason: "called"}, command)
+	})
+}
@@ -0,0 +1,281 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/input"
+	"go.uber.org/zap"
+)
+
+const (
+	SuccessToCreateService     = "service created with success"
+	SuccessToGetService        = "service found with success"
+	SuccessToListServices      = "services listed with success"
+	SuccessToListServicePrices = "service price history listed with success"
+	SuccessToUpdateService     = "service updated with success"
+	SuccessToActivateService   = "service activated with success"
+	SuccessToDeactivateService = "service deactivated with success"
+	ErrorToCreateService       = "error to create and process the request"
+	ErrorToGetService          = "error to get a service by id"
+	ErrorToListServices        = "error to list the services"
+	ErrorToListServicePrices   = "error to list the price history of the service"
+	ErrorToUpdateService       = "error to update the service"
+	ServiceNotFound            = "service not found"
+	ServiceNotFoundMessage     = "the service with id %d wasn't found"
+)
+
+type ServiceCatalog struct {
+	ServiceCatalogService input.IServiceCatalogService
+	LoggerSugar           *zap.SugaredLogger
+}
+
+type ServiceRequest struct {
+	Name            string       `json:"name"`
+	Price           domain.Money `json:"price"`
+	Description     string       `json:"description"`
+	DurationMinutes int          `json:"duration_minutes"`
+}
+
+type ServiceResponse struct {
+	ID              int64        `json:"id"`
+	Name            string       `json:"name"`
+	Price           domain.Money `json:"price"`
+	Active          bool         `json:"active"`
+	Description     string       `json:"description"`
+	DurationMinutes int          `json:"duration_minutes"`
+	ContractID      int64        `json:"contract_id"`
+}
+
+type ServicePriceResponse struct {
+	ID          int64        `json:"id"`
+	ServiceID   int64        `json:"service_id"`
+	Price       domain.Money `json:"price"`
+	DateCreated time.Time    `json:"date_created"`
+}
+
+func (s ServiceRequest) toServiceDomain() domain.ServiceDomain {
+	return domain.ServiceDomain{
+		Name:            s.Name,
+		Price:           s.Price,
+		Description:     s.Description,
+		DurationMinutes: s.DurationMinutes,
+	}
+}
+
+func newServiceResponse(serviceDomain domain.ServiceDomain) ServiceResponse {
+	return ServiceResponse{
+		ID:              serviceDomain.ID,
+		Name:            serviceDomain.Name,
+		Price:           serviceDomain.Price,
+		Active:          serviceDomain.Active,
+		Description:     serviceDomain.Description,
+		DurationMinutes: serviceDomain.DurationMinutes,
+		ContractID:      serviceDomain.ContractID,
+	}
+}
+
+func (s *ServiceCatalog) Create(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	var serviceRequest ServiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&serviceRequest); err != nil {
+		s.LoggerSugar.Errorw(ErrorToCreateService, "error", err.Error())
+		response := objectResponse(ErrorToCreateService, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	serviceDomain, err := s.ServiceCatalogService.Create(contextControl, serviceRequest.toServiceDomain())
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToCreateService, "error", err.Error())
+		response := objectResponse(ErrorToCreateService, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	response := objectResponse(newServiceResponse(serviceDomain), SuccessToCreateService)
+	responseReturn(w, http.StatusCreated, response.Bytes())
+}
+
+func (s *ServiceCatalog) GetByID(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToGetService, "error", err.Error())
+		response := objectResponse(ErrorToGetService, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	serviceDomain, exists, err := s.ServiceCatalogService.GetByID(contextControl, IDRequest)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToGetService, "error", err.Error())
+		response := objectResponse(ErrorToGetService, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		s.LoggerSugar.Infow(ServiceNotFound, "service_id", IDRequest)
+		response := objectResponse(ServiceNotFound, fmt.Sprintf(ServiceNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newServiceResponse(serviceDomain), SuccessToGetService)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+func (s *ServiceCatalog) GetAll(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	servicesDomain, err := s.ServiceCatalogService.GetAll(contextControl)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToListServices, "error", err.Error())
+		response := objectResponse(ErrorToListServices, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	servicesResponse := make([]ServiceResponse, 0, len(servicesDomain))
+	for _, serviceDomain := range servicesDomain {
+		servicesResponse = append(servicesResponse, newServiceResponse(serviceDomain))
+	}
+
+	response := objectResponse(servicesResponse, SuccessToListServices)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// Update replaces the name, price, description and duration of a service. The activation is kept.
+func (s *ServiceCatalog) Update(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToUpdateService, "error", err.Error())
+		response := objectResponse(ErrorToUpdateService, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	var serviceRequest ServiceRequest
+	if err = json.NewDecoder(r.Body).Decode(&serviceRequest); err != nil {
+		s.LoggerSugar.Errorw(ErrorToUpdateService, "error", err.Error())
+		response := objectResponse(ErrorToUpdateService, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	serviceDomain := serviceRequest.toServiceDomain()
+	serviceDomain.ID = IDRequest
+
+	serviceDomain, exists, err := s.ServiceCatalogService.Update(contextControl, serviceDomain)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToUpdateService, "error", err.Error())
+		response := objectResponse(ErrorToUpdateService, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		s.LoggerSugar.Infow(ServiceNotFound, "service_id", IDRequest)
+		response := objectResponse(ServiceNotFound, fmt.Sprintf(ServiceNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newServiceResponse(serviceDomain), SuccessToUpdateService)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+func (s *ServiceCatalog) Activate(w http.ResponseWriter, r *http.Request) {
+	s.setActive(w, r, true, SuccessToActivateService)
+}
+
+func (s *ServiceCatalog) Deactivate(w http.ResponseWriter, r *http.Request) {
+	s.setActive(w, r, false, SuccessToDeactivateService)
+}
+
+func (s *ServiceCatalog) setActive(w http.ResponseWriter, r *http.Request, active bool, successMessage string) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToUpdateService, "error", err.Error())
+		response := objectResponse(ErrorToUpdateService, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	serviceDomain, exists, err := s.ServiceCatalogService.SetActive(contextControl, IDRequest, active)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToUpdateService, "error", err.Error())
+		response := objectResponse(ErrorToUpdateService, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		s.LoggerSugar.Infow(ServiceNotFound, "service_id", IDRequest)
+		response := objectResponse(ServiceNotFound, fmt.Sprintf(ServiceNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	response := objectResponse(newServiceResponse(serviceDomain), successMessage)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
+
+// GetPriceHistory lists the prices a service had, the latest first.
+func (s *ServiceCatalog) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+
+	contextControl := getContextControl(r)
+
+	IDRequest, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToListServicePrices, "error", err.Error())
+		response := objectResponse(ErrorToListServicePrices, err.Error())
+		responseReturn(w, http.StatusBadRequest, response.Bytes())
+		return
+	}
+
+	priceHistory, exists, err := s.ServiceCatalogService.GetPriceHistory(contextControl, IDRequest)
+	if err != nil {
+		s.LoggerSugar.Errorw(ErrorToListServicePrices, "error", err.Error())
+		response := objectResponse(ErrorToListServicePrices, err.Error())
+		responseReturn(w, statusCodeFromError(err, http.StatusInternalServerError), response.Bytes())
+		return
+	}
+
+	if !exists {
+		s.LoggerSugar.Infow(ServiceNotFound, "service_id", IDRequest)
+		response := objectResponse(ServiceNotFound, fmt.Sprintf(ServiceNotFoundMessage, IDRequest))
+		responseReturn(w, http.StatusNotFound, response.Bytes())
+		return
+	}
+
+	pricesResponse := make([]ServicePriceResponse, 0, len(priceHistory))
+	for _, price := range priceHistory {
+		pricesResponse = append(pricesResponse, ServicePriceResponse{
+			ID:          price.ID,
+			ServiceID:   price.ServiceID,
+			Price:       price.Price,
+			DateCreated: price.DateCreated,
+		})
+	}
+
+	response := objectResponse(pricesResponse, SuccessToListServicePrices)
+	responseReturn(w, http.StatusOK, response.Bytes())
+}
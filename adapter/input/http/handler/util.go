@@ -2,9 +2,13 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
 )
 
 func responseReturn(w http.ResponseWriter, statusCode int, body []byte) {
@@ -31,3 +35,17 @@ func objectResponse(obj any, message string) *bytes.Buffer {
 	json.NewEncoder(body).Encode(response)
 	return body
 }
+
+// statusCodeFromError maps the domain error kinds to their HTTP status, falling back to defaultStatusCode.
+func statusCodeFromError(err error, defaultStatusCode int) int {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return defaultStatusCode
+	}
+}
@@ -28,6 +28,72 @@ func (router Router) AddGroupHandlerHealthCheck(ah *handler.Generic) func(r chi.
 	return func(r chi.Router) {
 		r.Route("/health-check", func(r chi.Router) {
 			r.Get("/", ah.HealthCheck)
+			r.Get("/cache", ah.CacheStats)
+		})
+	}
+}
+
+func (router Router) AddGroupHandlerContract(ah *handler.Contract) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Route("/contract", func(r chi.Router) {
+			r.Post("/create", ah.Create)
+			r.Get("/search/{id}", ah.GetByID)
+			r.Put("/update/{id}", ah.Update)
+		})
+	}
+}
+
+func (router Router) AddGroupHandlerEmployee(ah *handler.Employee) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Route("/employee", func(r chi.Router) {
+			r.Post("/create", ah.Create)
+			r.Get("/search/{id}", ah.GetByID)
+			r.Get("/list", ah.GetAll)
+			r.Put("/update/{id}", ah.Update)
+			r.Put("/activate/{id}", ah.Activate)
+			r.Put("/deactivate/{id}", ah.Deactivate)
+		})
+	}
+}
+
+func (router Router) AddGroupHandlerServiceCatalog(ah *handler.ServiceCatalog) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Route("/service", func(r chi.Router) {
+			r.Post("/create", ah.Create)
+			r.Get("/search/{id}", ah.GetByID)
+			r.Get("/list", ah.GetAll)
+			r.Get("/price-history/{id}", ah.GetPriceHistory)
+			r.Put("/update/{id}", ah.Update)
+			r.Put("/activate/{id}", ah.Activate)
+			r.Put("/deactivate/{id}", ah.Deactivate)
+		})
+	}
+}
+
+func (router Router) AddGroupHandlerSchedule(ah *handler.Schedule) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Route("/schedule", func(r chi.Router) {
+			r.Get("/availability", ah.Availability)
+			r.Get("/search/{id}", ah.GetByID)
+			r.Get("/history/{id}", ah.GetHistory)
+			r.Put("/confirm/{id}", ah.Confirm)
+			r.Put("/reschedule/{id}", ah.Reschedule)
+			r.Put("/decline/{id}", ah.Decline)
+			r.Put("/cancel/{id}", ah.Cancel)
+			r.Put("/complete/{id}", ah.Complete)
+			r.Put("/no-show/{id}", ah.NoShow)
+		})
+	}
+}
+
+func (router Router) AddGroupHandlerAttentionTime(ah *handler.AttentionTime) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Route("/attention-time", func(r chi.Router) {
+			r.Post("/create", ah.Create)
+			r.Get("/search/{id}", ah.GetByID)
+			r.Get("/employee/{id}", ah.GetByEmployeeID)
+			r.Put("/activate/{id}", ah.Activate)
+			r.Put("/deactivate/{id}", ah.Deactivate)
 		})
 	}
 }
@@ -35,8 +101,16 @@ func (router Router) AddGroupHandlerHealthCheck(ah *handler.Generic) func(r chi.
 func (router Router) AddGroupHandlerCustomer(ah *handler.Customer) func(r chi.Router) {
 	return func(r chi.Router) {
 		r.Route("/customer", func(r chi.Router) {
+			r.Get("/", ah.List)
 			r.Post("/validate-create", ah.ValidateCreate)
 			r.Post("/create", ah.Create)
+			r.Post("/onboard", ah.Onboard)
+			r.Get("/search/{id}", ah.GetByID)
+			r.Put("/update/{id}", ah.Update)
+			r.Patch("/update/{id}", ah.Patch)
+			r.Delete("/delete/{id}", ah.Delete)
+			r.Put("/restore/{id}", ah.Restore)
+			r.Get("/{id}/history", ah.GetHistory)
 		})
 	}
 }
@@ -44,6 +118,7 @@ func (router Router) AddGroupHandlerCustomer(ah *handler.Customer) func(r chi.Ro
 func (router Router) AddGroupHandlerAddress(ah *handler.Address) func(r chi.Router) {
 	return func(r chi.Router) {
 		r.Route("/address", func(r chi.Router) {
+			r.Get("/", ah.List)
 			r.Post("/create", ah.Create)
 			r.Get("/search/{id}", ah.GetByID)
 		})
@@ -53,8 +128,34 @@ func (router Router) AddGroupHandlerAddress(ah *handler.Address) func(r chi.Rout
 func (router Router) AddGroupHandlerPhone(ah *handler.Phone) func(r chi.Router) {
 	return func(r chi.Router) {
 		r.Route("/phone", func(r chi.Router) {
+			r.Get("/", ah.List)
 			r.Post("/create", ah.Create)
 			r.Get("/search/{id}", ah.GetByID)
+			r.Get("/owner/{user_type}/{user_id}", ah.GetByOwner)
+			r.Post("/attach/{id}", ah.Attach)
+			r.Delete("/detach/{id}", ah.Detach)
+			r.Put("/primary/{id}", ah.SetPrimary)
+		})
+	}
+}
+
+func (router Router) AddGroupHandlerPet(ah *handler.Pet) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Route("/pet", func(r chi.Router) {
+			r.Post("/create", ah.Create)
+			r.Get("/search/{id}", ah.GetByID)
+		})
+		r.Get("/customer/{id}/pets", ah.GetByCustomerID)
+	}
+}
+
+func (router Router) AddGroupHandlerCatalog(ah *handler.Catalog) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Route("/catalog", func(r chi.Router) {
+			r.Get("/species", ah.GetSpecies)
+			r.Get("/species/{id}/breeds", ah.GetBreedsBySpeciesID)
+			r.Post("/species/create", ah.CreateSpecies)
+			r.Post("/breed/create", ah.CreateBreed)
 		})
 	}
 }
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/jinzhu/copier"
 	"github.com/petshop-system/petshop-api/application/domain"
@@ -18,18 +21,96 @@ const (
 	ScheduleKafkaConsumerErrorTimeoutToReadMessage = "timeout error to read message from schedule kafka consumer"
 	ScheduleKafkaConsumerSuccessToConsumer         = "success to consumer"
 	ScheduleKafkaErrorToStartConsumer              = "error to start consumer from kafka"
+	ScheduleKafkaConsumerErrorToDecodeMessage      = "error to decode message from schedule kafka consumer"
+	ScheduleKafkaConsumerCommandScheduleNotFound   = "the schedule with id %d of the command wasn't found"
+	ScheduleKafkaConsumerErrorToProcessMessage     = "error to process message from schedule kafka consumer"
+	ScheduleKafkaConsumerRetryingMessage           = "retrying message from schedule kafka consumer"
+	ScheduleKafkaConsumerSuccessToDeadLetter       = "message sent to the schedule dead letter topic"
+	ScheduleKafkaConsumerErrorToDeadLetter         = "error to send message to the schedule dead letter topic"
+	ScheduleKafkaConsumerSuccessToDecline          = "declined event sent to the schedule declined topic"
+	ScheduleKafkaConsumerErrorToDecline            = "error to send the declined event to the schedule declined topic"
+	ScheduleKafkaConsumerErrorToCommit             = "error to commit offsets of the schedule kafka consumer"
+	ScheduleKafkaConsumerStoppedBeforeHandling     = "schedule kafka consumer stopped before handling the message"
+	ScheduleKafkaConsumerStopped                   = "schedule kafka consumer stopped"
+	ScheduleKafkaConsumerErrorToStop               = "error to wait the schedule kafka consumer to stop"
+	ScheduleKafkaConsumerErrorToLeaveGroup         = "error to leave the schedule kafka consumer group"
 )
 
+// Headers added to every record published to the dead letter topic.
+const (
+	DeadLetterHeaderReason            = "dead-letter-reason"
+	DeadLetterHeaderAttempts          = "dead-letter-attempts"
+	DeadLetterHeaderOriginalTopic     = "dead-letter-original-topic"
+	DeadLetterHeaderOriginalPartition = "dead-letter-original-partition"
+	DeadLetterHeaderOriginalOffset    = "dead-letter-original-offset"
+)
+
+// ScheduleHeaderCorrelationID is read from the consumed records and passed on to the events they cause.
+const ScheduleHeaderCorrelationID = "correlation-id"
+
 type ScheduleKafkaConsumer struct {
 	LoggerSugar     *zap.SugaredLogger
 	ScheduleService input.IScheduleService
 	KafkaClient     *kgo.Client
+	DeadLetterTopic string
+	DeclinedTopic   string
+	CommandTopic    string
+	RetryPolicy     RetryPolicy
+	done            chan struct{}
 }
 
 type ScheduleMessageKafka struct {
 	Booking                    string `json:"booking"`
 	PetId                      int    `json:"pet_id"`
 	ServiceEmployeeAttentionId int    `json:"service_employee_attention_id"`
+	Actor                      string `json:"actor,omitempty"`
+}
+
+// ScheduleCommandMessageKafka applies a lifecycle action (confirm, reschedule, decline, cancel,
+// complete or no_show) to a schedule. Booking and service_employee_attention_id are the new slot
+// of a reschedule.
+type ScheduleCommandMessageKafka struct {
+	Command                    string `json:"command"`
+	ScheduleId                 int64  `json:"schedule_id"`
+	Actor                      string `json:"actor"`
+	Reason                     string `json:"reason"`
+	Booking                    string `json:"booking"`
+	ServiceEmployeeAttentionId int64  `json:"service_employee_attention_id"`
+}
+
+// ScheduleDeclinedMessageKafka answers a booking that lost its slot to another booking.
+type ScheduleDeclinedMessageKafka struct {
+	Booking                    string    `json:"booking"`
+	PetId                      int       `json:"pet_id"`
+	ServiceEmployeeAttentionId int       `json:"service_employee_attention_id"`
+	Reason                     string    `json:"reason"`
+	DeclinedAt                 time.Time `json:"declined_at"`
+}
+
+// RetryPolicy controls how many times a record is handed to the service and how long
+// the consumer waits between attempts. The wait doubles at each attempt up to MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Backoff returns how long to wait after the given failed attempt, starting at 1.
+func (policy RetryPolicy) Backoff(attempt int) time.Duration {
+
+	backoff := policy.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff >= policy.MaxBackoff {
+			return policy.MaxBackoff
+		}
+	}
+
+	if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+		return policy.MaxBackoff
+	}
+
+	return backoff
 }
 
 func NewScheduleKafkaClient(loggerSugar *zap.SugaredLogger,
@@ -37,7 +118,11 @@ func NewScheduleKafkaClient(loggerSugar *zap.SugaredLogger,
 	bootstrapServer string,
 	groupID string,
 	autoOffsetReset string,
-	topic string) ScheduleKafkaConsumer {
+	topic string,
+	deadLetterTopic string,
+	declinedTopic string,
+	commandTopic string,
+	retryPolicy RetryPolicy) ScheduleKafkaConsumer {
 
 	seeds := []string{bootstrapServer}
 	// One client can both produce and consume!
@@ -45,7 +130,10 @@ func NewScheduleKafkaClient(loggerSugar *zap.SugaredLogger,
 	kafkaClient, err := kgo.NewClient(
 		kgo.SeedBrokers(seeds...),
 		kgo.ConsumerGroup(groupID),
-		kgo.ConsumeTopics(topic),
+		kgo.ConsumeTopics(topic, commandTopic),
+		// offsets are committed by ConsumerMessages only after each record is handled or dead-lettered
+		kgo.DisableAutoCommit(),
+		kgo.BlockRebalanceOnPoll(),
 	)
 
 	if err != nil {
@@ -57,58 +145,330 @@ func NewScheduleKafkaClient(loggerSugar *zap.SugaredLogger,
 		ScheduleService: scheduleService,
 		LoggerSugar:     loggerSugar,
 		KafkaClient:     kafkaClient,
+		DeadLetterTopic: deadLetterTopic,
+		DeclinedTopic:   declinedTopic,
+		CommandTopic:    commandTopic,
+		RetryPolicy:     retryPolicy,
+		done:            make(chan struct{}),
 	}
 
 	return scheduleKafkaConsumer
 }
 
-func (schedule *ScheduleKafkaConsumer) ConsumerMessages() {
+// ConsumerMessages polls the schedule and the command topics until ctx is cancelled. Close must be called
+// afterwards to wait for the last records and leave the consumer group.
+func (schedule *ScheduleKafkaConsumer) ConsumerMessages(ctx context.Context) {
 
 	go func() {
 
-		for {
+		defer close(schedule.done)
+
+		for ctx.Err() == nil {
 
-			ctx := context.Background()
 			fetches := schedule.KafkaClient.PollFetches(ctx)
-			if errs := fetches.Errors(); len(errs) > 0 {
-				// All errors are retried internally when fetching, but non-retriable errors are
-				// returned from polls so that users can notice and take action.
-				//panic(fmt.Sprint(errs))
-				schedule.LoggerSugar.Errorw(ScheduleKafkaConsumerErrorToReadMessage, "error", fmt.Sprint(errs))
-				continue
+			if ctx.Err() != nil {
+				schedule.KafkaClient.AllowRebalance()
+				break
 			}
 
-			// We can iterate through a record iterator...
-			iter := fetches.RecordIter()
-			for !iter.Done() {
-				record := iter.Next()
-				//fmt.Println(string(record.Value), "from an iterator!")
+			// All errors are retried internally when fetching, but non-retriable errors are returned
+			// from polls so that users can notice and take action. They are logged per partition,
+			// the records fetched from the other partitions are still handled.
+			fetches.EachError(func(topic string, partition int32, err error) {
+				schedule.LoggerSugar.Errorw(ScheduleKafkaConsumerErrorToReadMessage,
+					"topic", topic, "partition", partition, "error", err.Error())
+			})
+
+			handled := schedule.handleFetches(ctx, fetches)
+			schedule.commitRecords(ctx, handled)
+			schedule.KafkaClient.AllowRebalance()
+		}
+	}()
+}
+
+// handleFetches handles the records of the fetches in order and returns those handled. Records are
+// committed only once handled, so a crash redelivers whatever was in flight. Stopping in the middle
+// of the fetches leaves the remaining records uncommitted as well.
+func (schedule *ScheduleKafkaConsumer) handleFetches(ctx context.Context, fetches kgo.Fetches) []*kgo.Record {
+
+	var handled []*kgo.Record
+	iter := fetches.RecordIter()
+	for !iter.Done() {
+		record := iter.Next()
+		if err := schedule.handleRecord(ctx, record); err != nil {
+			schedule.LoggerSugar.Warnw(ScheduleKafkaConsumerStoppedBeforeHandling,
+				"partition", record.Partition, "offset", record.Offset, "error", err.Error())
+			break
+		}
+		handled = append(handled, record)
+	}
+
+	return handled
+}
+
+// Close waits for ConsumerMessages to return, leaves the consumer group and closes the client.
+// When ctx ends first the group is left without waiting, so the partitions are released anyway.
+func (schedule *ScheduleKafkaConsumer) Close(ctx context.Context) {
+
+	select {
+	case <-schedule.done:
+	case <-ctx.Done():
+		schedule.LoggerSugar.Errorw(ScheduleKafkaConsumerErrorToStop, "error", ctx.Err().Error())
+	}
+
+	if err := schedule.KafkaClient.LeaveGroupContext(ctx); err != nil {
+		schedule.LoggerSugar.Errorw(ScheduleKafkaConsumerErrorToLeaveGroup, "error", err.Error())
+	}
+
+	schedule.KafkaClient.Close()
+	schedule.LoggerSugar.Infow(ScheduleKafkaConsumerStopped)
+}
+
+// handleRecord processes a record with the retry policy. A booking whose slot was taken by another
+// one is answered on the declined topic; any other failure sends the record to the dead letter
+// topic once the attempts are exhausted or the failure can't be fixed by retrying.
+// It only fails when the context ends first, in which case the record must not be committed.
+func (schedule *ScheduleKafkaConsumer) handleRecord(ctx context.Context, record *kgo.Record) error {
+
+	attempts, err := schedule.processWithRetry(ctx, record)
+	if err == nil {
+		schedule.LoggerSugar.Infow(ScheduleKafkaConsumerSuccessToConsumer,
+			"message", string(record.Value), "attempts", attempts)
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if errors.Is(err, domain.ErrSlotTaken) && !schedule.isCommand(record) {
+		return schedule.publishDeclined(ctx, record, err)
+	}
+
+	schedule.LoggerSugar.Errorw(ScheduleKafkaConsumerErrorToProcessMessage,
+		"message", string(record.Value), "attempts", attempts, "error", err.Error())
+
+	return schedule.publishToDeadLetter(ctx, record, err, attempts)
+}
+
+func (schedule *ScheduleKafkaConsumer) commitRecords(ctx context.Context, records []*kgo.Record) {
 
-				var scheduleMessageKafka ScheduleMessageKafka
-				json.NewDecoder(bytes.NewReader(record.Value)).Decode(&scheduleMessageKafka)
+	if len(records) == 0 {
+		return
+	}
+
+	// the handled records must be committed even when the consumer is stopping
+	if err := schedule.KafkaClient.CommitRecords(context.WithoutCancel(ctx), records...); err != nil {
+		schedule.LoggerSugar.Errorw(ScheduleKafkaConsumerErrorToCommit, "error", err.Error())
+	}
+}
+
+func (schedule *ScheduleKafkaConsumer) processWithRetry(ctx context.Context, record *kgo.Record) (int, error) {
+
+	process, err := schedule.recordProcessor(record)
+	if err != nil {
+		schedule.LoggerSugar.Errorw(ScheduleKafkaConsumerErrorToDecodeMessage,
+			"message", string(record.Value), "error", err.Error())
+		return 1, err
+	}
 
-				var scheduleMessage domain.ScheduleMessage
-				copier.Copy(&scheduleMessage, &scheduleMessageKafka)
-				schedule.ScheduleService.CreateFromMessage(domain.ContextControl{
-					Context: context.Background(),
-				}, scheduleMessage)
+	for attempt := 1; ; attempt++ {
+
+		err = process(domain.ContextControl{
+			Context:   ctx,
+			RequestID: correlationID(record),
+		})
+
+		if err == nil || !isRetriable(err) || attempt >= schedule.RetryPolicy.MaxAttempts {
+			return attempt, err
+		}
+
+		backoff := schedule.RetryPolicy.Backoff(attempt)
+		schedule.LoggerSugar.Warnw(ScheduleKafkaConsumerRetryingMessage,
+			"attempt", attempt, "backoff", backoff.String(), "error", err.Error())
+
+		select {
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+}
 
-				schedule.LoggerSugar.Infow(ScheduleKafkaConsumerSuccessToConsumer,
-					"message", string(record.Value))
+// recordProcessor decodes the record by its topic: a command applies a lifecycle action to
+// a schedule, any other record books a new one.
+func (schedule *ScheduleKafkaConsumer) recordProcessor(record *kgo.Record) (func(contextControl domain.ContextControl) error, error) {
 
+	if schedule.isCommand(record) {
+
+		scheduleCommand, err := decodeScheduleCommand(record.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(contextControl domain.ContextControl) error {
+			_, exists, err := schedule.ScheduleService.ApplyCommand(contextControl, scheduleCommand)
+			if err == nil && !exists {
+				return domain.NewValidationError(ScheduleKafkaConsumerCommandScheduleNotFound, scheduleCommand.ScheduleID)
 			}
+			return err
+		}, nil
+	}
+
+	scheduleMessage, err := decodeScheduleMessage(record.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(contextControl domain.ContextControl) error {
+		return schedule.ScheduleService.CreateFromMessage(contextControl, scheduleMessage)
+	}, nil
+}
+
+// correlationID is the correlation of the producer of the record, or its position when there is none.
+func correlationID(record *kgo.Record) string {
 
-			// or a callback function.
-			//fetches.EachPartition(func(p kgo.FetchTopicPartition) {
-			//	for _, record := range p.Records {
-			//		fmt.Println(string(record.Value), "from range inside a callback!")
-			//	}
-			//
-			//	// We can even use a second callback!
-			//	p.EachRecord(func(record *kgo.Record) {
-			//		fmt.Println(string(record.Value), "from a second callback!")
-			//	})
-			//})
+	for _, header := range record.Headers {
+		if header.Key == ScheduleHeaderCorrelationID && len(header.Value) > 0 {
+			return string(header.Value)
 		}
-	}()
+	}
+
+	return fmt.Sprintf("%s/%d/%d", record.Topic, record.Partition, record.Offset)
+}
+
+func (schedule *ScheduleKafkaConsumer) isCommand(record *kgo.Record) bool {
+	return len(schedule.CommandTopic) > 0 && record.Topic == schedule.CommandTopic
+}
+
+// publishToDeadLetter sends the record to the dead letter topic with the failure in its headers.
+// It gives up only when the context ends.
+func (schedule *ScheduleKafkaConsumer) publishToDeadLetter(ctx context.Context, record *kgo.Record, cause error, attempts int) error {
+
+	headers := append([]kgo.RecordHeader{}, record.Headers...)
+	headers = append(headers,
+		kgo.RecordHeader{Key: DeadLetterHeaderReason, Value: []byte(cause.Error())},
+		kgo.RecordHeader{Key: DeadLetterHeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kgo.RecordHeader{Key: DeadLetterHeaderOriginalTopic, Value: []byte(record.Topic)},
+		kgo.RecordHeader{Key: DeadLetterHeaderOriginalPartition, Value: []byte(strconv.FormatInt(int64(record.Partition), 10))},
+		kgo.RecordHeader{Key: DeadLetterHeaderOriginalOffset, Value: []byte(strconv.FormatInt(record.Offset, 10))},
+	)
+
+	deadLetterRecord := &kgo.Record{
+		Topic:   schedule.DeadLetterTopic,
+		Key:     record.Key,
+		Value:   record.Value,
+		Headers: headers,
+	}
+
+	if err := schedule.produceUntilDone(ctx, deadLetterRecord, ScheduleKafkaConsumerErrorToDeadLetter); err != nil {
+		return err
+	}
+
+	schedule.LoggerSugar.Warnw(ScheduleKafkaConsumerSuccessToDeadLetter, "topic", schedule.DeadLetterTopic,
+		"partition", record.Partition, "offset", record.Offset, "reason", cause.Error())
+	return nil
+}
+
+// publishDeclined answers the booking on the declined topic, keyed as the original record.
+// Like publishToDeadLetter it gives up only when the context ends.
+func (schedule *ScheduleKafkaConsumer) publishDeclined(ctx context.Context, record *kgo.Record, cause error) error {
+
+	declinedRecord, err := newDeclinedRecord(schedule.DeclinedTopic, record, cause, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err = schedule.produceUntilDone(ctx, declinedRecord, ScheduleKafkaConsumerErrorToDecline); err != nil {
+		return err
+	}
+
+	schedule.LoggerSugar.Infow(ScheduleKafkaConsumerSuccessToDecline, "topic", schedule.DeclinedTopic,
+		"partition", record.Partition, "offset", record.Offset, "reason", cause.Error())
+	return nil
+}
+
+func newDeclinedRecord(topic string, record *kgo.Record, cause error, declinedAt time.Time) (*kgo.Record, error) {
+
+	var scheduleMessageKafka ScheduleMessageKafka
+	if err := json.Unmarshal(record.Value, &scheduleMessageKafka); err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(ScheduleDeclinedMessageKafka{
+		Booking:                    scheduleMessageKafka.Booking,
+		PetId:                      scheduleMessageKafka.PetId,
+		ServiceEmployeeAttentionId: scheduleMessageKafka.ServiceEmployeeAttentionId,
+		Reason:                     cause.Error(),
+		DeclinedAt:                 declinedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kgo.Record{
+		Topic:   topic,
+		Key:     record.Key,
+		Value:   value,
+		Headers: record.Headers,
+	}, nil
+}
+
+// produceUntilDone keeps trying to publish the record, since committing the consumed record
+// without reaching the topic would lose it. It gives up only when the context ends.
+func (schedule *ScheduleKafkaConsumer) produceUntilDone(ctx context.Context, record *kgo.Record, errorMessage string) error {
+
+	for attempt := 1; ; attempt++ {
+
+		err := schedule.KafkaClient.ProduceSync(ctx, record).FirstErr()
+		if err == nil {
+			return nil
+		}
+
+		schedule.LoggerSugar.Errorw(errorMessage,
+			"topic", record.Topic, "message", string(record.Value), "attempt", attempt, "error", err.Error())
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(schedule.RetryPolicy.Backoff(attempt)):
+		}
+	}
+}
+
+// isRetriable tells whether trying the same message again may succeed.
+func isRetriable(err error) bool {
+	return !errors.Is(err, domain.ErrValidation) && !errors.Is(err, domain.ErrConflict)
+}
+
+func decodeScheduleMessage(value []byte) (domain.ScheduleMessage, error) {
+
+	var scheduleMessageKafka ScheduleMessageKafka
+	if err := json.NewDecoder(bytes.NewReader(value)).Decode(&scheduleMessageKafka); err != nil {
+		return domain.ScheduleMessage{}, err
+	}
+
+	var scheduleMessage domain.ScheduleMessage
+	if err := copier.Copy(&scheduleMessage, &scheduleMessageKafka); err != nil {
+		return domain.ScheduleMessage{}, err
+	}
+
+	return scheduleMessage, nil
+}
+
+func decodeScheduleCommand(value []byte) (domain.ScheduleCommand, error) {
+
+	var scheduleCommandKafka ScheduleCommandMessageKafka
+	if err := json.NewDecoder(bytes.NewReader(value)).Decode(&scheduleCommandKafka); err != nil {
+		return domain.ScheduleCommand{}, err
+	}
+
+	return domain.ScheduleCommand{
+		ScheduleID:      scheduleCommandKafka.ScheduleId,
+		Action:          domain.ScheduleAction(scheduleCommandKafka.Command),
+		Actor:           scheduleCommandKafka.Actor,
+		Reason:          scheduleCommandKafka.Reason,
+		Booking:         scheduleCommandKafka.Booking,
+		AttentionTimeID: scheduleCommandKafka.ServiceEmployeeAttentionId,
+	}, nil
 }
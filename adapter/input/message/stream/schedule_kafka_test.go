@@ -0,0 +1,269 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.uber.org/zap"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 400*time.Millisecond, policy.Backoff(3))
+	assert.Equal(t, 800*time.Millisecond, policy.Backoff(4))
+	assert.Equal(t, time.Second, policy.Backoff(5))
+	assert.Equal(t, time.Second, policy.Backoff(30))
+}
+
+func TestScheduleKafkaConsumer_processWithRetry(t *testing.T) {
+
+	validRecord := &kgo.Record{Value: []byte(`{"booking":"2030-12-10","pet_id":1,"service_employee_attention_id":2}`)}
+
+	tests := []struct {
+		Name             string
+		Record           *kgo.Record
+		ServiceErrors    []error
+		ExpectedAttempts int
+		ExpectedCalls    int
+		ExpectedError    bool
+	}{
+		{
+			Name:             "WithSuccess_ProcessesOnce",
+			Record:           validRecord,
+			ServiceErrors:    []error{nil},
+			ExpectedAttempts: 1,
+			ExpectedCalls:    1,
+			ExpectedError:    false,
+		},
+		{
+			Name:             "WithTransientFailure_RetriesUntilSuccess",
+			Record:           validRecord,
+			ServiceErrors:    []error{errors.New("connection refused"), errors.New("connection refused"), nil},
+			ExpectedAttempts: 3,
+			ExpectedCalls:    3,
+			ExpectedError:    false,
+		},
+		{
+			Name:             "WithPersistentFailure_StopsAtMaxAttempts",
+			Record:           validRecord,
+			ServiceErrors:    []error{errors.New("connection refused")},
+			ExpectedAttempts: 4,
+			ExpectedCalls:    4,
+			ExpectedError:    true,
+		},
+		{
+			Name:             "WithValidationError_DoesNotRetry",
+			Record:           validRecord,
+			ServiceErrors:    []error{domain.NewValidationError("pet not found")},
+			ExpectedAttempts: 1,
+			ExpectedCalls:    1,
+			ExpectedError:    true,
+		},
+		{
+			Name:             "WithSlotTaken_DoesNotRetry",
+			Record:           validRecord,
+			ServiceErrors:    []error{domain.NewSlotTakenError("slot taken")},
+			ExpectedAttempts: 1,
+			ExpectedCalls:    1,
+			ExpectedError:    true,
+		},
+		{
+			Name:             "WithInvalidJSON_DoesNotCallTheService",
+			Record:           &kgo.Record{Value: []byte(`{"pet_id":"one"`)},
+			ServiceErrors:    []error{nil},
+			ExpectedAttempts: 1,
+			ExpectedCalls:    0,
+			ExpectedError:    true,
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			calls := 0
+			consumer := ScheduleKafkaConsumer{
+				LoggerSugar: zap.NewNop().Sugar(),
+				ScheduleService: service.ScheduleMock{
+					CreateFromMessageMock: func(contextControl domain.ContextControl, message domain.ScheduleMessage) error {
+						err := test.ServiceErrors[min(calls, len(test.ServiceErrors)-1)]
+						calls++
+						return err
+					},
+				},
+				RetryPolicy: RetryPolicy{MaxAttempts: 4, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+			}
+
+			attempts, err := consumer.processWithRetry(context.Background(), test.Record)
+			assert.Equal(t, test.ExpectedAttempts, attempts)
+			assert.Equal(t, test.ExpectedCalls, calls)
+			assert.Equal(t, test.ExpectedError, err != nil)
+		})
+	}
+}
+
+func TestNewDeclinedRecord(t *testing.T) {
+
+	declinedAt := time.Date(2030, 12, 9, 10, 0, 0, 0, time.UTC)
+	record := &kgo.Record{
+		Key:     []byte("1"),
+		Value:   []byte(`{"booking":"2030-12-10","pet_id":1,"service_employee_attention_id":2}`),
+		Headers: []kgo.RecordHeader{{Key: "trace", Value: []byte("abc")}},
+	}
+
+	declined, err := newDeclinedRecord("schedule_declined", record, domain.NewSlotTakenError("slot taken"), declinedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, "schedule_declined", declined.Topic)
+	assert.Equal(t, record.Key, declined.Key)
+	assert.Equal(t, record.Headers, declined.Headers)
+
+	var message ScheduleDeclinedMessageKafka
+	assert.NoError(t, json.Unmarshal(declined.Value, &message))
+	assert.Equal(t, ScheduleDeclinedMessageKafka{
+		Booking:                    "2030-12-10",
+		PetId:                      1,
+		ServiceEmployeeAttentionId: 2,
+		Reason:                     "slot taken",
+		DeclinedAt:                 declinedAt,
+	}, message)
+
+	_, err = newDeclinedRecord("schedule_declined", &kgo.Record{Value: []byte(`{"pet_id":"one"`)}, errors.New("slot taken"), declinedAt)
+	assert.Error(t, err)
+}
+
+func TestCorrelationID(t *testing.T) {
+
+	record := &kgo.Record{Topic: "schedule", Partition: 2, Offset: 41}
+	assert.Equal(t, "schedule/2/41", correlationID(record))
+
+	record.Headers = []kgo.RecordHeader{{Key: "trace", Value: []byte("abc")}, {Key: ScheduleHeaderCorrelationID, Value: []byte("gateway-7")}}
+	assert.Equal(t, "gateway-7", correlationID(record))
+}
+
+func TestScheduleKafkaConsumer_processWithRetry_Command(t *testing.T) {
+
+	record := &kgo.Record{
+		Topic: "schedule_command",
+		Value: []byte(`{"command":"reschedule","schedule_id":10,"actor":"siclano","reason":"travel","booking":"2030-12-11","service_employee_attention_id":3}`),
+	}
+
+	tests := []struct {
+		Name          string
+		Exists        bool
+		ServiceError  error
+		ExpectedError error
+	}{
+		{Name: "WithKnownSchedule_AppliesTheCommand", Exists: true},
+		{Name: "WithUnknownSchedule_ReturnsValidationError", Exists: false,
+			ExpectedError: domain.NewValidationError(ScheduleKafkaConsumerCommandScheduleNotFound, int64(10))},
+		{Name: "WithTransitionNotAllowed_DoesNotRetry", Exists: true,
+			ServiceError: domain.NewConflictError("not allowed"), ExpectedError: domain.NewConflictError("not allowed")},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			var commands []domain.ScheduleCommand
+			consumer := ScheduleKafkaConsumer{
+				LoggerSugar:  zap.NewNop().Sugar(),
+				CommandTopic: "schedule_command",
+				ScheduleService: service.ScheduleMock{
+					CreateFromMessageMock: func(contextControl domain.ContextControl, message domain.ScheduleMessage) error {
+						t.Fatal("a command must not book a schedule")
+						return nil
+					},
+					ApplyCommandMock: func(contextControl domain.ContextControl, command domain.ScheduleCommand) (domain.ScheduleDomain, bool, error) {
+						commands = append(commands, command)
+						return domain.ScheduleDomain{ID: command.ScheduleID}, test.Exists, test.ServiceError
+					},
+				},
+				RetryPolicy: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+			}
+
+			attempts, err := consumer.processWithRetry(context.Background(), record)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, 1, attempts)
+			assert.Equal(t, []domain.ScheduleCommand{{
+				ScheduleID:      10,
+				Action:          domain.ScheduleActionReschedule,
+				Actor:           "siclano",
+				Reason:          "travel",
+				Booking:         "2030-12-11",
+				AttentionTimeID: 3,
+			}}, commands)
+		})
+	}
+}
+
+func TestScheduleKafkaConsumer_handleFetches(t *testing.T) {
+
+	booking := func(petID string) []byte {
+		return []byte(`{"booking":"2030-12-10","pet_id":` + petID + `,"service_employee_attention_id":2}`)
+	}
+	first := &kgo.Record{Topic: "schedule", Partition: 1, Offset: 7, Value: booking("1")}
+	second := &kgo.Record{Topic: "schedule", Partition: 1, Offset: 8, Value: booking("2")}
+
+	// the partition 0 fails while the partition 1 delivers its records
+	fetches := kgo.Fetches{{Topics: []kgo.FetchTopic{{
+		Topic: "schedule",
+		Partitions: []kgo.FetchPartition{
+			{Partition: 0, Err: errors.New("not leader for partition")},
+			{Partition: 1, Records: []*kgo.Record{first, second}},
+		},
+	}}}}
+
+	t.Run("WithFailedPartition_HandlesTheOtherPartitions", func(t *testing.T) {
+
+		var pets []int
+		consumer := ScheduleKafkaConsumer{
+			LoggerSugar: zap.NewNop().Sugar(),
+			ScheduleService: service.ScheduleMock{
+				CreateFromMessageMock: func(contextControl domain.ContextControl, message domain.ScheduleMessage) error {
+					pets = append(pets, message.PetId)
+					return nil
+				},
+			},
+			RetryPolicy: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+		}
+
+		handled := consumer.handleFetches(context.Background(), fetches)
+		assert.Equal(t, []*kgo.Record{first, second}, handled)
+		assert.Equal(t, []int{1, 2}, pets)
+	})
+
+	t.Run("WithStopBeforeHandling_ReturnsOnlyTheHandledRecords", func(t *testing.T) {
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		consumer := ScheduleKafkaConsumer{
+			LoggerSugar: zap.NewNop().Sugar(),
+			ScheduleService: service.ScheduleMock{
+				CreateFromMessageMock: func(contextControl domain.ContextControl, message domain.ScheduleMessage) error {
+					if message.PetId == 2 {
+						return contextControl.Context.Err()
+					}
+					// the consumer stops once the first record is handled
+					cancel()
+					return nil
+				},
+			},
+			RetryPolicy: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+		}
+
+		handled := consumer.handleFetches(ctx, fetches)
+		assert.Equal(t, []*kgo.Record{first}, handled)
+	})
+}
@@ -14,8 +14,12 @@ const (
 	ErrorToInsertValueInRedis = "Failed to insert value in Redis"
 	ErrorToGetInRedis         = "Failed to get value from Redis"
 	ErrorToDeleteInRedis      = "Failed to delete value in Redis"
+	ErrorToScanInRedis        = "Failed to scan keys in Redis"
 )
 
+// ScanCount is the amount of keys Redis is asked to check at each SCAN call.
+const ScanCount = 500
+
 type Redis struct {
 	RedisClient *redis.Client
 	LoggerSugar *zap.SugaredLogger
@@ -63,3 +67,24 @@ func (r *Redis) Delete(ctx domain.ContextControl, key string) error {
 
 	return nil
 }
+
+// GetKeysByPattern walks the keyspace with SCAN, so it doesn't block Redis the way KEYS does.
+func (r *Redis) GetKeysByPattern(ctx domain.ContextControl, pattern string) ([]string, error) {
+
+	var keys []string
+	iter := r.RedisClient.Scan(ctx.Context, 0, pattern, ScanCount).Iterator()
+	for iter.Next(ctx.Context) {
+		keys = append(keys, iter.Val())
+	}
+
+	if err := iter.Err(); err != nil {
+		r.LoggerSugar.Warnw(ErrorToScanInRedis, "pattern", pattern, "err", err.Error())
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (r *Redis) Close() error {
+	return r.RedisClient.Close()
+}
@@ -17,6 +17,7 @@ type AddressPostgresDB struct {
 const (
 	AddressSaveDBError = "failed to save address to postgres"
 	AddressNotFound    = "address not found"
+	AddressListDBError = "error to list the addresses"
 )
 
 func NewAddressPostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) AddressPostgresDB {
@@ -36,6 +37,7 @@ type AddressDB struct {
 	City         string `gorm:"column:city"`
 	State        string `gorm:"column:state"`
 	Country      string `gorm:"column:country"`
+	ContractID   *int64 `gorm:"column:fk_id_contract"`
 }
 
 func (AddressDB) TableName() string {
@@ -59,8 +61,9 @@ func (cp AddressPostgresDB) Save(contextControl domain.ContextControl, addressDo
 		cp.LoggerSugar.Errorw("error copying address domain to DB struct", "error", err.Error())
 		return domain.AddressDomain{}, err
 	}
+	addressDB.ContractID = scopedContractID(contextControl)
 
-	if err := cp.DB.WithContext(contextControl.Context).
+	if err := connection(cp.DB, contextControl).
 		Create(&addressDB).Error; err != nil {
 		cp.LoggerSugar.Errorw(AddressSaveDBError,
 			"error", err.Error())
@@ -79,7 +82,7 @@ func (cp AddressPostgresDB) Save(contextControl domain.ContextControl, addressDo
 func (cp AddressPostgresDB) GetByID(contextControl domain.ContextControl, ID int64) (domain.AddressDomain, bool, error) {
 	var addressDB AddressDB
 
-	result := cp.DB.WithContext(contextControl.Context).First(&addressDB, ID)
+	result := connection(cp.DB, contextControl).Scopes(contractScope(contextControl)).First(&addressDB, ID)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			cp.LoggerSugar.Infow(AddressNotFound, "address_id", ID)
@@ -97,3 +100,51 @@ func (cp AddressPostgresDB) GetByID(contextControl domain.ContextControl, ID int
 
 	return addressResult, true, nil
 }
+
+// addressFilterScope restricts a query to the addresses matching the filter, whose zip code is
+// expected with digits only and city lower case. The stored zip code may have a dash.
+func addressFilterScope(filter domain.AddressFilterDomain) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.ZipCode != "" {
+			db = db.Where(`regexp_replace(zip_code, '\D', '', 'g') = ?`, filter.ZipCode)
+		}
+		if filter.City != "" {
+			db = db.Where("lower(city) = ?", filter.City)
+		}
+		return db
+	}
+}
+
+// List returns the page of the addresses matching the filter, in ID order.
+func (cp AddressPostgresDB) List(contextControl domain.ContextControl, filter domain.AddressFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.AddressDomain], error) {
+
+	var addressesDB []AddressDB
+	if err := connection(cp.DB, contextControl).
+		Scopes(addressFilterScope(filter), contractScope(contextControl), keysetPage(page)).
+		Find(&addressesDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(AddressListDBError, "error", err.Error())
+		return domain.PageResultDomain[domain.AddressDomain]{}, err
+	}
+
+	total, capped, err := countCapped(connection(cp.DB, contextControl),
+		connection(cp.DB, contextControl).Model(&AddressDB{}).
+			Scopes(addressFilterScope(filter), contractScope(contextControl)))
+	if err != nil {
+		cp.LoggerSugar.Errorw(AddressListDBError, "error", err.Error())
+		return domain.PageResultDomain[domain.AddressDomain]{}, err
+	}
+
+	addresses := make([]domain.AddressDomain, 0, len(addressesDB))
+	for _, addressDB := range addressesDB {
+		address, err := addressDB.CopyToAddressDomain()
+		if err != nil {
+			cp.LoggerSugar.Errorw("error copying DB struct to address domain", "error", err.Error())
+			return domain.PageResultDomain[domain.AddressDomain]{}, err
+		}
+		addresses = append(addresses, address)
+	}
+
+	return newPage(addresses, page, total, capped, func(address domain.AddressDomain) int64 {
+		return address.ID
+	}), nil
+}
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	AttentionTimeSaveDBError            = "error to save the service employee attention time into postgres"
+	AttentionTimeUpdateDBError          = "error to update the service employee attention time into postgres"
+	AttentionTimeGetByIDDBError         = "error to get a service employee attention time by id"
+	AttentionTimeGetByEmployeeIDDBError = "error to get the service employee attention times by employee id"
+	AttentionTimeGetByServiceIDDBError  = "error to get the service employee attention times by service id"
+	AttentionTimeNotFound               = "service employee attention time not found"
+)
+
+type AttentionTimePostgresDB struct {
+	DB          *gorm.DB
+	LoggerSugar *zap.SugaredLogger
+}
+
+func NewAttentionTimePostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) AttentionTimePostgresDB {
+	return AttentionTimePostgresDB{
+		DB:          gormDB,
+		LoggerSugar: loggerSugar,
+	}
+}
+
+type AttentionTimeDB struct {
+	ID          int64  `gorm:"primaryKey, column:id"`
+	InitialTime string `gorm:"column:initial_time"`
+	FinalTime   string `gorm:"column:final_time"`
+	Active      bool   `gorm:"column:active"`
+	ServiceID   int64  `gorm:"column:fk_id_service"`
+	ContractID  int64  `gorm:"column:fk_id_contract"`
+	EmployeeID  int64  `gorm:"column:fk_id_employee"`
+}
+
+func (AttentionTimeDB) TableName() string {
+	return "petshop_api.service_employee_attention_time"
+}
+
+func (c AttentionTimeDB) CopyToAttentionTimeDomain() domain.AttentionTimeDomain {
+	return domain.AttentionTimeDomain{
+		ID:          c.ID,
+		InitialTime: c.InitialTime,
+		FinalTime:   c.FinalTime,
+		Active:      c.Active,
+		ServiceID:   c.ServiceID,
+		ContractID:  c.ContractID,
+		EmployeeID:  c.EmployeeID,
+	}
+}
+
+func (cp AttentionTimePostgresDB) Save(contextControl domain.ContextControl, attentionTimeDomain domain.AttentionTimeDomain) (domain.AttentionTimeDomain, error) {
+
+	attentionTimeDB := AttentionTimeDB{
+		InitialTime: attentionTimeDomain.InitialTime,
+		FinalTime:   attentionTimeDomain.FinalTime,
+		Active:      attentionTimeDomain.Active,
+		ServiceID:   attentionTimeDomain.ServiceID,
+		ContractID:  contextControl.ScopedContractID(attentionTimeDomain.ContractID),
+		EmployeeID:  attentionTimeDomain.EmployeeID,
+	}
+
+	if err := connection(cp.DB, contextControl).Create(&attentionTimeDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(AttentionTimeSaveDBError,
+			"error", err.Error())
+		return domain.AttentionTimeDomain{}, err
+	}
+
+	return attentionTimeDB.CopyToAttentionTimeDomain(), nil
+}
+
+func (cp AttentionTimePostgresDB) SetActive(contextControl domain.ContextControl, ID int64, active bool) error {
+
+	if err := connection(cp.DB, contextControl).
+		Model(&AttentionTimeDB{}).
+		Scopes(contractScope(contextControl)).
+		Where("id = ?", ID).
+		Update("active", active).Error; err != nil {
+		cp.LoggerSugar.Errorw(AttentionTimeUpdateDBError,
+			"attention_time_id", ID, "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (cp AttentionTimePostgresDB) GetByID(contextControl domain.ContextControl, ID int64) (domain.AttentionTimeDomain, bool, error) {
+
+	var attentionTimeDB AttentionTimeDB
+
+	result := connection(cp.DB, contextControl).Scopes(contractScope(contextControl)).First(&attentionTimeDB, ID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			cp.LoggerSugar.Infow(AttentionTimeNotFound, "attention_time_id", ID)
+			return domain.AttentionTimeDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(AttentionTimeGetByIDDBError, "attention_time_id", ID, "error", result.Error.Error())
+		return domain.AttentionTimeDomain{}, false, result.Error
+	}
+
+	return attentionTimeDB.CopyToAttentionTimeDomain(), true, nil
+}
+
+func (cp AttentionTimePostgresDB) GetByEmployeeID(contextControl domain.ContextControl, employeeID int64) ([]domain.AttentionTimeDomain, error) {
+
+	var attentionTimesDB []AttentionTimeDB
+
+	if err := connection(cp.DB, contextControl).
+		Scopes(contractScope(contextControl)).
+		Where("fk_id_employee = ?", employeeID).
+		Order("initial_time").
+		Find(&attentionTimesDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(AttentionTimeGetByEmployeeIDDBError,
+			"employee_id", employeeID, "error", err.Error())
+		return nil, err
+	}
+
+	attentionTimes := make([]domain.AttentionTimeDomain, 0, len(attentionTimesDB))
+	for _, attentionTimeDB := range attentionTimesDB {
+		attentionTimes = append(attentionTimes, attentionTimeDB.CopyToAttentionTimeDomain())
+	}
+
+	return attentionTimes, nil
+}
+
+func (cp AttentionTimePostgresDB) GetByServiceID(contextControl domain.ContextControl, serviceID int64) ([]domain.AttentionTimeDomain, error) {
+
+	var attentionTimesDB []AttentionTimeDB
+
+	if err := connection(cp.DB, contextControl).
+		Scopes(contractScope(contextControl)).
+		Where("fk_id_service = ?", serviceID).
+		Order("fk_id_employee, initial_time").
+		Find(&attentionTimesDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(AttentionTimeGetByServiceIDDBError,
+			"service_id", serviceID, "error", err.Error())
+		return nil, err
+	}
+
+	attentionTimes := make([]domain.AttentionTimeDomain, 0, len(attentionTimesDB))
+	for _, attentionTimeDB := range attentionTimesDB {
+		attentionTimes = append(attentionTimes, attentionTimeDB.CopyToAttentionTimeDomain())
+	}
+
+	return attentionTimes, nil
+}
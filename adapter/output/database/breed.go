@@ -0,0 +1,126 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	BreedSaveDBError      = "error to save the breed into postgres"
+	BreedGetByIDDBError   = "error to get a breed by id"
+	BreedGetByNameDBError = "error to get a breed by species and name"
+	BreedGetAllDBError    = "error to get all breeds"
+	BreedNotFound         = "breed not found"
+	BreedAlreadyExists    = "the breed %s already exists for the species %d"
+)
+
+type BreedPostgresDB struct {
+	DB          *gorm.DB
+	LoggerSugar *zap.SugaredLogger
+}
+
+func NewBreedPostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) BreedPostgresDB {
+	return BreedPostgresDB{
+		DB:          gormDB,
+		LoggerSugar: loggerSugar,
+	}
+}
+
+type BreedDB struct {
+	ID        int64  `gorm:"primaryKey, column:id"`
+	Name      string `gorm:"column:name"`
+	SpeciesID int64  `gorm:"column:fk_id_species"`
+}
+
+func (BreedDB) TableName() string {
+	return "petshop_api.breed"
+}
+
+func (c BreedDB) CopyToBreedDomain() domain.BreedDomain {
+	return domain.BreedDomain{
+		ID:        c.ID,
+		Name:      c.Name,
+		SpeciesID: c.SpeciesID,
+	}
+}
+
+func (cp BreedPostgresDB) GetByID(contextControl domain.ContextControl, ID int64) (domain.BreedDomain, bool, error) {
+
+	var breedDB BreedDB
+
+	result := connection(cp.DB, contextControl).First(&breedDB, ID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			cp.LoggerSugar.Infow(BreedNotFound, "breed_id", ID)
+			return domain.BreedDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(BreedGetByIDDBError, "breed_id", ID, "error", result.Error.Error())
+		return domain.BreedDomain{}, false, result.Error
+	}
+
+	return breedDB.CopyToBreedDomain(), true, nil
+}
+
+func (cp BreedPostgresDB) Save(contextControl domain.ContextControl, breedDomain domain.BreedDomain) (domain.BreedDomain, error) {
+
+	breedDB := BreedDB{
+		ID:        breedDomain.ID,
+		Name:      breedDomain.Name,
+		SpeciesID: breedDomain.SpeciesID,
+	}
+
+	if err := connection(cp.DB, contextControl).
+		Create(&breedDB).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			cp.LoggerSugar.Infow(BreedSaveDBError, "name", breedDomain.Name, "species_id", breedDomain.SpeciesID,
+				"error", err.Error())
+			return domain.BreedDomain{}, domain.NewConflictError(BreedAlreadyExists, breedDomain.Name, breedDomain.SpeciesID)
+		}
+		cp.LoggerSugar.Errorw(BreedSaveDBError,
+			"error", err.Error())
+		return domain.BreedDomain{}, err
+	}
+
+	return breedDB.CopyToBreedDomain(), nil
+}
+
+func (cp BreedPostgresDB) GetBySpeciesIDAndName(contextControl domain.ContextControl, speciesID int64, name string) (domain.BreedDomain, bool, error) {
+
+	var breedDB BreedDB
+
+	result := connection(cp.DB, contextControl).
+		Where("fk_id_species = ? and lower(name) = lower(?)", speciesID, name).
+		First(&breedDB)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return domain.BreedDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(BreedGetByNameDBError, "species_id", speciesID, "name", name,
+			"error", result.Error.Error())
+		return domain.BreedDomain{}, false, result.Error
+	}
+
+	return breedDB.CopyToBreedDomain(), true, nil
+}
+
+func (cp BreedPostgresDB) GetAll(contextControl domain.ContextControl) ([]domain.BreedDomain, error) {
+
+	var breedsDB []BreedDB
+
+	if err := connection(cp.DB, contextControl).
+		Order("fk_id_species, name").
+		Find(&breedsDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(BreedGetAllDBError, "error", err.Error())
+		return nil, err
+	}
+
+	breeds := make([]domain.BreedDomain, 0, len(breedsDB))
+	for _, breedDB := range breedsDB {
+		breeds = append(breeds, breedDB.CopyToBreedDomain())
+	}
+
+	return breeds, nil
+}
@@ -0,0 +1,24 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestBreedPostgresDB_Save(t *testing.T) {
+
+	t.Run("WithDuplicatedNameInTheSpecies_ReturnsConflict", func(t *testing.T) {
+
+		breedDB := NewBreedPostgresDB(failingCreateDB(t, gorm.ErrDuplicatedKey), zap.NewNop().Sugar())
+		_, err := breedDB.Save(domain.ContextControl{Context: context.Background()},
+			domain.BreedDomain{Name: "Poodle", SpeciesID: 1})
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		assert.EqualError(t, err, "the breed Poodle already exists for the species 1")
+	})
+}
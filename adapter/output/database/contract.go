@@ -0,0 +1,155 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"github.com/petshop-system/petshop-api/application/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ContractSaveDBError          = "error to save the contract into postgres"
+	ContractUpdateDBError        = "error to update the contract into postgres"
+	ContractGetByIDDBError       = "error to get a contract by id"
+	ContractGetByDocumentDBError = "error to get a contract by document"
+	ContractGetByEmailDBError    = "error to get a contract by email"
+	ContractNotFound             = "contract not found"
+	ContractAlreadyExists        = "there is already a contract with this email or document"
+)
+
+type ContractPostgresDB struct {
+	DB          *gorm.DB
+	LoggerSugar *zap.SugaredLogger
+}
+
+func NewContractPostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) ContractPostgresDB {
+	return ContractPostgresDB{
+		DB:          gormDB,
+		LoggerSugar: loggerSugar,
+	}
+}
+
+type ContractDB struct {
+	ID          int64     `gorm:"primaryKey, column:id"`
+	Name        string    `gorm:"column:name"`
+	Email       string    `gorm:"column:email"`
+	Document    string    `gorm:"column:document"`
+	PersonType  string    `gorm:"column:person_type"`
+	DateCreated time.Time `gorm:"column:date_created;default:now()"`
+	AddressID   int64     `gorm:"column:fk_id_address"`
+	Address     AddressDB `gorm:"foreignKey:AddressID"`
+}
+
+func (ContractDB) TableName() string {
+	return "petshop_api.contract"
+}
+
+func (c ContractDB) CopyToContractDomain() domain.ContractDomain {
+
+	contractDomain := domain.ContractDomain{
+		ID:          c.ID,
+		Name:        c.Name,
+		Email:       c.Email,
+		Document:    c.Document,
+		PersonType:  c.PersonType,
+		DateCreated: c.DateCreated,
+		AddressID:   c.AddressID,
+	}
+	copier.Copy(&contractDomain.Address, &c.Address)
+
+	return contractDomain
+}
+
+// Save inserts the address and then the contract in the same transaction,
+// so a contract rejected by a unique key doesn't leave its address behind.
+func (cp ContractPostgresDB) Save(contextControl domain.ContextControl, contractDomain domain.ContractDomain) (domain.ContractDomain, error) {
+
+	contractDB := ContractDB{
+		Name:       contractDomain.Name,
+		Email:      contractDomain.Email,
+		Document:   contractDomain.Document,
+		PersonType: contractDomain.PersonType,
+	}
+	copier.Copy(&contractDB.Address, &contractDomain.Address)
+
+	err := connection(cp.DB, contextControl).Transaction(func(tx *gorm.DB) error {
+
+		if err := tx.Create(&contractDB.Address).Error; err != nil {
+			return err
+		}
+
+		contractDB.AddressID = contractDB.Address.ID
+		return tx.Omit("Address").Create(&contractDB).Error
+	})
+
+	if err != nil {
+		cp.LoggerSugar.Errorw(ContractSaveDBError,
+			"error", err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ContractDomain{}, domain.NewConflictError(ContractAlreadyExists)
+		}
+		return domain.ContractDomain{}, err
+	}
+
+	return contractDB.CopyToContractDomain(), nil
+}
+
+func (cp ContractPostgresDB) Update(contextControl domain.ContextControl, contractDomain domain.ContractDomain) error {
+
+	err := connection(cp.DB, contextControl).
+		Model(&ContractDB{ID: contractDomain.ID}).
+		Select("name", "email", "document", "person_type").
+		Updates(ContractDB{
+			Name:       contractDomain.Name,
+			Email:      contractDomain.Email,
+			Document:   contractDomain.Document,
+			PersonType: contractDomain.PersonType,
+		}).Error
+
+	if err != nil {
+		cp.LoggerSugar.Errorw(ContractUpdateDBError,
+			"contract_id", contractDomain.ID, "error", err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.NewConflictError(ContractAlreadyExists)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (cp ContractPostgresDB) GetByID(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error) {
+	return cp.getBy(contextControl, ContractGetByIDDBError, "id = ?", ID)
+}
+
+func (cp ContractPostgresDB) GetByDocument(contextControl domain.ContextControl, document string) (domain.ContractDomain, bool, error) {
+	return cp.getBy(contextControl, ContractGetByDocumentDBError, "document = ?", document)
+}
+
+func (cp ContractPostgresDB) GetByEmail(contextControl domain.ContextControl, email string) (domain.ContractDomain, bool, error) {
+	return cp.getBy(contextControl, ContractGetByEmailDBError, "lower(email) = lower(?)", email)
+}
+
+func (cp ContractPostgresDB) getBy(contextControl domain.ContextControl, errorMessage string, query string, value any) (domain.ContractDomain, bool, error) {
+
+	var contractDB ContractDB
+
+	result := connection(cp.DB, contextControl).
+		Preload("Address").
+		Where(query, value).
+		First(&contractDB)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			cp.LoggerSugar.Infow(ContractNotFound, "value", value)
+			return domain.ContractDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(errorMessage, "value", value, "error", result.Error.Error())
+		return domain.ContractDomain{}, false, result.Error
+	}
+
+	return contractDB.CopyToContractDomain(), true, nil
+}
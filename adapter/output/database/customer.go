@@ -1,6 +1,11 @@
 package database
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/jinzhu/copier"
 	"github.com/petshop-system/petshop-api/application/domain"
 	"go.uber.org/zap"
@@ -11,8 +16,22 @@ const (
 	CustomerSaveDBError    = "error to save the customer into postgres"
 	CustomerGetByIDDBError = "error to get a customer by id"
 	CustomerNotFound       = "customer not found"
+	CustomerUpdateDBError  = "error to update the customer"
+	CustomerDeleteDBError  = "error to delete the customer"
+	CustomerRestoreDBError = "error to restore the customer"
+	CustomerListDBError    = "error to list the customers"
+	CustomerChangedByOther = "the customer %d was deleted or restored by another request"
+
+	CustomerHistorySaveDBError = "error to record the change of the customer"
+	CustomerHistoryGetDBError  = "error to get the history of the customer"
 )
 
+// customerNotDeleted restricts a query to the customers not soft deleted.
+const customerNotDeleted = "date_deleted is null"
+
+// customerDeleted restricts a query to the customers soft deleted.
+const customerDeleted = "date_deleted is not null"
+
 type CustomerPostgresDB struct {
 	DB          *gorm.DB
 	LoggerSugar *zap.SugaredLogger
@@ -25,19 +44,15 @@ func NewCustomerPostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) Cust
 	}
 }
 
-func (cp CustomerPostgresDB) GetByID(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, error) {
-	//TODO implement me
-	panic("implement me")
-}
-
 type CustomerDB struct {
-	ID         int64  `gorm:"primaryKey, column:id"`
-	Name       string `gorm:"column:name"`
-	Email      string `gorm:"column:email"`
-	Document   string `gorm:"column:document"`
-	PersonType string `gorm:"column:person_type"`
-	ContractID int64  `gorm:"column:fk_id_contract"`
-	AddressID  int64  `gorm:"column:fk_id_address"`
+	ID          int64      `gorm:"primaryKey, column:id"`
+	Name        string     `gorm:"column:name"`
+	Email       string     `gorm:"column:email"`
+	Document    string     `gorm:"column:document"`
+	PersonType  string     `gorm:"column:person_type"`
+	ContractID  int64      `gorm:"column:fk_id_contract"`
+	AddressID   int64      `gorm:"column:fk_id_address"`
+	DateDeleted *time.Time `gorm:"column:date_deleted"`
 }
 
 func (CustomerDB) TableName() string {
@@ -46,8 +61,14 @@ func (CustomerDB) TableName() string {
 
 func (c CustomerDB) CopyToCustomerDomain() domain.CustomerDomain {
 	return domain.CustomerDomain{
-		ID:   c.ID,
-		Name: c.Name,
+		ID:          c.ID,
+		Name:        c.Name,
+		Email:       c.Email,
+		Document:    c.Document,
+		PersonType:  c.PersonType,
+		ContractID:  c.ContractID,
+		AddressID:   c.AddressID,
+		DateDeleted: c.DateDeleted,
 	}
 }
 
@@ -55,8 +76,9 @@ func (cp CustomerPostgresDB) Save(contextControl domain.ContextControl, customer
 
 	var customerDB CustomerDB
 	copier.Copy(&customerDB, &customerDomain)
+	customerDB.ContractID = contextControl.ScopedContractID(customerDB.ContractID)
 
-	if err := cp.DB.WithContext(contextControl.Context).
+	if err := connection(cp.DB, contextControl).
 		Create(&customerDB).Error; err != nil {
 		cp.LoggerSugar.Errorw(CustomerSaveDBError,
 			"error", err.Error())
@@ -65,3 +87,249 @@ func (cp CustomerPostgresDB) Save(contextControl domain.ContextControl, customer
 
 	return customerDB.CopyToCustomerDomain(), nil
 }
+
+func (cp CustomerPostgresDB) GetByID(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+
+	var customerDB CustomerDB
+
+	result := connection(cp.DB, contextControl).Scopes(contractScope(contextControl)).
+		Where(customerNotDeleted).First(&customerDB, ID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			cp.LoggerSugar.Infow(CustomerNotFound, "customer_id", ID)
+			return domain.CustomerDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(CustomerGetByIDDBError, "customer_id", ID, "error", result.Error.Error())
+		return domain.CustomerDomain{}, false, result.Error
+	}
+
+	return customerDB.CopyToCustomerDomain(), true, nil
+}
+
+// GetByIDIncludingDeleted returns the customer even when soft deleted, so it can be restored.
+func (cp CustomerPostgresDB) GetByIDIncludingDeleted(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+
+	var customerDB CustomerDB
+
+	result := connection(cp.DB, contextControl).Scopes(contractScope(contextControl)).First(&customerDB, ID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			cp.LoggerSugar.Infow(CustomerNotFound, "customer_id", ID)
+			return domain.CustomerDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(CustomerGetByIDDBError, "customer_id", ID, "error", result.Error.Error())
+		return domain.CustomerDomain{}, false, result.Error
+	}
+
+	return customerDB.CopyToCustomerDomain(), true, nil
+}
+
+// Update changes the name, email, document and person type of a customer not deleted. It
+// returns a conflict when the customer was deleted since it was read.
+func (cp CustomerPostgresDB) Update(contextControl domain.ContextControl, customerDomain domain.CustomerDomain) error {
+
+	result := connection(cp.DB, contextControl).
+		Model(&CustomerDB{}).
+		Scopes(contractScope(contextControl)).
+		Where("id = ?", customerDomain.ID).
+		Where(customerNotDeleted).
+		Select("name", "email", "document", "person_type").
+		Updates(CustomerDB{
+			Name:       customerDomain.Name,
+			Email:      customerDomain.Email,
+			Document:   customerDomain.Document,
+			PersonType: customerDomain.PersonType,
+		})
+
+	return cp.changed(result, CustomerUpdateDBError, customerDomain.ID)
+}
+
+// Delete soft deletes the customer, marking it with deletedAt. It returns a conflict when the
+// customer was already deleted since it was read.
+func (cp CustomerPostgresDB) Delete(contextControl domain.ContextControl, ID int64, deletedAt time.Time) error {
+
+	result := connection(cp.DB, contextControl).
+		Model(&CustomerDB{}).
+		Scopes(contractScope(contextControl)).
+		Where("id = ?", ID).
+		Where(customerNotDeleted).
+		Update("date_deleted", deletedAt)
+
+	return cp.changed(result, CustomerDeleteDBError, ID)
+}
+
+// Restore clears the deleted-at marker of a deleted customer. It returns a conflict when the
+// customer was already restored since it was read.
+func (cp CustomerPostgresDB) Restore(contextControl domain.ContextControl, ID int64) error {
+
+	result := connection(cp.DB, contextControl).
+		Model(&CustomerDB{}).
+		Scopes(contractScope(contextControl)).
+		Where("id = ?", ID).
+		Where(customerDeleted).
+		Update("date_deleted", nil)
+
+	return cp.changed(result, CustomerRestoreDBError, ID)
+}
+
+// changed checks that the write of the customer found its row, which another request may have
+// deleted or restored between the read of the service and the write.
+func (cp CustomerPostgresDB) changed(result *gorm.DB, message string, ID int64) error {
+
+	if result.Error != nil {
+		cp.LoggerSugar.Errorw(message, "customer_id", ID, "error", result.Error.Error())
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		cp.LoggerSugar.Infow(message, "customer_id", ID, "error", fmt.Sprintf(CustomerChangedByOther, ID))
+		return domain.NewConflictError(CustomerChangedByOther, ID)
+	}
+
+	return nil
+}
+
+// customerFilterScope restricts a query to the customers not deleted matching the filter, whose
+// name and email are expected lower case and document without punctuation.
+func customerFilterScope(filter domain.CustomerFilterDomain) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where(customerNotDeleted)
+		if filter.Name != "" {
+			db = db.Where("lower(name) like ?", containing(filter.Name))
+		}
+		if filter.Document != "" {
+			db = db.Where("document = ?", filter.Document)
+		}
+		if filter.Email != "" {
+			db = db.Where("lower(email) = ?", filter.Email)
+		}
+		return db
+	}
+}
+
+// List returns the page of the customers matching the filter, in ID order.
+func (cp CustomerPostgresDB) List(contextControl domain.ContextControl, filter domain.CustomerFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerDomain], error) {
+
+	var customersDB []CustomerDB
+	if err := connection(cp.DB, contextControl).
+		Scopes(customerFilterScope(filter), contractScope(contextControl), keysetPage(page)).
+		Find(&customersDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(CustomerListDBError, "error", err.Error())
+		return domain.PageResultDomain[domain.CustomerDomain]{}, err
+	}
+
+	total, capped, err := countCapped(connection(cp.DB, contextControl),
+		connection(cp.DB, contextControl).Model(&CustomerDB{}).
+			Scopes(customerFilterScope(filter), contractScope(contextControl)))
+	if err != nil {
+		cp.LoggerSugar.Errorw(CustomerListDBError, "error", err.Error())
+		return domain.PageResultDomain[domain.CustomerDomain]{}, err
+	}
+
+	customers := make([]domain.CustomerDomain, 0, len(customersDB))
+	for _, customerDB := range customersDB {
+		customers = append(customers, customerDB.CopyToCustomerDomain())
+	}
+
+	return newPage(customers, page, total, capped, func(customer domain.CustomerDomain) int64 {
+		return customer.ID
+	}), nil
+}
+
+type CustomerHistoryDB struct {
+	ID          int64     `gorm:"primaryKey, column:id"`
+	CustomerID  int64     `gorm:"column:fk_id_customer"`
+	Action      string    `gorm:"column:action"`
+	Actor       string    `gorm:"column:actor"`
+	RequestID   string    `gorm:"column:request_id"`
+	Description string    `gorm:"column:description"`
+	Changes     string    `gorm:"column:changes"`
+	Date        time.Time `gorm:"column:date;default:now()"`
+}
+
+func (CustomerHistoryDB) TableName() string {
+	return "petshop_api.customer_history"
+}
+
+func (c CustomerHistoryDB) CopyToCustomerHistoryDomain() (domain.CustomerHistoryDomain, error) {
+
+	var changes map[string]domain.CustomerFieldChangeDomain
+	if err := json.Unmarshal([]byte(c.Changes), &changes); err != nil {
+		return domain.CustomerHistoryDomain{}, err
+	}
+
+	return domain.CustomerHistoryDomain{
+		ID:          c.ID,
+		CustomerID:  c.CustomerID,
+		Action:      c.Action,
+		Actor:       c.Actor,
+		RequestID:   c.RequestID,
+		Description: c.Description,
+		Changes:     changes,
+		Date:        c.Date,
+	}, nil
+}
+
+// SaveHistory records a change of a customer, in the transaction of the change when there is one.
+func (cp CustomerPostgresDB) SaveHistory(contextControl domain.ContextControl, historyDomain domain.CustomerHistoryDomain) error {
+
+	changes, err := json.Marshal(historyDomain.Changes)
+	if err != nil {
+		cp.LoggerSugar.Errorw(CustomerHistorySaveDBError, "customer_id", historyDomain.CustomerID, "error", err.Error())
+		return err
+	}
+
+	historyDB := CustomerHistoryDB{
+		CustomerID:  historyDomain.CustomerID,
+		Action:      historyDomain.Action,
+		Actor:       historyDomain.Actor,
+		RequestID:   historyDomain.RequestID,
+		Description: historyDomain.Description,
+		Changes:     string(changes),
+	}
+
+	if err = connection(cp.DB, contextControl).Create(&historyDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(CustomerHistorySaveDBError, "customer_id", historyDomain.CustomerID, "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetHistory returns a page of the changes of the customer, the oldest first.
+func (cp CustomerPostgresDB) GetHistory(contextControl domain.ContextControl, customerID int64, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerHistoryDomain], error) {
+
+	ofCustomer := func(db *gorm.DB) *gorm.DB {
+		return db.Where("fk_id_customer = ?", customerID)
+	}
+
+	var historiesDB []CustomerHistoryDB
+	if err := connection(cp.DB, contextControl).
+		Scopes(ofCustomer, customerHistoryContractScope(contextControl), keysetPage(page)).
+		Find(&historiesDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(CustomerHistoryGetDBError, "customer_id", customerID, "error", err.Error())
+		return domain.PageResultDomain[domain.CustomerHistoryDomain]{}, err
+	}
+
+	total, capped, err := countCapped(connection(cp.DB, contextControl),
+		connection(cp.DB, contextControl).Model(&CustomerHistoryDB{}).
+			Scopes(ofCustomer, customerHistoryContractScope(contextControl)))
+	if err != nil {
+		cp.LoggerSugar.Errorw(CustomerHistoryGetDBError, "customer_id", customerID, "error", err.Error())
+		return domain.PageResultDomain[domain.CustomerHistoryDomain]{}, err
+	}
+
+	histories := make([]domain.CustomerHistoryDomain, 0, len(historiesDB))
+	for _, historyDB := range historiesDB {
+		history, err := historyDB.CopyToCustomerHistoryDomain()
+		if err != nil {
+			cp.LoggerSugar.Errorw(CustomerHistoryGetDBError, "customer_id", customerID, "error", err.Error())
+			return domain.PageResultDomain[domain.CustomerHistoryDomain]{}, err
+		}
+		histories = append(histories, history)
+	}
+
+	return newPage(histories, page, total, capped, func(history domain.CustomerHistoryDomain) int64 {
+		return history.ID
+	}), nil
+}
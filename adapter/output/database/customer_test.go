@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestCustomerPostgresDB_Write(t *testing.T) {
+
+	contextControl := domain.ContextControl{Context: context.Background(), ContractID: 2}
+
+	writes := []struct {
+		Name          string
+		Write         func(customerDB CustomerPostgresDB) error
+		ExpectedWhere string
+	}{
+		{
+			Name: "Update",
+			Write: func(customerDB CustomerPostgresDB) error {
+				return customerDB.Update(contextControl, domain.CustomerDomain{ID: 5, Name: "Fulano"})
+			},
+			ExpectedWhere: `WHERE id = $5 AND date_deleted is null AND fk_id_contract = $6`,
+		},
+		{
+			Name: "Delete",
+			Write: func(customerDB CustomerPostgresDB) error {
+				return customerDB.Delete(contextControl, 5, time.Now())
+			},
+			ExpectedWhere: `WHERE id = $2 AND date_deleted is null AND fk_id_contract = $3`,
+		},
+		{
+			Name: "Restore",
+			Write: func(customerDB CustomerPostgresDB) error {
+				return customerDB.Restore(contextControl, 5)
+			},
+			ExpectedWhere: `WHERE id = $2 AND date_deleted is not null AND fk_id_contract = $3`,
+		},
+	}
+
+	for _, write := range writes {
+
+		t.Run(write.Name+"_WithRowChangedByAnotherRequest_ReturnsConflict", func(t *testing.T) {
+
+			db := dryRunDB(t)
+			var statement string
+			assert.Nil(t, db.Callback().Update().After("gorm:update").Register("test:statement", func(db *gorm.DB) {
+				statement = db.Statement.SQL.String()
+			}))
+
+			err := write.Write(NewCustomerPostgresDB(db, zap.NewNop().Sugar()))
+			assert.ErrorIs(t, err, domain.ErrConflict)
+			assert.Contains(t, statement, write.ExpectedWhere)
+		})
+
+		t.Run(write.Name+"_WithRowFound_Succeeds", func(t *testing.T) {
+
+			db := dryRunDB(t)
+			assert.Nil(t, db.Callback().Update().After("gorm:update").Register("test:rows", func(db *gorm.DB) {
+				db.RowsAffected = 1
+			}))
+
+			assert.Nil(t, write.Write(NewCustomerPostgresDB(db, zap.NewNop().Sugar())))
+		})
+	}
+}
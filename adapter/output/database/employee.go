@@ -0,0 +1,176 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	EmployeeSaveDBError   = "error to save the employee into postgres"
+	EmployeeUpdateDBError = "error to update the employee into postgres"
+	EmployeeGetDBError    = "error to get an employee"
+	EmployeeGetAllDBError = "error to get the employees"
+	EmployeeNotFound      = "employee not found"
+	EmployeeAlreadyExists = "there is already an employee with this register or document"
+)
+
+type EmployeePostgresDB struct {
+	DB          *gorm.DB
+	LoggerSugar *zap.SugaredLogger
+}
+
+func NewEmployeePostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) EmployeePostgresDB {
+	return EmployeePostgresDB{
+		DB:          gormDB,
+		LoggerSugar: loggerSugar,
+	}
+}
+
+type EmployeeDB struct {
+	ID          int64     `gorm:"primaryKey, column:id"`
+	Name        string    `gorm:"column:name"`
+	Register    string    `gorm:"column:register"`
+	Document    string    `gorm:"column:document"`
+	DateCreated time.Time `gorm:"column:date_created;default:now()"`
+	Active      bool      `gorm:"column:active"`
+	ContractID  int64     `gorm:"column:fk_id_contract"`
+}
+
+func (EmployeeDB) TableName() string {
+	return "petshop_api.employee"
+}
+
+func (c EmployeeDB) CopyToEmployeeDomain() domain.EmployeeDomain {
+	return domain.EmployeeDomain{
+		ID:          c.ID,
+		Name:        c.Name,
+		Register:    c.Register,
+		Document:    c.Document,
+		DateCreated: c.DateCreated,
+		Active:      c.Active,
+		ContractID:  c.ContractID,
+	}
+}
+
+func (cp EmployeePostgresDB) Save(contextControl domain.ContextControl, employeeDomain domain.EmployeeDomain) (domain.EmployeeDomain, error) {
+
+	employeeDB := EmployeeDB{
+		Name:       employeeDomain.Name,
+		Register:   employeeDomain.Register,
+		Document:   employeeDomain.Document,
+		Active:     employeeDomain.Active,
+		ContractID: contextControl.ScopedContractID(employeeDomain.ContractID),
+	}
+
+	// active is listed so a new inactive employee isn't replaced by the column default
+	if err := connection(cp.DB, contextControl).
+		Select("name", "register", "document", "active", "fk_id_contract").
+		Create(&employeeDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(EmployeeSaveDBError,
+			"error", err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.EmployeeDomain{}, domain.NewConflictError(EmployeeAlreadyExists)
+		}
+		return domain.EmployeeDomain{}, err
+	}
+
+	return employeeDB.CopyToEmployeeDomain(), nil
+}
+
+func (cp EmployeePostgresDB) Update(contextControl domain.ContextControl, employeeDomain domain.EmployeeDomain) error {
+
+	err := connection(cp.DB, contextControl).
+		Model(&EmployeeDB{}).
+		Scopes(contractScope(contextControl)).
+		Where("id = ?", employeeDomain.ID).
+		Updates(map[string]any{
+			"name":     employeeDomain.Name,
+			"register": employeeDomain.Register,
+			"document": employeeDomain.Document,
+		}).Error
+
+	if err != nil {
+		cp.LoggerSugar.Errorw(EmployeeUpdateDBError,
+			"employee_id", employeeDomain.ID, "error", err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.NewConflictError(EmployeeAlreadyExists)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (cp EmployeePostgresDB) SetActive(contextControl domain.ContextControl, ID int64, active bool) error {
+
+	if err := connection(cp.DB, contextControl).
+		Model(&EmployeeDB{}).
+		Scopes(contractScope(contextControl)).
+		Where("id = ?", ID).
+		Update("active", active).Error; err != nil {
+		cp.LoggerSugar.Errorw(EmployeeUpdateDBError,
+			"employee_id", ID, "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (cp EmployeePostgresDB) GetByID(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+	return cp.getBy(contextControl, contractScope(contextControl), "id = ?", ID)
+}
+
+func (cp EmployeePostgresDB) GetByRegister(contextControl domain.ContextControl, register string) (domain.EmployeeDomain, bool, error) {
+	return cp.getBy(contextControl, unscoped, "register = ?", register)
+}
+
+func (cp EmployeePostgresDB) GetByDocument(contextControl domain.ContextControl, document string) (domain.EmployeeDomain, bool, error) {
+	return cp.getBy(contextControl, unscoped, "document = ?", document)
+}
+
+func (cp EmployeePostgresDB) GetAll(contextControl domain.ContextControl) ([]domain.EmployeeDomain, error) {
+
+	var employeesDB []EmployeeDB
+
+	if err := connection(cp.DB, contextControl).
+		Scopes(contractScope(contextControl)).
+		Order("id").
+		Find(&employeesDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(EmployeeGetAllDBError, "error", err.Error())
+		return nil, err
+	}
+
+	employees := make([]domain.EmployeeDomain, 0, len(employeesDB))
+	for _, employeeDB := range employeesDB {
+		employees = append(employees, employeeDB.CopyToEmployeeDomain())
+	}
+
+	return employees, nil
+}
+
+// unscoped leaves the query untouched; register and document are unique across every
+// contract, so the uniqueness checks must see the employees of the other contracts too.
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db
+}
+
+func (cp EmployeePostgresDB) getBy(contextControl domain.ContextControl, scope func(*gorm.DB) *gorm.DB, query string, value any) (domain.EmployeeDomain, bool, error) {
+
+	var employeeDB EmployeeDB
+
+	result := connection(cp.DB, contextControl).Scopes(scope).Where(query, value).First(&employeeDB)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			cp.LoggerSugar.Infow(EmployeeNotFound, "value", value)
+			return domain.EmployeeDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(EmployeeGetDBError, "value", value, "error", result.Error.Error())
+		return domain.EmployeeDomain{}, false, result.Error
+	}
+
+	return employeeDB.CopyToEmployeeDomain(), true, nil
+}
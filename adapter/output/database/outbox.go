@@ -0,0 +1,240 @@
+package database
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	OutboxSaveDBError          = "error to save the events into the outbox"
+	OutboxEncodeError          = "error to encode the event payload"
+	OutboxLockDBError          = "error to lock the outbox"
+	OutboxClaimDBError         = "error to claim the pending events of the outbox"
+	OutboxMarkPublishedDBError = "error to mark the outbox event as published"
+	OutboxMarkFailedDBError    = "error to record the outbox event failure"
+	OutboxParkDBError          = "error to park the outbox event"
+	OutboxDeleteDBError        = "error to delete the published events of the outbox"
+)
+
+// outboxLockKey identifies the advisory lock held by the relay publishing the outbox, so only
+// one instance publishes at a time and the events keep their order.
+const outboxLockKey = 7_340_001
+
+const (
+	OutboxTryLockQuery = "select pg_try_advisory_xact_lock(?)"
+	// OutboxClaimQuery leases the events due whose key has no earlier event waiting for a retry or
+	// being published, so the events of a key keep their order while the other keys go on.
+	// Parked events hold nothing back.
+	OutboxClaimQuery = "update petshop_api.outbox set next_attempt_at = ? where id in " +
+		"(select o.id from petshop_api.outbox o " +
+		"where o.date_published is null and o.date_parked is null and o.next_attempt_at <= ? " +
+		"and not exists (select 1 from petshop_api.outbox e where e.aggregate_key = o.aggregate_key " +
+		"and e.id < o.id and e.date_published is null and e.date_parked is null and e.next_attempt_at > ?) " +
+		"order by o.id limit ?) returning *"
+	OutboxDeleteQuery = "delete from petshop_api.outbox where id in " +
+		"(select id from petshop_api.outbox where date_published < ? order by id limit ?)"
+)
+
+type OutboxPostgresDB struct {
+	DB          *gorm.DB
+	LoggerSugar *zap.SugaredLogger
+}
+
+func NewOutboxPostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) OutboxPostgresDB {
+	return OutboxPostgresDB{
+		DB:          gormDB,
+		LoggerSugar: loggerSugar,
+	}
+}
+
+type OutboxDB struct {
+	ID            int64      `gorm:"primaryKey, column:id"`
+	EventID       string     `gorm:"column:event_id"`
+	EventType     string     `gorm:"column:event_type"`
+	EventVersion  int        `gorm:"column:event_version"`
+	AggregateKey  string     `gorm:"column:aggregate_key"`
+	CorrelationID string     `gorm:"column:correlation_id"`
+	Payload       string     `gorm:"column:payload"`
+	OccurredAt    time.Time  `gorm:"column:occurred_at"`
+	DateCreated   time.Time  `gorm:"column:date_created;default:now()"`
+	DatePublished *time.Time `gorm:"column:date_published"`
+	Attempts      int        `gorm:"column:attempts"`
+	LastError     string     `gorm:"column:last_error"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;default:now()"`
+	DateParked    *time.Time `gorm:"column:date_parked"`
+}
+
+func (OutboxDB) TableName() string {
+	return "petshop_api.outbox"
+}
+
+func (c OutboxDB) CopyToOutboxDomain() domain.OutboxDomain {
+	return domain.OutboxDomain{
+		ID: c.ID,
+		Event: domain.EventDomain{
+			ID:            c.EventID,
+			Type:          c.EventType,
+			Version:       c.EventVersion,
+			Key:           c.AggregateKey,
+			CorrelationID: c.CorrelationID,
+			OccurredAt:    c.OccurredAt,
+			Payload:       json.RawMessage(c.Payload),
+		},
+		Attempts:      c.Attempts,
+		LastError:     c.LastError,
+		NextAttemptAt: c.NextAttemptAt,
+		DatePublished: c.DatePublished,
+		DateParked:    c.DateParked,
+	}
+}
+
+func newOutboxDB(event domain.EventDomain) (OutboxDB, error) {
+
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return OutboxDB{}, err
+	}
+
+	return OutboxDB{
+		EventID:       event.ID,
+		EventType:     event.Type,
+		EventVersion:  event.Version,
+		AggregateKey:  event.Key,
+		CorrelationID: event.CorrelationID,
+		Payload:       string(payload),
+		OccurredAt:    event.OccurredAt,
+	}, nil
+}
+
+// Save stores the events, joining the transaction of the contextControl when there is one.
+func (cp OutboxPostgresDB) Save(contextControl domain.ContextControl, events ...domain.EventDomain) error {
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	outboxDB := make([]OutboxDB, 0, len(events))
+	for _, event := range events {
+		row, err := newOutboxDB(event)
+		if err != nil {
+			cp.LoggerSugar.Errorw(OutboxEncodeError, "event_type", event.Type, "event_id", event.ID,
+				"error", err.Error())
+			return err
+		}
+		outboxDB = append(outboxDB, row)
+	}
+
+	if err := connection(cp.DB, contextControl).Create(&outboxDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(OutboxSaveDBError, "event_type", events[0].Type, "events", len(events),
+			"error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// TryLock takes the lock of the relay until the end of the transaction of the contextControl,
+// returning false when another relay holds it.
+func (cp OutboxPostgresDB) TryLock(contextControl domain.ContextControl) (bool, error) {
+
+	var locked bool
+	if err := connection(cp.DB, contextControl).
+		Raw(OutboxTryLockQuery, outboxLockKey).Scan(&locked).Error; err != nil {
+		cp.LoggerSugar.Errorw(OutboxLockDBError, "error", err.Error())
+		return false, err
+	}
+
+	return locked, nil
+}
+
+// ClaimPending returns up to limit events due at now, in the order they were stored, and
+// postpones their next attempt to claimUntil so no other relay takes them while they are being
+// published. An event left claimed by a relay that stopped is taken again after claimUntil.
+func (cp OutboxPostgresDB) ClaimPending(contextControl domain.ContextControl, now, claimUntil time.Time, limit int) ([]domain.OutboxDomain, error) {
+
+	var outboxDB []OutboxDB
+	if err := connection(cp.DB, contextControl).
+		Raw(OutboxClaimQuery, claimUntil, now, now, limit).
+		Scan(&outboxDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(OutboxClaimDBError, "error", err.Error())
+		return nil, err
+	}
+	// returning doesn't keep the order of the subquery
+	sort.Slice(outboxDB, func(i, j int) bool { return outboxDB[i].ID < outboxDB[j].ID })
+
+	pending := make([]domain.OutboxDomain, 0, len(outboxDB))
+	for _, row := range outboxDB {
+		pending = append(pending, row.CopyToOutboxDomain())
+	}
+
+	return pending, nil
+}
+
+func (cp OutboxPostgresDB) MarkPublished(contextControl domain.ContextControl, ID int64, publishedAt time.Time) error {
+
+	if err := connection(cp.DB, contextControl).
+		Model(&OutboxDB{}).
+		Where("id = ?", ID).
+		Update("date_published", publishedAt).Error; err != nil {
+		cp.LoggerSugar.Errorw(OutboxMarkPublishedDBError, "outbox_id", ID, "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (cp OutboxPostgresDB) MarkFailed(contextControl domain.ContextControl, ID int64, attempts int,
+	nextAttemptAt time.Time, lastError string) error {
+
+	if err := connection(cp.DB, contextControl).
+		Model(&OutboxDB{}).
+		Where("id = ?", ID).
+		Updates(map[string]any{
+			"attempts":        attempts,
+			"next_attempt_at": nextAttemptAt,
+			"last_error":      lastError,
+		}).Error; err != nil {
+		cp.LoggerSugar.Errorw(OutboxMarkFailedDBError, "outbox_id", ID, "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Park gives up on an event that failed too many times: it is no longer published nor holds the
+// following events of its key back, and stays in the outbox with its last error.
+func (cp OutboxPostgresDB) Park(contextControl domain.ContextControl, ID int64, attempts int,
+	parkedAt time.Time, lastError string) error {
+
+	if err := connection(cp.DB, contextControl).
+		Model(&OutboxDB{}).
+		Where("id = ?", ID).
+		Updates(map[string]any{
+			"attempts":    attempts,
+			"date_parked": parkedAt,
+			"last_error":  lastError,
+		}).Error; err != nil {
+		cp.LoggerSugar.Errorw(OutboxParkDBError, "outbox_id", ID, "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// DeletePublishedBefore prunes up to limit events published before the date, oldest first, and
+// returns how many were deleted.
+func (cp OutboxPostgresDB) DeletePublishedBefore(contextControl domain.ContextControl, before time.Time, limit int) (int64, error) {
+
+	result := connection(cp.DB, contextControl).Exec(OutboxDeleteQuery, before, limit)
+	if result.Error != nil {
+		cp.LoggerSugar.Errorw(OutboxDeleteDBError, "before", before, "error", result.Error.Error())
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
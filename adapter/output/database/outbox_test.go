@@ -0,0 +1,26 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxClaimQuery(t *testing.T) {
+
+	now := time.Date(2030, 12, 10, 9, 0, 0, 0, time.UTC)
+	claimUntil := now.Add(time.Minute)
+
+	var outboxDB []OutboxDB
+	statement := dryRunDB(t).WithContext(context.Background()).
+		Raw(OutboxClaimQuery, claimUntil, now, now, 100).Scan(&outboxDB).Statement
+
+	// the lease comes first, then the time the events must be due at, for the event and its key
+	assert.Contains(t, statement.SQL.String(), "set next_attempt_at = $1")
+	assert.Contains(t, statement.SQL.String(), "o.next_attempt_at <= $2")
+	assert.Contains(t, statement.SQL.String(), "e.next_attempt_at > $3")
+	assert.Contains(t, statement.SQL.String(), "limit $4")
+	assert.Equal(t, []any{claimUntil, now, now, 100}, statement.Vars)
+}
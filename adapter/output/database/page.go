@@ -0,0 +1,50 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"gorm.io/gorm"
+)
+
+// pageTotalCap bounds the count of the rows matching a list, so a broad filter on a large table
+// doesn't scan all of it; the total is reported as capped beyond it.
+const pageTotalCap = 10_000
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// keysetPage reads the rows after the cursor in ID order, one more than the size of the page
+// telling whether there is a next one.
+func keysetPage(page domain.PageDomain) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("id > ?", page.Cursor).Order("id").Limit(page.Size + 1)
+	}
+}
+
+// containing matches a column holding the text anywhere, the wildcards of the text taken literally.
+func containing(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
+
+// countCapped counts the rows of the filtered query up to pageTotalCap.
+func countCapped(db *gorm.DB, filtered *gorm.DB) (int64, bool, error) {
+	var total int64
+	if err := db.Table("(?) as matches", filtered.Select("1").Limit(pageTotalCap)).
+		Count(&total).Error; err != nil {
+		return 0, false, err
+	}
+	return total, total >= pageTotalCap, nil
+}
+
+// newPage trims the rows read by keysetPage to the size of the page, setting the cursor of the
+// next page when there is one more row.
+func newPage[T any](items []T, page domain.PageDomain, total int64, capped bool, ID func(T) int64) domain.PageResultDomain[T] {
+
+	result := domain.PageResultDomain[T]{Items: items, TotalEstimate: total, TotalCapped: capped}
+	if len(items) > page.Size {
+		result.Items = items[:page.Size]
+		result.NextCursor = ID(result.Items[page.Size-1])
+	}
+
+	return result
+}
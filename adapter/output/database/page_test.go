@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomerFilterScope(t *testing.T) {
+
+	contextControl := domain.ContextControl{Context: context.Background(), ContractID: 2}
+
+	tests := []struct {
+		Name         string
+		Filter       domain.CustomerFilterDomain
+		ExpectedSQL  string
+		ExpectedVars []any
+	}{
+		{
+			Name:         "WithoutFilter_ListsTheCustomersNotDeleted",
+			ExpectedSQL:  `SELECT * FROM "petshop_api"."customer" WHERE date_deleted is null AND fk_id_contract = $1 AND id > $2 ORDER BY id LIMIT $3`,
+			ExpectedVars: []any{int64(2), int64(10), 21},
+		},
+		{
+			Name:         "WithAllFilters_MatchesThemAll",
+			Filter:       domain.CustomerFilterDomain{Name: "50%_off", Document: "29623057091", Email: "fulano@email.com"},
+			ExpectedSQL:  `SELECT * FROM "petshop_api"."customer" WHERE date_deleted is null AND lower(name) like $1 AND document = $2 AND lower(email) = $3 AND fk_id_contract = $4 AND id > $5 ORDER BY id LIMIT $6`,
+			ExpectedVars: []any{`%50\%\_off%`, "29623057091", "fulano@email.com", int64(2), int64(10), 21},
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			var customersDB []CustomerDB
+			statement := dryRunDB(t).
+				Scopes(customerFilterScope(test.Filter), contractScope(contextControl),
+					keysetPage(domain.PageDomain{Size: 20, Cursor: 10})).
+				Find(&customersDB).Statement
+
+			assert.Equal(t, test.ExpectedSQL, statement.SQL.String())
+			assert.Equal(t, test.ExpectedVars, statement.Vars)
+		})
+	}
+}
+
+func TestAddressFilterScope(t *testing.T) {
+
+	var addressesDB []AddressDB
+	statement := dryRunDB(t).
+		Scopes(addressFilterScope(domain.AddressFilterDomain{ZipCode: "36025200", City: "juiz de fora"}),
+			keysetPage(domain.PageDomain{Size: 5})).
+		Find(&addressesDB).Statement
+
+	assert.Equal(t, `SELECT * FROM "petshop_api"."address" WHERE regexp_replace(zip_code, '\D', '', 'g') = $1 AND lower(city) = $2 AND id > $3 ORDER BY id LIMIT $4`,
+		statement.SQL.String())
+	assert.Equal(t, []any{"36025200", "juiz de fora", int64(0), 6}, statement.Vars)
+}
+
+func TestNewPage(t *testing.T) {
+
+	ID := func(ID int64) int64 { return ID }
+
+	tests := []struct {
+		Name               string
+		Items              []int64
+		ExpectedItems      []int64
+		ExpectedNextCursor int64
+	}{
+		{Name: "WithOneRowMoreThanThePage_SetsTheNextCursor", Items: []int64{3, 5, 8}, ExpectedItems: []int64{3, 5}, ExpectedNextCursor: 5},
+		{Name: "WithTheRowsOfThePage_IsTheLastPage", Items: []int64{3, 5}, ExpectedItems: []int64{3, 5}},
+		{Name: "WithoutRows_IsTheLastPage", Items: []int64{}, ExpectedItems: []int64{}},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+			page := newPage(test.Items, domain.PageDomain{Size: 2}, 7, false, ID)
+			assert.Equal(t, test.ExpectedItems, page.Items)
+			assert.Equal(t, test.ExpectedNextCursor, page.NextCursor)
+			assert.Equal(t, int64(7), page.TotalEstimate)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"github.com/petshop-system/petshop-api/application/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	PetSaveDBError            = "error to save the pet into postgres"
+	PetGetByIDDBError         = "error to get a pet by id"
+	PetGetByCustomerIDDBError = "error to get the pets of a customer"
+	PetNotFound               = "pet not found"
+)
+
+type PetPostgresDB struct {
+	DB          *gorm.DB
+	LoggerSugar *zap.SugaredLogger
+}
+
+func NewPetPostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) PetPostgresDB {
+	return PetPostgresDB{
+		DB:          gormDB,
+		LoggerSugar: loggerSugar,
+	}
+}
+
+type PetDB struct {
+	ID           int64      `gorm:"primaryKey, column:id"`
+	Name         string     `gorm:"column:name"`
+	DateCreated  time.Time  `gorm:"column:date_created;default:now()"`
+	DateBirthday time.Time  `gorm:"column:date_birthday"`
+	DateDeleted  *time.Time `gorm:"column:date_deleted"`
+	CustomerID   int64      `gorm:"column:fk_id_customer"`
+	BreedID      int64      `gorm:"column:fk_id_breed"`
+	ContractID   int64      `gorm:"column:fk_id_contract"`
+}
+
+func (PetDB) TableName() string {
+	return "petshop_api.pet"
+}
+
+func (c PetDB) CopyToPetDomain() domain.PetDomain {
+	return domain.PetDomain{
+		ID:           c.ID,
+		Name:         c.Name,
+		DateCreated:  c.DateCreated,
+		DateBirthday: c.DateBirthday,
+		DateDeleted:  c.DateDeleted,
+		CustomerID:   c.CustomerID,
+		BreedID:      c.BreedID,
+		ContractID:   c.ContractID,
+	}
+}
+
+func (cp PetPostgresDB) Save(contextControl domain.ContextControl, petDomain domain.PetDomain) (domain.PetDomain, error) {
+
+	var petDB PetDB
+	copier.Copy(&petDB, &petDomain)
+	petDB.ContractID = contextControl.ScopedContractID(petDB.ContractID)
+
+	if err := connection(cp.DB, contextControl).
+		Create(&petDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(PetSaveDBError,
+			"error", err.Error())
+		return domain.PetDomain{}, err
+	}
+
+	return petDB.CopyToPetDomain(), nil
+}
+
+func (cp PetPostgresDB) GetByID(contextControl domain.ContextControl, ID int64) (domain.PetDomain, bool, error) {
+
+	var petDB PetDB
+
+	result := connection(cp.DB, contextControl).
+		Scopes(contractScope(contextControl)).
+		Where("date_deleted is null").
+		First(&petDB, ID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			cp.LoggerSugar.Infow(PetNotFound, "pet_id", ID)
+			return domain.PetDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(PetGetByIDDBError, "pet_id", ID, "error", result.Error.Error())
+		return domain.PetDomain{}, false, result.Error
+	}
+
+	return petDB.CopyToPetDomain(), true, nil
+}
+
+func (cp PetPostgresDB) GetByCustomerID(contextControl domain.ContextControl, customerID int64) ([]domain.PetDomain, error) {
+
+	var petsDB []PetDB
+
+	if err := connection(cp.DB, contextControl).
+		Scopes(contractScope(contextControl)).
+		Where("fk_id_customer = ? and date_deleted is null", customerID).
+		Order("id").
+		Find(&petsDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(PetGetByCustomerIDDBError, "customer_id", customerID, "error", err.Error())
+		return nil, err
+	}
+
+	pets := make([]domain.PetDomain, 0, len(petsDB))
+	for _, petDB := range petsDB {
+		pets = append(pets, petDB.CopyToPetDomain())
+	}
+
+	return pets, nil
+}
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"errors"
+
 	"github.com/jinzhu/copier"
 	"github.com/petshop-system/petshop-api/application/domain"
 	"github.com/petshop-system/petshop-api/application/utils"
@@ -14,9 +16,18 @@ type PhonePostgresDB struct {
 }
 
 const (
-	PhoneSaveError = "error to save the phone into postgres"
-	//PhoneGetByIDDBError = "error to get a phone by id"
-	PhoneNotFound = "phone not found"
+	PhoneSaveError      = "error to save the phone into postgres"
+	PhoneGetByIDDBError = "error to get a phone by id"
+	PhoneNotFound       = "phone not found"
+	PhoneListDBError    = "error to list the phones"
+
+	PhoneUserSaveDBError       = "error to link the phone to its owner"
+	PhoneUserGetDBError        = "error to get the owner of the phone"
+	PhoneUserGetByOwnerDBError = "error to get the phones of the owner"
+	PhoneUserDeleteDBError     = "error to unlink the phone from its owner"
+	PhoneUserPrimaryDBError    = "error to set the primary phone of the owner"
+	PhoneUserAlreadyLinked     = "the phone %d is already linked to an owner"
+	PhoneUserPrimaryChanged    = "the primary phone of the owner was changed by another request"
 )
 
 func NewPhonePostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) PhonePostgresDB {
@@ -27,10 +38,11 @@ func NewPhonePostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) PhonePo
 }
 
 type PhoneDB struct {
-	ID        int64  `gorm:"primaryKey, column:id"`
-	Number    string `gorm:"column:number"`
-	CodeArea  string `gorm:"column:code_area"`
-	PhoneType string `gorm:"column:phone_type"`
+	ID         int64  `gorm:"primaryKey, column:id"`
+	Number     string `gorm:"column:number"`
+	CodeArea   string `gorm:"column:code_area"`
+	PhoneType  string `gorm:"column:phone_type"`
+	ContractID *int64 `gorm:"column:fk_id_contract"`
 }
 
 func (PhoneDB) TableName() string {
@@ -52,8 +64,9 @@ func (cp PhonePostgresDB) Save(contextControl domain.ContextControl, phoneDomain
 	copier.Copy(&phoneDB, &phoneDomain)
 	phoneDB.Number = utils.RemoveNonAlphaNumericCharacters(phoneDB.Number)
 	phoneDB.CodeArea = utils.RemoveNonAlphaNumericCharacters(phoneDB.CodeArea)
+	phoneDB.ContractID = scopedContractID(contextControl)
 
-	if err := cp.DB.WithContext(contextControl.Context).Create(&phoneDB).Error; err != nil {
+	if err := connection(cp.DB, contextControl).Create(&phoneDB).Error; err != nil {
 		cp.LoggerSugar.Errorw(PhoneSaveError, "error", err.Error())
 		return domain.PhoneDomain{}, err
 	}
@@ -64,10 +77,205 @@ func (cp PhonePostgresDB) GetByID(contextControl domain.ContextControl, ID int64
 
 	var phoneDB PhoneDB
 
-	result := cp.DB.WithContext(contextControl.Context).First(&phoneDB, ID)
-	if result.RowsAffected == 0 {
-		cp.LoggerSugar.Errorw(PhoneNotFound)
-		return domain.PhoneDomain{}, false, nil
+	result := connection(cp.DB, contextControl).Scopes(contractScope(contextControl)).First(&phoneDB, ID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			cp.LoggerSugar.Infow(PhoneNotFound, "phone_id", ID)
+			return domain.PhoneDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(PhoneGetByIDDBError, "phone_id", ID, "error", result.Error.Error())
+		return domain.PhoneDomain{}, false, result.Error
 	}
 	return phoneDB.CopyToPhoneDomain(), true, nil
 }
+
+type PhoneUserDB struct {
+	ID       int64  `gorm:"primaryKey, column:id"`
+	PhoneID  int64  `gorm:"column:fk_id_phone"`
+	UserID   int64  `gorm:"column:fk_id_user"`
+	UserType string `gorm:"column:user_type"`
+	Primary  bool   `gorm:"column:is_primary"`
+}
+
+func (PhoneUserDB) TableName() string {
+	return "petshop_api.phone_user"
+}
+
+func (c PhoneUserDB) CopyToPhoneUserDomain() domain.PhoneUserDomain {
+	return domain.PhoneUserDomain{
+		ID:       c.ID,
+		PhoneID:  c.PhoneID,
+		UserID:   c.UserID,
+		UserType: c.UserType,
+		Primary:  c.Primary,
+	}
+}
+
+// phoneOfUserDB is a link read with the phone it points to.
+type phoneOfUserDB struct {
+	PhoneUserDB
+	Number    string `gorm:"column:number"`
+	CodeArea  string `gorm:"column:code_area"`
+	PhoneType string `gorm:"column:phone_type"`
+}
+
+func (c phoneOfUserDB) CopyToPhoneUserDomain() domain.PhoneUserDomain {
+	phoneUser := c.PhoneUserDB.CopyToPhoneUserDomain()
+	phoneUser.Phone = domain.PhoneDomain{
+		ID:        c.PhoneID,
+		Number:    c.Number,
+		CodeArea:  c.CodeArea,
+		PhoneType: c.PhoneType,
+	}
+	return phoneUser
+}
+
+// SaveUser links the phone to its owner. A phone has a single owner, linking it again is a conflict.
+func (cp PhonePostgresDB) SaveUser(contextControl domain.ContextControl, phoneUserDomain domain.PhoneUserDomain) (domain.PhoneUserDomain, error) {
+
+	phoneUserDB := PhoneUserDB{
+		PhoneID:  phoneUserDomain.PhoneID,
+		UserID:   phoneUserDomain.UserID,
+		UserType: phoneUserDomain.UserType,
+		Primary:  phoneUserDomain.Primary,
+	}
+
+	if err := connection(cp.DB, contextControl).Create(&phoneUserDB).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			cp.LoggerSugar.Infow(PhoneUserSaveDBError, "phone_id", phoneUserDomain.PhoneID, "error", err.Error())
+			return domain.PhoneUserDomain{}, domain.NewConflictError(PhoneUserAlreadyLinked, phoneUserDomain.PhoneID)
+		}
+		cp.LoggerSugar.Errorw(PhoneUserSaveDBError, "phone_id", phoneUserDomain.PhoneID, "error", err.Error())
+		return domain.PhoneUserDomain{}, err
+	}
+
+	return phoneUserDB.CopyToPhoneUserDomain(), nil
+}
+
+// GetUserByPhoneID returns the link of the phone to its owner.
+func (cp PhonePostgresDB) GetUserByPhoneID(contextControl domain.ContextControl, phoneID int64) (domain.PhoneUserDomain, bool, error) {
+
+	var phoneUserDB PhoneUserDB
+
+	result := connection(cp.DB, contextControl).
+		Scopes(phoneUserContractScope(contextControl)).
+		Where("fk_id_phone = ?", phoneID).
+		First(&phoneUserDB)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return domain.PhoneUserDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(PhoneUserGetDBError, "phone_id", phoneID, "error", result.Error.Error())
+		return domain.PhoneUserDomain{}, false, result.Error
+	}
+
+	return phoneUserDB.CopyToPhoneUserDomain(), true, nil
+}
+
+// GetUsersByOwner lists the phones of the owner, the primary one first.
+func (cp PhonePostgresDB) GetUsersByOwner(contextControl domain.ContextControl, userType string, userID int64) ([]domain.PhoneUserDomain, error) {
+
+	var phonesOfUserDB []phoneOfUserDB
+
+	if err := connection(cp.DB, contextControl).
+		Table("petshop_api.phone_user").
+		Select("phone_user.*, phone.number, phone.code_area, phone.phone_type").
+		Joins("join petshop_api.phone on phone.id = phone_user.fk_id_phone").
+		Scopes(phoneUserContractScope(contextControl)).
+		Where("phone_user.user_type = ? and phone_user.fk_id_user = ?", userType, userID).
+		Order("phone_user.is_primary desc, phone_user.id").
+		Find(&phonesOfUserDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(PhoneUserGetByOwnerDBError, "user_type", userType, "user_id", userID,
+			"error", err.Error())
+		return nil, err
+	}
+
+	phonesOfUser := make([]domain.PhoneUserDomain, 0, len(phonesOfUserDB))
+	for _, phoneOfUser := range phonesOfUserDB {
+		phonesOfUser = append(phonesOfUser, phoneOfUser.CopyToPhoneUserDomain())
+	}
+
+	return phonesOfUser, nil
+}
+
+// DeleteUser unlinks the phone from its owner.
+func (cp PhonePostgresDB) DeleteUser(contextControl domain.ContextControl, phoneID int64) error {
+
+	if err := connection(cp.DB, contextControl).
+		Scopes(phoneUserContractScope(contextControl)).
+		Where("fk_id_phone = ?", phoneID).
+		Delete(&PhoneUserDB{}).Error; err != nil {
+		cp.LoggerSugar.Errorw(PhoneUserDeleteDBError, "phone_id", phoneID, "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// SetPrimaryUser makes the phone the primary one of its owner, in place of the current one.
+func (cp PhonePostgresDB) SetPrimaryUser(contextControl domain.ContextControl, phoneUserDomain domain.PhoneUserDomain) error {
+
+	err := connection(cp.DB, contextControl).Transaction(func(tx *gorm.DB) error {
+
+		if err := tx.Model(&PhoneUserDB{}).
+			Where("user_type = ? and fk_id_user = ? and is_primary", phoneUserDomain.UserType, phoneUserDomain.UserID).
+			Update("is_primary", false).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&PhoneUserDB{}).
+			Where("fk_id_phone = ?", phoneUserDomain.PhoneID).
+			Update("is_primary", true).Error
+	})
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			cp.LoggerSugar.Infow(PhoneUserPrimaryDBError, "phone_id", phoneUserDomain.PhoneID, "error", err.Error())
+			return domain.NewConflictError(PhoneUserPrimaryChanged)
+		}
+		cp.LoggerSugar.Errorw(PhoneUserPrimaryDBError, "phone_id", phoneUserDomain.PhoneID, "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// phoneFilterScope restricts a query to the phones matching the filter, whose number is expected
+// with digits only as it is stored.
+func phoneFilterScope(filter domain.PhoneFilterDomain) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Number != "" {
+			db = db.Where("number = ?", filter.Number)
+		}
+		return db
+	}
+}
+
+// List returns the page of the phones matching the filter, in ID order.
+func (cp PhonePostgresDB) List(contextControl domain.ContextControl, filter domain.PhoneFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.PhoneDomain], error) {
+
+	var phonesDB []PhoneDB
+	if err := connection(cp.DB, contextControl).
+		Scopes(phoneFilterScope(filter), contractScope(contextControl), keysetPage(page)).
+		Find(&phonesDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(PhoneListDBError, "error", err.Error())
+		return domain.PageResultDomain[domain.PhoneDomain]{}, err
+	}
+
+	total, capped, err := countCapped(connection(cp.DB, contextControl),
+		connection(cp.DB, contextControl).Model(&PhoneDB{}).
+			Scopes(phoneFilterScope(filter), contractScope(contextControl)))
+	if err != nil {
+		cp.LoggerSugar.Errorw(PhoneListDBError, "error", err.Error())
+		return domain.PageResultDomain[domain.PhoneDomain]{}, err
+	}
+
+	phones := make([]domain.PhoneDomain, 0, len(phonesDB))
+	for _, phoneDB := range phonesDB {
+		phones = append(phones, phoneDB.CopyToPhoneDomain())
+	}
+
+	return newPage(phones, page, total, capped, func(phone domain.PhoneDomain) int64 {
+		return phone.ID
+	}), nil
+}
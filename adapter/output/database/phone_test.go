@@ -0,0 +1,34 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPhonePostgresDB_GetByID(t *testing.T) {
+
+	t.Run("WithDeadlineExceeded_ReturnsTheError", func(t *testing.T) {
+
+		// the connection pool gives up on an expired context before dialing, so no Postgres is needed
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}),
+			&gorm.Config{DisableAutomaticPing: true})
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+
+		phoneDB := NewPhonePostgresDB(db, zap.NewNop().Sugar())
+		_, exists, err := phoneDB.GetByID(domain.ContextControl{Context: ctx}, 1)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.False(t, exists)
+	})
+}
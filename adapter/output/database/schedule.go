@@ -0,0 +1,355 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ScheduleSaveDBError         = "error to save the schedule into postgres"
+	ScheduleNextNumberDBError   = "error to get the next schedule number"
+	ScheduleGetByBookingDBError = "error to get a schedule by its booking"
+	ScheduleGetBySlotDBError    = "error to get a schedule by its attention time and date"
+	ScheduleGetActiveDBError    = "error to get the active schedules of the attention times"
+	ScheduleLockEmployeeDBError = "error to lock the bookings of the employee"
+	ScheduleGetByIDDBError      = "error to get a schedule by id"
+	ScheduleTransitionDBError   = "error to change the status of the schedule"
+	ScheduleGetHistoryDBError   = "error to get the history of the schedule"
+	ScheduleBookingDuplicated   = "there is already a schedule for this attention time and date"
+	ScheduleStatusChanged       = "the schedule %d is no longer %s, it was changed by another request"
+	ScheduleNumberSequenceQuery = "select nextval('petshop_api.schedule_number_seq')"
+	ScheduleLockEmployeeQuery   = "select pg_advisory_xact_lock(?, ?)"
+)
+
+// scheduleEmployeeLockKey identifies, with the employee, the advisory lock held while booking the
+// employee, beside the one of the outbox relay.
+const scheduleEmployeeLockKey = 7_340_002
+
+// scheduleHoldsSlot restricts a query to the schedules keeping their attention time booked,
+// matching domain.ScheduleStatus.HoldsSlot and the partial unique index on the slot.
+const scheduleHoldsSlot = "status not in ('declined', 'cancelled')"
+
+type SchedulePostgresDB struct {
+	DB          *gorm.DB
+	LoggerSugar *zap.SugaredLogger
+}
+
+func NewSchedulePostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) SchedulePostgresDB {
+	return SchedulePostgresDB{
+		DB:          gormDB,
+		LoggerSugar: loggerSugar,
+	}
+}
+
+type ScheduleDB struct {
+	ID              int64        `gorm:"primaryKey, column:id"`
+	DateCreated     time.Time    `gorm:"column:date_created;default:now()"`
+	DateDeclined    *time.Time   `gorm:"column:date_declined"`
+	Number          string       `gorm:"column:number"`
+	Status          string       `gorm:"column:status"`
+	BookedAt        time.Time    `gorm:"column:booked_at"`
+	Price           domain.Money `gorm:"column:price"`
+	PriceHistoryID  *int64       `gorm:"column:fk_id_service_price_history"`
+	PetID           int64        `gorm:"column:fk_id_pet"`
+	AttentionTimeID int64        `gorm:"column:fk_id_service_employee_attention_time"`
+}
+
+func (ScheduleDB) TableName() string {
+	return "petshop_api.schedule"
+}
+
+func (c ScheduleDB) CopyToScheduleDomain() domain.ScheduleDomain {
+	return domain.ScheduleDomain{
+		ID:              c.ID,
+		DateCreated:     c.DateCreated,
+		DateDeclined:    c.DateDeclined,
+		Number:          c.Number,
+		Status:          domain.ScheduleStatus(c.Status),
+		BookedAt:        c.BookedAt,
+		Price:           c.Price,
+		PriceHistoryID:  valueOfID(c.PriceHistoryID),
+		PetID:           c.PetID,
+		AttentionTimeID: c.AttentionTimeID,
+	}
+}
+
+func newScheduleDB(scheduleDomain domain.ScheduleDomain) ScheduleDB {
+	return ScheduleDB{
+		ID:              scheduleDomain.ID,
+		DateCreated:     scheduleDomain.DateCreated,
+		DateDeclined:    scheduleDomain.DateDeclined,
+		Number:          scheduleDomain.Number,
+		Status:          string(scheduleDomain.Status),
+		BookedAt:        scheduleDomain.BookedAt,
+		Price:           scheduleDomain.Price,
+		PriceHistoryID:  nullableID(scheduleDomain.PriceHistoryID),
+		PetID:           scheduleDomain.PetID,
+		AttentionTimeID: scheduleDomain.AttentionTimeID,
+	}
+}
+
+type ScheduleHistoryDB struct {
+	ID                      int64      `gorm:"primaryKey, column:id"`
+	ScheduleID              int64      `gorm:"column:fk_id_schedule"`
+	Action                  string     `gorm:"column:action"`
+	FromStatus              *string    `gorm:"column:from_status"`
+	ToStatus                string     `gorm:"column:to_status"`
+	Actor                   string     `gorm:"column:actor"`
+	Reason                  string     `gorm:"column:reason"`
+	PreviousBookedAt        *time.Time `gorm:"column:previous_booked_at"`
+	PreviousAttentionTimeID *int64     `gorm:"column:fk_id_previous_attention_time"`
+	DateCreated             time.Time  `gorm:"column:date_created;default:now()"`
+}
+
+func (ScheduleHistoryDB) TableName() string {
+	return "petshop_api.schedule_history"
+}
+
+func (c ScheduleHistoryDB) CopyToScheduleHistoryDomain() domain.ScheduleHistoryDomain {
+
+	var fromStatus domain.ScheduleStatus
+	if c.FromStatus != nil {
+		fromStatus = domain.ScheduleStatus(*c.FromStatus)
+	}
+
+	return domain.ScheduleHistoryDomain{
+		ID:                      c.ID,
+		ScheduleID:              c.ScheduleID,
+		Action:                  domain.ScheduleAction(c.Action),
+		FromStatus:              fromStatus,
+		ToStatus:                domain.ScheduleStatus(c.ToStatus),
+		Actor:                   c.Actor,
+		Reason:                  c.Reason,
+		PreviousBookedAt:        c.PreviousBookedAt,
+		PreviousAttentionTimeID: valueOfID(c.PreviousAttentionTimeID),
+		DateCreated:             c.DateCreated,
+	}
+}
+
+func newScheduleHistoryDB(historyDomain domain.ScheduleHistoryDomain) ScheduleHistoryDB {
+
+	var fromStatus *string
+	if len(historyDomain.FromStatus) > 0 {
+		status := string(historyDomain.FromStatus)
+		fromStatus = &status
+	}
+
+	return ScheduleHistoryDB{
+		ScheduleID:              historyDomain.ScheduleID,
+		Action:                  string(historyDomain.Action),
+		FromStatus:              fromStatus,
+		ToStatus:                string(historyDomain.ToStatus),
+		Actor:                   historyDomain.Actor,
+		Reason:                  historyDomain.Reason,
+		PreviousBookedAt:        historyDomain.PreviousBookedAt,
+		PreviousAttentionTimeID: nullableID(historyDomain.PreviousAttentionTimeID),
+	}
+}
+
+// Save creates the schedule and records its creation in the history, in the same transaction.
+func (cp SchedulePostgresDB) Save(contextControl domain.ContextControl, scheduleDomain domain.ScheduleDomain,
+	historyDomain domain.ScheduleHistoryDomain) (domain.ScheduleDomain, error) {
+
+	scheduleDB := newScheduleDB(scheduleDomain)
+
+	if err := connection(cp.DB, contextControl).Transaction(func(tx *gorm.DB) error {
+
+		if err := tx.Create(&scheduleDB).Error; err != nil {
+			return err
+		}
+
+		historyDomain.ScheduleID = scheduleDB.ID
+		historyDB := newScheduleHistoryDB(historyDomain)
+		return tx.Create(&historyDB).Error
+	}); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			cp.LoggerSugar.Infow(ScheduleBookingDuplicated, "pet_id", scheduleDomain.PetID,
+				"attention_time_id", scheduleDomain.AttentionTimeID, "booked_at", scheduleDomain.BookedAt)
+			return domain.ScheduleDomain{}, domain.NewConflictError(ScheduleBookingDuplicated)
+		}
+		cp.LoggerSugar.Errorw(ScheduleSaveDBError,
+			"error", err.Error())
+		return domain.ScheduleDomain{}, err
+	}
+
+	return scheduleDB.CopyToScheduleDomain(), nil
+}
+
+func (cp SchedulePostgresDB) NextNumberSequence(contextControl domain.ContextControl) (int64, error) {
+
+	var sequence int64
+	if err := connection(cp.DB, contextControl).
+		Raw(ScheduleNumberSequenceQuery).Scan(&sequence).Error; err != nil {
+		cp.LoggerSugar.Errorw(ScheduleNextNumberDBError, "error", err.Error())
+		return 0, err
+	}
+
+	return sequence, nil
+}
+
+func (cp SchedulePostgresDB) GetByBooking(contextControl domain.ContextControl, petID, attentionTimeID int64, bookedAt time.Time) (domain.ScheduleDomain, bool, error) {
+
+	var scheduleDB ScheduleDB
+
+	result := connection(cp.DB, contextControl).
+		Where("fk_id_pet = ? and fk_id_service_employee_attention_time = ? and booked_at = ?",
+			petID, attentionTimeID, bookedAt).
+		Where(scheduleHoldsSlot).
+		First(&scheduleDB)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return domain.ScheduleDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(ScheduleGetByBookingDBError, "pet_id", petID,
+			"attention_time_id", attentionTimeID, "error", result.Error.Error())
+		return domain.ScheduleDomain{}, false, result.Error
+	}
+
+	return scheduleDB.CopyToScheduleDomain(), true, nil
+}
+
+// GetBySlot returns the schedule holding the attention time on the date, whichever pet it is for.
+func (cp SchedulePostgresDB) GetBySlot(contextControl domain.ContextControl, attentionTimeID int64, bookedAt time.Time) (domain.ScheduleDomain, bool, error) {
+
+	var scheduleDB ScheduleDB
+
+	result := connection(cp.DB, contextControl).
+		Where("fk_id_service_employee_attention_time = ? and booked_at = ?",
+			attentionTimeID, bookedAt).
+		Where(scheduleHoldsSlot).
+		First(&scheduleDB)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return domain.ScheduleDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(ScheduleGetBySlotDBError, "attention_time_id", attentionTimeID,
+			"booked_at", bookedAt, "error", result.Error.Error())
+		return domain.ScheduleDomain{}, false, result.Error
+	}
+
+	return scheduleDB.CopyToScheduleDomain(), true, nil
+}
+
+// GetActiveByAttentionTimes lists the schedules holding the attention times booked from the
+// date from to the date to, both included.
+func (cp SchedulePostgresDB) GetActiveByAttentionTimes(contextControl domain.ContextControl, attentionTimeIDs []int64, from, to time.Time) ([]domain.ScheduleDomain, error) {
+
+	if len(attentionTimeIDs) == 0 {
+		return nil, nil
+	}
+
+	var schedulesDB []ScheduleDB
+
+	if err := connection(cp.DB, contextControl).
+		Where("fk_id_service_employee_attention_time in ? and booked_at between ? and ?",
+			attentionTimeIDs, from, to).
+		Where(scheduleHoldsSlot).
+		Order("booked_at, id").
+		Find(&schedulesDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(ScheduleGetActiveDBError, "attention_time_ids", attentionTimeIDs,
+			"error", err.Error())
+		return nil, err
+	}
+
+	schedules := make([]domain.ScheduleDomain, 0, len(schedulesDB))
+	for _, scheduleDB := range schedulesDB {
+		schedules = append(schedules, scheduleDB.CopyToScheduleDomain())
+	}
+
+	return schedules, nil
+}
+
+// LockEmployee holds the bookings of the employee until the end of the transaction, so concurrent
+// bookings of overlapping attention times are checked one after the other. Employee ids beyond
+// the 32 bits of the lock share their lock with another employee, which only serializes more.
+func (cp SchedulePostgresDB) LockEmployee(contextControl domain.ContextControl, employeeID int64) error {
+
+	if err := connection(cp.DB, contextControl).
+		Exec(ScheduleLockEmployeeQuery, scheduleEmployeeLockKey, int32(employeeID)).Error; err != nil {
+		cp.LoggerSugar.Errorw(ScheduleLockEmployeeDBError, "employee_id", employeeID, "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (cp SchedulePostgresDB) GetByID(contextControl domain.ContextControl, ID int64) (domain.ScheduleDomain, bool, error) {
+
+	var scheduleDB ScheduleDB
+
+	result := connection(cp.DB, contextControl).
+		Scopes(scheduleContractScope(contextControl)).
+		First(&scheduleDB, ID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return domain.ScheduleDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(ScheduleGetByIDDBError, "schedule_id", ID, "error", result.Error.Error())
+		return domain.ScheduleDomain{}, false, result.Error
+	}
+
+	return scheduleDB.CopyToScheduleDomain(), true, nil
+}
+
+// Transition stores the status and the slot of the schedule and records the transition in the
+// history, in the same transaction. The update only applies while the schedule is still in the
+// status from, so a concurrent transition is reported as a conflict instead of being overwritten.
+func (cp SchedulePostgresDB) Transition(contextControl domain.ContextControl, scheduleDomain domain.ScheduleDomain,
+	from domain.ScheduleStatus, historyDomain domain.ScheduleHistoryDomain) error {
+
+	scheduleDB := newScheduleDB(scheduleDomain)
+
+	err := connection(cp.DB, contextControl).Transaction(func(tx *gorm.DB) error {
+
+		result := tx.Model(&ScheduleDB{}).
+			Where("id = ? and status = ?", scheduleDomain.ID, string(from)).
+			Select("status", "date_declined", "booked_at", "fk_id_service_employee_attention_time").
+			Updates(scheduleDB)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.NewConflictError(ScheduleStatusChanged, scheduleDomain.ID, from)
+		}
+
+		historyDB := newScheduleHistoryDB(historyDomain)
+		return tx.Create(&historyDB).Error
+	})
+
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		cp.LoggerSugar.Infow(ScheduleBookingDuplicated, "schedule_id", scheduleDomain.ID,
+			"attention_time_id", scheduleDomain.AttentionTimeID, "booked_at", scheduleDomain.BookedAt)
+		return domain.NewConflictError(ScheduleBookingDuplicated)
+	}
+	if err != nil && !errors.Is(err, domain.ErrConflict) {
+		cp.LoggerSugar.Errorw(ScheduleTransitionDBError,
+			"schedule_id", scheduleDomain.ID, "error", err.Error())
+	}
+
+	return err
+}
+
+// GetHistory lists the transitions of the schedule, oldest first.
+func (cp SchedulePostgresDB) GetHistory(contextControl domain.ContextControl, scheduleID int64) ([]domain.ScheduleHistoryDomain, error) {
+
+	var historiesDB []ScheduleHistoryDB
+
+	if err := connection(cp.DB, contextControl).
+		Where("fk_id_schedule = ?", scheduleID).
+		Order("date_created, id").
+		Find(&historiesDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(ScheduleGetHistoryDBError, "schedule_id", scheduleID, "error", err.Error())
+		return nil, err
+	}
+
+	histories := make([]domain.ScheduleHistoryDomain, 0, len(historiesDB))
+	for _, historyDB := range historiesDB {
+		histories = append(histories, historyDB.CopyToScheduleHistoryDomain())
+	}
+
+	return histories, nil
+}
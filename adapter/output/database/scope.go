@@ -0,0 +1,77 @@
+package database
+
+import (
+	"github.com/petshop-system/petshop-api/application/domain"
+	"gorm.io/gorm"
+)
+
+// contractScope restricts a query to the contract (tenant) of the request, so rows of
+// another contract are reported as not found. Requests without a contract aren't restricted.
+func contractScope(contextControl domain.ContextControl) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if contextControl.ContractID == 0 {
+			return db
+		}
+		return db.Where("fk_id_contract = ?", contextControl.ContractID)
+	}
+}
+
+// scheduleContractScope restricts a query to the schedules of the pets of the contract of the
+// request, as the schedule has no contract of its own. Requests without a contract aren't restricted.
+func scheduleContractScope(contextControl domain.ContextControl) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if contextControl.ContractID == 0 {
+			return db
+		}
+		return db.Where("fk_id_pet in (select id from petshop_api.pet where fk_id_contract = ?)",
+			contextControl.ContractID)
+	}
+}
+
+// phoneUserContractScope restricts a query to the links of the phones of the contract of the
+// request, as phone_user has no contract of its own. Requests without a contract aren't restricted.
+func phoneUserContractScope(contextControl domain.ContextControl) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if contextControl.ContractID == 0 {
+			return db
+		}
+		return db.Where("fk_id_phone in (select id from petshop_api.phone where fk_id_contract = ?)",
+			contextControl.ContractID)
+	}
+}
+
+// customerHistoryContractScope restricts a query to the history of the customers of the contract
+// of the request. Requests without a contract aren't restricted.
+func customerHistoryContractScope(contextControl domain.ContextControl) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if contextControl.ContractID == 0 {
+			return db
+		}
+		return db.Where("fk_id_customer in (select id from petshop_api.customer where fk_id_contract = ?)",
+			contextControl.ContractID)
+	}
+}
+
+// scopedContractID is the value stored in the nullable fk_id_contract of address and phone.
+func scopedContractID(contextControl domain.ContextControl) *int64 {
+	if contextControl.ContractID == 0 {
+		return nil
+	}
+	contractID := contextControl.ContractID
+	return &contractID
+}
+
+// nullableID stores an optional reference, zero meaning there is none.
+func nullableID(ID int64) *int64 {
+	if ID == 0 {
+		return nil
+	}
+	return &ID
+}
+
+func valueOfID(ID *int64) int64 {
+	if ID == nil {
+		return 0
+	}
+	return *ID
+}
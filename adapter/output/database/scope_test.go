@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunDB builds statements without connecting to Postgres.
+func dryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	assert.Nil(t, err)
+	return db
+}
+
+// failingCreateDB is a dry run DB whose inserts fail with err, as Postgres would report it.
+func failingCreateDB(t *testing.T, err error) *gorm.DB {
+	db := dryRunDB(t)
+	assert.Nil(t, db.Callback().Create().Before("gorm:create").Register("test:fail", func(db *gorm.DB) {
+		db.AddError(err)
+	}))
+	return db
+}
+
+func TestContractScope(t *testing.T) {
+
+	tests := []struct {
+		Name         string
+		ContractID   int64
+		ExpectedSQL  string
+		ExpectedVars []any
+	}{
+		{
+			Name:         "WithContract_FiltersByIt",
+			ContractID:   2,
+			ExpectedSQL:  `SELECT * FROM "petshop_api"."customer" WHERE "customer"."id" = $1 AND fk_id_contract = $2 ORDER BY "customer"."id" LIMIT $3`,
+			ExpectedVars: []any{int64(5), int64(2), 1},
+		},
+		{
+			Name:         "WithoutContract_DoesNotFilter",
+			ExpectedSQL:  `SELECT * FROM "petshop_api"."customer" WHERE "customer"."id" = $1 ORDER BY "customer"."id" LIMIT $2`,
+			ExpectedVars: []any{int64(5), 1},
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			contextControl := domain.ContextControl{Context: context.Background(), ContractID: test.ContractID}
+
+			var customerDB CustomerDB
+			statement := dryRunDB(t).Scopes(contractScope(contextControl)).First(&customerDB, int64(5)).Statement
+
+			assert.Equal(t, test.ExpectedSQL, statement.SQL.String())
+			assert.Equal(t, test.ExpectedVars, statement.Vars)
+		})
+	}
+}
+
+func TestScheduleContractScope(t *testing.T) {
+
+	contextControl := domain.ContextControl{Context: context.Background(), ContractID: 2}
+
+	var scheduleDB ScheduleDB
+	statement := dryRunDB(t).Scopes(scheduleContractScope(contextControl)).First(&scheduleDB, int64(5)).Statement
+
+	assert.Equal(t, `SELECT * FROM "petshop_api"."schedule" WHERE "schedule"."id" = $1 AND fk_id_pet in (select id from petshop_api.pet where fk_id_contract = $2) ORDER BY "schedule"."id" LIMIT $3`,
+		statement.SQL.String())
+	assert.Equal(t, []any{int64(5), int64(2), 1}, statement.Vars)
+
+	statement = dryRunDB(t).Scopes(scheduleContractScope(domain.ContextControl{Context: context.Background()})).
+		First(&scheduleDB, int64(5)).Statement
+	assert.Equal(t, `SELECT * FROM "petshop_api"."schedule" WHERE "schedule"."id" = $1 ORDER BY "schedule"."id" LIMIT $2`,
+		statement.SQL.String())
+}
+
+func TestPhoneUserContractScope(t *testing.T) {
+
+	contextControl := domain.ContextControl{Context: context.Background(), ContractID: 2}
+
+	var phoneUserDB PhoneUserDB
+	statement := dryRunDB(t).Scopes(phoneUserContractScope(contextControl)).Where("fk_id_phone = ?", int64(5)).
+		First(&phoneUserDB).Statement
+
+	assert.Equal(t, `SELECT * FROM "petshop_api"."phone_user" WHERE fk_id_phone = $1 AND fk_id_phone in (select id from petshop_api.phone where fk_id_contract = $2) ORDER BY "phone_user"."id" LIMIT $3`,
+		statement.SQL.String())
+	assert.Equal(t, []any{int64(5), int64(2), 1}, statement.Vars)
+}
+
+func TestCustomerHistoryContractScope(t *testing.T) {
+
+	contextControl := domain.ContextControl{Context: context.Background(), ContractID: 2}
+
+	var historiesDB []CustomerHistoryDB
+	statement := dryRunDB(t).Scopes(customerHistoryContractScope(contextControl)).Where("fk_id_customer = ?", int64(5)).
+		Find(&historiesDB).Statement
+
+	assert.Equal(t, `SELECT * FROM "petshop_api"."customer_history" WHERE fk_id_customer = $1 AND fk_id_customer in (select id from petshop_api.customer where fk_id_contract = $2)`,
+		statement.SQL.String())
+	assert.Equal(t, []any{int64(5), int64(2)}, statement.Vars)
+}
@@ -0,0 +1,257 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ServiceSaveDBError            = "error to save the service into postgres"
+	ServiceUpdateDBError          = "error to update the service into postgres"
+	ServiceGetByIDDBError         = "error to get a service by id"
+	ServiceGetAllDBError          = "error to get the services"
+	ServiceGetPriceHistoryDBError = "error to get the price history of the service"
+	ServiceNotFound               = "service not found"
+	ServicePriceNotFound          = "service price not found"
+)
+
+type ServicePostgresDB struct {
+	DB          *gorm.DB
+	LoggerSugar *zap.SugaredLogger
+}
+
+func NewServicePostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) ServicePostgresDB {
+	return ServicePostgresDB{
+		DB:          gormDB,
+		LoggerSugar: loggerSugar,
+	}
+}
+
+type ServiceDB struct {
+	ID              int64        `gorm:"primaryKey, column:id"`
+	Name            string       `gorm:"column:name"`
+	Price           domain.Money `gorm:"column:price"`
+	Active          bool         `gorm:"column:active"`
+	Description     string       `gorm:"column:description"`
+	DurationMinutes int          `gorm:"column:duration_minutes"`
+	ContractID      int64        `gorm:"column:fk_id_contract"`
+}
+
+func (ServiceDB) TableName() string {
+	return "petshop_api.service"
+}
+
+func (c ServiceDB) CopyToServiceDomain() domain.ServiceDomain {
+	return domain.ServiceDomain{
+		ID:              c.ID,
+		Name:            c.Name,
+		Price:           c.Price,
+		Active:          c.Active,
+		Description:     c.Description,
+		DurationMinutes: c.DurationMinutes,
+		ContractID:      c.ContractID,
+	}
+}
+
+type ServicePriceHistoryDB struct {
+	ID          int64        `gorm:"primaryKey, column:id"`
+	ServiceID   int64        `gorm:"column:fk_id_service"`
+	Price       domain.Money `gorm:"column:price"`
+	DateCreated time.Time    `gorm:"column:date_created;default:now()"`
+	ContractID  int64        `gorm:"column:fk_id_contract"`
+}
+
+func (ServicePriceHistoryDB) TableName() string {
+	return "petshop_api.service_price_history"
+}
+
+func (c ServicePriceHistoryDB) CopyToServicePriceHistoryDomain() domain.ServicePriceHistoryDomain {
+	return domain.ServicePriceHistoryDomain{
+		ID:          c.ID,
+		ServiceID:   c.ServiceID,
+		Price:       c.Price,
+		DateCreated: c.DateCreated,
+		ContractID:  c.ContractID,
+	}
+}
+
+// Save inserts the service and its first price history record in the same transaction.
+func (cp ServicePostgresDB) Save(contextControl domain.ContextControl, serviceDomain domain.ServiceDomain) (domain.ServiceDomain, error) {
+
+	serviceDB := ServiceDB{
+		Name:            serviceDomain.Name,
+		Price:           serviceDomain.Price,
+		Active:          serviceDomain.Active,
+		Description:     serviceDomain.Description,
+		DurationMinutes: serviceDomain.DurationMinutes,
+		ContractID:      contextControl.ScopedContractID(serviceDomain.ContractID),
+	}
+
+	err := connection(cp.DB, contextControl).Transaction(func(tx *gorm.DB) error {
+
+		// active is listed so a new inactive service isn't replaced by the column default
+		if err := tx.Select("name", "price", "active", "description", "duration_minutes", "fk_id_contract").
+			Create(&serviceDB).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&ServicePriceHistoryDB{
+			ServiceID:  serviceDB.ID,
+			Price:      serviceDB.Price,
+			ContractID: serviceDB.ContractID,
+		}).Error
+	})
+
+	if err != nil {
+		cp.LoggerSugar.Errorw(ServiceSaveDBError,
+			"error", err.Error())
+		return domain.ServiceDomain{}, err
+	}
+
+	return serviceDB.CopyToServiceDomain(), nil
+}
+
+// Update changes the name, description, duration and price of the service. A price history record is
+// added in the same transaction when the price differs from the stored one.
+func (cp ServicePostgresDB) Update(contextControl domain.ContextControl, serviceDomain domain.ServiceDomain) error {
+
+	err := connection(cp.DB, contextControl).Transaction(func(tx *gorm.DB) error {
+
+		var current ServiceDB
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(contractScope(contextControl)).
+			First(&current, serviceDomain.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&current).
+			Select("name", "price", "description", "duration_minutes").
+			Updates(ServiceDB{
+				Name:            serviceDomain.Name,
+				Price:           serviceDomain.Price,
+				Description:     serviceDomain.Description,
+				DurationMinutes: serviceDomain.DurationMinutes,
+			}).Error; err != nil {
+			return err
+		}
+
+		if current.Price == serviceDomain.Price {
+			return nil
+		}
+
+		return tx.Create(&ServicePriceHistoryDB{
+			ServiceID:  current.ID,
+			Price:      serviceDomain.Price,
+			ContractID: current.ContractID,
+		}).Error
+	})
+
+	if err != nil {
+		cp.LoggerSugar.Errorw(ServiceUpdateDBError,
+			"service_id", serviceDomain.ID, "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (cp ServicePostgresDB) SetActive(contextControl domain.ContextControl, ID int64, active bool) error {
+
+	if err := connection(cp.DB, contextControl).
+		Model(&ServiceDB{}).
+		Scopes(contractScope(contextControl)).
+		Where("id = ?", ID).
+		Update("active", active).Error; err != nil {
+		cp.LoggerSugar.Errorw(ServiceUpdateDBError,
+			"service_id", ID, "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (cp ServicePostgresDB) GetByID(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error) {
+
+	var serviceDB ServiceDB
+
+	result := connection(cp.DB, contextControl).Scopes(contractScope(contextControl)).First(&serviceDB, ID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			cp.LoggerSugar.Infow(ServiceNotFound, "service_id", ID)
+			return domain.ServiceDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(ServiceGetByIDDBError, "service_id", ID, "error", result.Error.Error())
+		return domain.ServiceDomain{}, false, result.Error
+	}
+
+	return serviceDB.CopyToServiceDomain(), true, nil
+}
+
+func (cp ServicePostgresDB) GetAll(contextControl domain.ContextControl) ([]domain.ServiceDomain, error) {
+
+	var servicesDB []ServiceDB
+
+	if err := connection(cp.DB, contextControl).
+		Scopes(contractScope(contextControl)).
+		Order("name").
+		Find(&servicesDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(ServiceGetAllDBError, "error", err.Error())
+		return nil, err
+	}
+
+	services := make([]domain.ServiceDomain, 0, len(servicesDB))
+	for _, serviceDB := range servicesDB {
+		services = append(services, serviceDB.CopyToServiceDomain())
+	}
+
+	return services, nil
+}
+
+// GetCurrentPrice returns the latest price history record of the service.
+func (cp ServicePostgresDB) GetCurrentPrice(contextControl domain.ContextControl, serviceID int64) (domain.ServicePriceHistoryDomain, bool, error) {
+
+	var priceHistoryDB ServicePriceHistoryDB
+
+	result := connection(cp.DB, contextControl).
+		Scopes(contractScope(contextControl)).
+		Where("fk_id_service = ?", serviceID).
+		Order("date_created desc, id desc").
+		First(&priceHistoryDB)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			cp.LoggerSugar.Infow(ServicePriceNotFound, "service_id", serviceID)
+			return domain.ServicePriceHistoryDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(ServiceGetPriceHistoryDBError, "service_id", serviceID, "error", result.Error.Error())
+		return domain.ServicePriceHistoryDomain{}, false, result.Error
+	}
+
+	return priceHistoryDB.CopyToServicePriceHistoryDomain(), true, nil
+}
+
+// GetPriceHistory lists the prices of the service, the latest first.
+func (cp ServicePostgresDB) GetPriceHistory(contextControl domain.ContextControl, serviceID int64) ([]domain.ServicePriceHistoryDomain, error) {
+
+	var priceHistoryDB []ServicePriceHistoryDB
+
+	if err := connection(cp.DB, contextControl).
+		Scopes(contractScope(contextControl)).
+		Where("fk_id_service = ?", serviceID).
+		Order("date_created desc, id desc").
+		Find(&priceHistoryDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(ServiceGetPriceHistoryDBError, "service_id", serviceID, "error", err.Error())
+		return nil, err
+	}
+
+	priceHistory := make([]domain.ServicePriceHistoryDomain, 0, len(priceHistoryDB))
+	for _, record := range priceHistoryDB {
+		priceHistory = append(priceHistory, record.CopyToServicePriceHistoryDomain())
+	}
+
+	return priceHistory, nil
+}
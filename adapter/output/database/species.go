@@ -0,0 +1,121 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	SpeciesSaveDBError      = "error to save the species into postgres"
+	SpeciesGetByIDDBError   = "error to get a species by id"
+	SpeciesGetByNameDBError = "error to get a species by name"
+	SpeciesGetAllDBError    = "error to get all species"
+	SpeciesNotFound         = "species not found"
+	SpeciesAlreadyExists    = "the species %s already exists"
+)
+
+type SpeciesPostgresDB struct {
+	DB          *gorm.DB
+	LoggerSugar *zap.SugaredLogger
+}
+
+func NewSpeciesPostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) SpeciesPostgresDB {
+	return SpeciesPostgresDB{
+		DB:          gormDB,
+		LoggerSugar: loggerSugar,
+	}
+}
+
+type SpeciesDB struct {
+	ID   int64  `gorm:"primaryKey, column:id"`
+	Name string `gorm:"column:name"`
+}
+
+func (SpeciesDB) TableName() string {
+	return "petshop_api.species"
+}
+
+func (c SpeciesDB) CopyToSpeciesDomain() domain.SpeciesDomain {
+	return domain.SpeciesDomain{
+		ID:   c.ID,
+		Name: c.Name,
+	}
+}
+
+func (cp SpeciesPostgresDB) Save(contextControl domain.ContextControl, speciesDomain domain.SpeciesDomain) (domain.SpeciesDomain, error) {
+
+	speciesDB := SpeciesDB{
+		ID:   speciesDomain.ID,
+		Name: speciesDomain.Name,
+	}
+
+	if err := connection(cp.DB, contextControl).
+		Create(&speciesDB).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			cp.LoggerSugar.Infow(SpeciesSaveDBError, "name", speciesDomain.Name, "error", err.Error())
+			return domain.SpeciesDomain{}, domain.NewConflictError(SpeciesAlreadyExists, speciesDomain.Name)
+		}
+		cp.LoggerSugar.Errorw(SpeciesSaveDBError,
+			"error", err.Error())
+		return domain.SpeciesDomain{}, err
+	}
+
+	return speciesDB.CopyToSpeciesDomain(), nil
+}
+
+func (cp SpeciesPostgresDB) GetByID(contextControl domain.ContextControl, ID int64) (domain.SpeciesDomain, bool, error) {
+
+	var speciesDB SpeciesDB
+
+	result := connection(cp.DB, contextControl).First(&speciesDB, ID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			cp.LoggerSugar.Infow(SpeciesNotFound, "species_id", ID)
+			return domain.SpeciesDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(SpeciesGetByIDDBError, "species_id", ID, "error", result.Error.Error())
+		return domain.SpeciesDomain{}, false, result.Error
+	}
+
+	return speciesDB.CopyToSpeciesDomain(), true, nil
+}
+
+func (cp SpeciesPostgresDB) GetByName(contextControl domain.ContextControl, name string) (domain.SpeciesDomain, bool, error) {
+
+	var speciesDB SpeciesDB
+
+	result := connection(cp.DB, contextControl).
+		Where("lower(name) = lower(?)", name).
+		First(&speciesDB)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return domain.SpeciesDomain{}, false, nil
+		}
+		cp.LoggerSugar.Errorw(SpeciesGetByNameDBError, "name", name, "error", result.Error.Error())
+		return domain.SpeciesDomain{}, false, result.Error
+	}
+
+	return speciesDB.CopyToSpeciesDomain(), true, nil
+}
+
+func (cp SpeciesPostgresDB) GetAll(contextControl domain.ContextControl) ([]domain.SpeciesDomain, error) {
+
+	var speciesListDB []SpeciesDB
+
+	if err := connection(cp.DB, contextControl).
+		Order("name").
+		Find(&speciesListDB).Error; err != nil {
+		cp.LoggerSugar.Errorw(SpeciesGetAllDBError, "error", err.Error())
+		return nil, err
+	}
+
+	speciesList := make([]domain.SpeciesDomain, 0, len(speciesListDB))
+	for _, speciesDB := range speciesListDB {
+		speciesList = append(speciesList, speciesDB.CopyToSpeciesDomain())
+	}
+
+	return speciesList, nil
+}
@@ -0,0 +1,23 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestSpeciesPostgresDB_Save(t *testing.T) {
+
+	t.Run("WithDuplicatedName_ReturnsConflict", func(t *testing.T) {
+
+		speciesDB := NewSpeciesPostgresDB(failingCreateDB(t, gorm.ErrDuplicatedKey), zap.NewNop().Sugar())
+		_, err := speciesDB.Save(domain.ContextControl{Context: context.Background()}, domain.SpeciesDomain{Name: "Dog"})
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		assert.EqualError(t, err, "the species Dog already exists")
+	})
+}
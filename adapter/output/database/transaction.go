@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const TransactionDBError = "error to run the transaction"
+
+type transactionKey struct{}
+
+// TransactionManagerPostgresDB runs several repository calls in one Postgres transaction. The
+// transaction travels in the context of the ContextControl given to the function, and the
+// repositories join it through connection.
+type TransactionManagerPostgresDB struct {
+	DB          *gorm.DB
+	LoggerSugar *zap.SugaredLogger
+}
+
+func NewTransactionManagerPostgresDB(gormDB *gorm.DB, loggerSugar *zap.SugaredLogger) TransactionManagerPostgresDB {
+	return TransactionManagerPostgresDB{
+		DB:          gormDB,
+		LoggerSugar: loggerSugar,
+	}
+}
+
+// WithinTransaction commits what fn stored when it returns nil and rolls it back otherwise.
+// Called inside another transaction, fn runs in a savepoint of it.
+func (tm TransactionManagerPostgresDB) WithinTransaction(contextControl domain.ContextControl,
+	fn func(contextControl domain.ContextControl) error) error {
+
+	err := connection(tm.DB, contextControl).Transaction(func(tx *gorm.DB) error {
+		txControl := contextControl
+		txControl.Context = context.WithValue(contextControl.Context, transactionKey{}, tx)
+		return fn(txControl)
+	})
+
+	if err != nil {
+		tm.LoggerSugar.Infow(TransactionDBError, "request_id", contextControl.RequestID, "error", err.Error())
+	}
+
+	return err
+}
+
+// connection returns the transaction of the contextControl when there is one, so the call joins
+// it, otherwise the pool, both bound to the request context.
+func connection(db *gorm.DB, contextControl domain.ContextControl) *gorm.DB {
+	if tx, ok := contextControl.Context.Value(transactionKey{}).(*gorm.DB); ok {
+		return tx.WithContext(contextControl.Context)
+	}
+	return db.WithContext(contextControl.Context)
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnection(t *testing.T) {
+
+	t.Run("WithTransaction_JoinsIt", func(t *testing.T) {
+
+		tx := dryRunDB(t).Table("petshop_api.in_transaction")
+		contextControl := domain.ContextControl{Context: context.WithValue(context.Background(), transactionKey{}, tx)}
+
+		var customerDB CustomerDB
+		statement := connection(dryRunDB(t), contextControl).Find(&customerDB).Statement
+
+		assert.Equal(t, `SELECT * FROM "petshop_api"."in_transaction"`, statement.SQL.String())
+	})
+
+	t.Run("WithoutTransaction_UsesThePool", func(t *testing.T) {
+
+		contextControl := domain.ContextControl{Context: context.Background()}
+
+		var customerDB CustomerDB
+		statement := connection(dryRunDB(t), contextControl).Find(&customerDB).Statement
+
+		assert.Equal(t, `SELECT * FROM "petshop_api"."customer"`, statement.SQL.String())
+	})
+}
@@ -0,0 +1,118 @@
+package event
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.uber.org/zap"
+)
+
+const (
+	KafkaEventPublisherErrorToStart   = "error to start the event producer from kafka"
+	KafkaEventPublisherErrorToEncode  = "error to encode the event"
+	KafkaEventPublisherErrorToPublish = "error to publish the events to kafka"
+)
+
+// Headers added to every event record, so consumers can route them without decoding the value.
+const (
+	EventHeaderID            = "event-id"
+	EventHeaderType          = "event-type"
+	EventHeaderVersion       = "event-version"
+	EventHeaderCorrelationID = "correlation-id"
+	EventHeaderContentType   = "content-type"
+	EventContentType         = "application/json"
+)
+
+type KafkaEventPublisher struct {
+	LoggerSugar *zap.SugaredLogger
+	KafkaClient *kgo.Client
+	Topic       string
+}
+
+// EventMessageKafka is the JSON value of an event record; Data is the versioned payload.
+type EventMessageKafka struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	Version       int       `json:"version"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	CorrelationID string    `json:"correlation_id"`
+	Data          any       `json:"data"`
+}
+
+func NewKafkaEventPublisher(loggerSugar *zap.SugaredLogger, bootstrapServer string, topic string) KafkaEventPublisher {
+
+	kafkaClient, err := kgo.NewClient(
+		kgo.SeedBrokers(bootstrapServer),
+		kgo.DefaultProduceTopic(topic),
+	)
+
+	if err != nil {
+		loggerSugar.Errorw(KafkaEventPublisherErrorToStart, "error", err.Error())
+		panic(err)
+	}
+
+	return KafkaEventPublisher{
+		LoggerSugar: loggerSugar,
+		KafkaClient: kafkaClient,
+		Topic:       topic,
+	}
+}
+
+// Publish sends the events and waits for the brokers to acknowledge all of them.
+func (publisher *KafkaEventPublisher) Publish(contextControl domain.ContextControl, events ...domain.EventDomain) error {
+
+	records := make([]*kgo.Record, 0, len(events))
+	for _, event := range events {
+		record, err := newEventRecord(publisher.Topic, event)
+		if err != nil {
+			publisher.LoggerSugar.Errorw(KafkaEventPublisherErrorToEncode, "event_type", event.Type,
+				"event_id", event.ID, "error", err.Error())
+			return err
+		}
+		records = append(records, record)
+	}
+
+	if err := publisher.KafkaClient.ProduceSync(contextControl.Context, records...).FirstErr(); err != nil {
+		publisher.LoggerSugar.Errorw(KafkaEventPublisherErrorToPublish, "topic", publisher.Topic,
+			"events", len(records), "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Close waits for the buffered records and closes the client.
+func (publisher *KafkaEventPublisher) Close() {
+	publisher.KafkaClient.Close()
+}
+
+func newEventRecord(topic string, event domain.EventDomain) (*kgo.Record, error) {
+
+	value, err := json.Marshal(EventMessageKafka{
+		ID:            event.ID,
+		Type:          event.Type,
+		Version:       event.Version,
+		OccurredAt:    event.OccurredAt,
+		CorrelationID: event.CorrelationID,
+		Data:          event.Payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kgo.Record{
+		Topic: topic,
+		Key:   []byte(event.Key),
+		Value: value,
+		Headers: []kgo.RecordHeader{
+			{Key: EventHeaderID, Value: []byte(event.ID)},
+			{Key: EventHeaderType, Value: []byte(event.Type)},
+			{Key: EventHeaderVersion, Value: []byte(strconv.Itoa(event.Version))},
+			{Key: EventHeaderCorrelationID, Value: []byte(event.CorrelationID)},
+			{Key: EventHeaderContentType, Value: []byte(EventContentType)},
+		},
+	}, nil
+}
@@ -0,0 +1,54 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestNewEventRecord(t *testing.T) {
+
+	occurredAt := time.Date(2030, 12, 9, 10, 0, 0, 0, time.UTC)
+	event := domain.EventDomain{
+		ID:            "3f1c9a52-6a3e-4c2b-9d0e-2b7f4f1a8c11",
+		Type:          domain.EventCustomerCreated,
+		Version:       domain.CustomerEventVersion,
+		Key:           "5",
+		CorrelationID: "host/abc-000001",
+		OccurredAt:    occurredAt,
+		Payload:       domain.CustomerEvent{ID: 5, Name: "siclano", Email: "siclano@gmail.com", PersonType: "individual", ContractID: 1},
+	}
+
+	record, err := newEventRecord("petshop_events", event)
+	assert.NoError(t, err)
+	assert.Equal(t, "petshop_events", record.Topic)
+	assert.Equal(t, []byte("5"), record.Key)
+	assert.Equal(t, []kgo.RecordHeader{
+		{Key: EventHeaderID, Value: []byte(event.ID)},
+		{Key: EventHeaderType, Value: []byte("customer.created")},
+		{Key: EventHeaderVersion, Value: []byte("1")},
+		{Key: EventHeaderCorrelationID, Value: []byte("host/abc-000001")},
+		{Key: EventHeaderContentType, Value: []byte("application/json")},
+	}, record.Headers)
+
+	var value map[string]any
+	assert.NoError(t, json.Unmarshal(record.Value, &value))
+	assert.Equal(t, map[string]any{
+		"id":             event.ID,
+		"type":           "customer.created",
+		"version":        float64(1),
+		"occurred_at":    "2030-12-09T10:00:00Z",
+		"correlation_id": "host/abc-000001",
+		"data": map[string]any{
+			"id":          float64(5),
+			"name":        "siclano",
+			"email":       "siclano@gmail.com",
+			"person_type": "individual",
+			"contract_id": float64(1),
+		},
+	}, value)
+}
@@ -0,0 +1,54 @@
+package event
+
+import (
+	"sync"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+// InMemoryEventPublisher keeps the published events instead of sending them, for tests.
+type InMemoryEventPublisher struct {
+	mutex  sync.Mutex
+	events []domain.EventDomain
+	// Err, when set, is returned by Publish and the events are discarded.
+	Err error
+}
+
+func NewInMemoryEventPublisher() *InMemoryEventPublisher {
+	return &InMemoryEventPublisher{}
+}
+
+func (publisher *InMemoryEventPublisher) Publish(contextControl domain.ContextControl, events ...domain.EventDomain) error {
+
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+
+	if publisher.Err != nil {
+		return publisher.Err
+	}
+
+	publisher.events = append(publisher.events, events...)
+	return nil
+}
+
+// Events returns the published events, oldest first.
+func (publisher *InMemoryEventPublisher) Events() []domain.EventDomain {
+
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+
+	return append([]domain.EventDomain(nil), publisher.events...)
+}
+
+// EventsOfType returns the published events of the type, oldest first.
+func (publisher *InMemoryEventPublisher) EventsOfType(eventType string) []domain.EventDomain {
+
+	var events []domain.EventDomain
+	for _, event := range publisher.Events() {
+		if event.Type == eventType {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
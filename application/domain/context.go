@@ -5,4 +5,18 @@ import "context"
 type ContextControl struct {
 	Context         context.Context
 	CancelCauseFunc context.CancelCauseFunc
+	RequestID       string
+	// ContractID is the contract (tenant) the request acts on. Zero means the caller
+	// isn't bound to a contract, as the Kafka consumers, and nothing is scoped.
+	ContractID int64
+	// Actor is who makes the request, recorded in the audit trails. Empty when unknown.
+	Actor string
+}
+
+// ScopedContractID returns the contract of the request when there is one, otherwise contractID.
+func (contextControl ContextControl) ScopedContractID(contractID int64) int64 {
+	if contextControl.ContractID != 0 {
+		return contextControl.ContractID
+	}
+	return contractID
 }
@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// Actions recorded in the history of a customer.
+const (
+	CustomerActionCreated  = "created"
+	CustomerActionUpdated  = "updated"
+	CustomerActionDeleted  = "deleted"
+	CustomerActionRestored = "restored"
+)
+
+// CustomerHistoryDomain records a change of a customer: the action, who made it, in which request
+// and the value before and after of every field changed, keyed by the name of the field.
+type CustomerHistoryDomain struct {
+	ID          int64
+	CustomerID  int64
+	Action      string
+	Actor       string
+	RequestID   string
+	Description string
+	Changes     map[string]CustomerFieldChangeDomain
+	Date        time.Time
+}
+
+// CustomerFieldChangeDomain is the value of a field before and after a change. Before is nil on
+// the creation and a date_deleted not set is nil as well.
+type CustomerFieldChangeDomain struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// customerAuditedFields are the fields of a customer compared by CustomerChanges.
+var customerAuditedFields = []struct {
+	name  string
+	value func(customer CustomerDomain) any
+}{
+	{"name", func(customer CustomerDomain) any { return customer.Name }},
+	{"email", func(customer CustomerDomain) any { return customer.Email }},
+	{"document", func(customer CustomerDomain) any { return customer.Document }},
+	{"person_type", func(customer CustomerDomain) any { return customer.PersonType }},
+	{"contract_id", func(customer CustomerDomain) any { return customer.ContractID }},
+	{"address_id", func(customer CustomerDomain) any { return customer.AddressID }},
+	{"date_deleted", func(customer CustomerDomain) any {
+		if customer.DateDeleted == nil {
+			return nil
+		}
+		return customer.DateDeleted.UTC()
+	}},
+}
+
+// CustomerChanges compares two versions of a customer, before being nil for its creation, and
+// returns the fields that differ. It is empty when nothing changed.
+func CustomerChanges(before *CustomerDomain, after CustomerDomain) map[string]CustomerFieldChangeDomain {
+
+	changes := make(map[string]CustomerFieldChangeDomain)
+	for _, field := range customerAuditedFields {
+
+		afterValue := field.value(after)
+		if before == nil {
+			changes[field.name] = CustomerFieldChangeDomain{After: afterValue}
+			continue
+		}
+
+		if beforeValue := field.value(*before); beforeValue != afterValue {
+			changes[field.name] = CustomerFieldChangeDomain{Before: beforeValue, After: afterValue}
+		}
+	}
+
+	return changes
+}
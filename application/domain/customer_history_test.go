@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomerChanges(t *testing.T) {
+
+	deletedAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	customer := CustomerDomain{ID: 1, Name: "Fulano", Email: "fulano@email.com", Document: "29623057091",
+		PersonType: "individual", ContractID: 1, AddressID: 2}
+
+	t.Run("WithoutBefore_RecordsEveryFieldAsCreated", func(t *testing.T) {
+		changes := CustomerChanges(nil, customer)
+		assert.Len(t, changes, len(customerAuditedFields))
+		assert.Equal(t, CustomerFieldChangeDomain{After: "fulano@email.com"}, changes["email"])
+		assert.Equal(t, CustomerFieldChangeDomain{After: int64(1)}, changes["contract_id"])
+		assert.Equal(t, CustomerFieldChangeDomain{}, changes["date_deleted"])
+	})
+
+	t.Run("WithChangedEmail_RecordsOnlyIt", func(t *testing.T) {
+		after := customer
+		after.Email = "ciclano@email.com"
+		assert.Equal(t, map[string]CustomerFieldChangeDomain{
+			"email": {Before: "fulano@email.com", After: "ciclano@email.com"},
+		}, CustomerChanges(&customer, after))
+	})
+
+	t.Run("WithDeleteAndRestore_RecordsTheDeletionDate", func(t *testing.T) {
+		deleted := customer
+		deleted.DateDeleted = &deletedAt
+		assert.Equal(t, map[string]CustomerFieldChangeDomain{
+			"date_deleted": {Before: nil, After: deletedAt},
+		}, CustomerChanges(&customer, deleted))
+		assert.Equal(t, map[string]CustomerFieldChangeDomain{
+			"date_deleted": {Before: deletedAt, After: nil},
+		}, CustomerChanges(&deleted, customer))
+	})
+
+	t.Run("WithoutChange_IsEmpty", func(t *testing.T) {
+		same := customer
+		assert.Empty(t, CustomerChanges(&customer, same))
+	})
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type CustomerDomain struct {
 	ID         int64
 	Name       string
@@ -8,6 +10,35 @@ type CustomerDomain struct {
 	PersonType string
 	ContractID int64
 	AddressID  int64
+	// DateDeleted marks a customer deleted; it is hidden from reads until restored.
+	DateDeleted *time.Time
+}
+
+// CustomerOnboardingDomain is a customer created together with its address and phones. The phones
+// are attached to the customer, the one marked primary, or else the first, as its contact number.
+type CustomerOnboardingDomain struct {
+	Customer CustomerDomain
+	Address  AddressDomain
+	Phones   []PhoneUserDomain
+}
+
+// CustomerPatchDomain holds the fields of a partial customer update; nil fields are kept.
+type CustomerPatchDomain struct {
+	Name       *string
+	Email      *string
+	Document   *string
+	PersonType *string
+}
+
+type ContractDomain struct {
+	ID          int64
+	Name        string
+	Email       string
+	Document    string
+	PersonType  string
+	DateCreated time.Time
+	AddressID   int64
+	Address     AddressDomain
 }
 
 type PhoneDomain struct {
@@ -17,14 +48,38 @@ type PhoneDomain struct {
 	PhoneType string
 }
 
+// PhoneUserDomain links a phone to its owner, a contract, customer or employee given by
+// UserType and UserID. An owner has at most one primary phone, its contact number.
+type PhoneUserDomain struct {
+	ID       int64
+	PhoneID  int64
+	UserID   int64
+	UserType string
+	Primary  bool
+	Phone    PhoneDomain
+}
+
 type SpeciesDomain struct {
-	ID   int64
-	Name string
+	ID     int64
+	Name   string
+	Breeds []BreedDomain
 }
 
 type BreedDomain struct {
-	ID   int64
-	Name string
+	ID        int64
+	Name      string
+	SpeciesID int64
+}
+
+type PetDomain struct {
+	ID           int64
+	Name         string
+	DateCreated  time.Time
+	DateBirthday time.Time
+	DateDeleted  *time.Time
+	CustomerID   int64
+	BreedID      int64
+	ContractID   int64
 }
 
 type AddressDomain struct {
@@ -39,8 +94,112 @@ type AddressDomain struct {
 	Country      string
 }
 
+type ServiceDomain struct {
+	ID          int64
+	Name        string
+	Price       Money
+	Active      bool
+	Description string
+	// DurationMinutes is how long the service takes from the start of an attention time.
+	DurationMinutes int
+	ContractID      int64
+}
+
+// ServicePriceHistoryDomain is a price of a service from DateCreated until the next record
+// of the same service.
+type ServicePriceHistoryDomain struct {
+	ID          int64
+	ServiceID   int64
+	Price       Money
+	DateCreated time.Time
+	ContractID  int64
+}
+
+type EmployeeDomain struct {
+	ID          int64
+	Name        string
+	Register    string
+	Document    string
+	DateCreated time.Time
+	Active      bool
+	ContractID  int64
+}
+
+// AttentionTimeDomain is a daily slot, from InitialTime to FinalTime (HH:MM), in which
+// an employee performs a service.
+type AttentionTimeDomain struct {
+	ID          int64
+	InitialTime string
+	FinalTime   string
+	Active      bool
+	ServiceID   int64
+	ContractID  int64
+	EmployeeID  int64
+}
+
+type ScheduleDomain struct {
+	ID              int64
+	DateCreated     time.Time
+	DateDeclined    *time.Time
+	Number          string
+	Status          ScheduleStatus
+	BookedAt        time.Time
+	Price           Money
+	PriceHistoryID  int64
+	PetID           int64
+	AttentionTimeID int64
+}
+
+// ScheduleHistoryDomain records a transition of a schedule: the action applied, by whom and when.
+// FromStatus is empty for the creation; the previous slot is only kept by a reschedule.
+type ScheduleHistoryDomain struct {
+	ID                      int64
+	ScheduleID              int64
+	Action                  ScheduleAction
+	FromStatus              ScheduleStatus
+	ToStatus                ScheduleStatus
+	Actor                   string
+	Reason                  string
+	PreviousBookedAt        *time.Time
+	PreviousAttentionTimeID int64
+	DateCreated             time.Time
+}
+
 type ScheduleMessage struct {
 	Booking                    string
 	PetId                      int
 	ServiceEmployeeAttentionId int
+	Actor                      string
+}
+
+// ScheduleCommand asks to apply an action to a schedule on behalf of Actor. Booking and
+// AttentionTimeID are the new slot of a reschedule.
+type ScheduleCommand struct {
+	ScheduleID      int64
+	Action          ScheduleAction
+	Actor           string
+	Reason          string
+	Booking         string
+	AttentionTimeID int64
+}
+
+type CacheStatsDomain struct {
+	Hits   int64
+	Misses int64
+}
+
+// EmployeeAvailabilityDomain lists the free slots in which an employee can perform a service.
+type EmployeeAvailabilityDomain struct {
+	EmployeeID   int64
+	EmployeeName string
+	Slots        []AvailableSlotDomain
+}
+
+// AvailableSlotDomain is a free attention time on a date; AttentionTimeID and Date are what a
+// booking message needs. FinalTime is when the service ends, not when the attention time does.
+type AvailableSlotDomain struct {
+	Date            time.Time
+	AttentionTimeID int64
+	InitialTime     string
+	FinalTime       string
 }
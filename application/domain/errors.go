@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrConflict is matched by errors.Is for every error built by NewConflictError.
+	ErrConflict = errors.New("conflict")
+	// ErrValidation is matched by errors.Is for every error built by NewValidationError.
+	ErrValidation = errors.New("validation")
+	// ErrSlotTaken is matched by errors.Is for every error built by NewSlotTakenError.
+	ErrSlotTaken = errors.New("slot taken")
+)
+
+type kindError struct {
+	kind    error
+	parent  error
+	message string
+}
+
+func (e kindError) Error() string {
+	return e.message
+}
+
+func (e kindError) Is(target error) bool {
+	return target == e.kind || (e.parent != nil && target == e.parent)
+}
+
+// NewConflictError describes a request that clashes with data already stored, such as a unique key.
+func NewConflictError(format string, args ...any) error {
+	return kindError{kind: ErrConflict, message: fmt.Sprintf(format, args...)}
+}
+
+// NewValidationError describes a request rejected before reaching any repository.
+func NewValidationError(format string, args ...any) error {
+	return kindError{kind: ErrValidation, message: fmt.Sprintf(format, args...)}
+}
+
+// NewSlotTakenError describes a booking of an attention time already held by another booking on
+// the same date. It is a conflict as well, so it also matches ErrConflict.
+func NewSlotTakenError(format string, args ...any) error {
+	return kindError{kind: ErrSlotTaken, parent: ErrConflict, message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Event types announced to the other services. The payload of each type is versioned by its
+// Version field, bumped whenever a field changes meaning or is removed.
+const (
+	EventCustomerCreated     = "customer.created"
+	EventPetCreated          = "pet.created"
+	EventScheduleRequested   = "schedule.requested"
+	EventScheduleConfirmed   = "schedule.confirmed"
+	EventScheduleRescheduled = "schedule.rescheduled"
+	EventScheduleDeclined    = "schedule.declined"
+	EventScheduleCancelled   = "schedule.cancelled"
+	EventScheduleCompleted   = "schedule.completed"
+	EventScheduleNoShow      = "schedule.no_show"
+	CustomerEventVersion     = 1
+	PetEventVersion          = 1
+	ScheduleEventVersion     = 1
+)
+
+// EventDomain is a fact announced to the other services. Key keeps the events of the same
+// aggregate in order and CorrelationID ties them to the request that caused them.
+type EventDomain struct {
+	ID            string
+	Type          string
+	Version       int
+	Key           string
+	CorrelationID string
+	OccurredAt    time.Time
+	Payload       any
+}
+
+// NewEvent builds an event correlated to the request of the contextControl.
+func NewEvent(contextControl ContextControl, eventType string, version int, key string, payload any) EventDomain {
+	return EventDomain{
+		ID:            newEventID(),
+		Type:          eventType,
+		Version:       version,
+		Key:           key,
+		CorrelationID: contextControl.RequestID,
+		OccurredAt:    time.Now().UTC(),
+		Payload:       payload,
+	}
+}
+
+// newEventID returns a random UUID (version 4), so consumers can discard events delivered twice.
+func newEventID() string {
+
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+
+	text := hex.EncodeToString(id[:])
+	return text[0:8] + "-" + text[8:12] + "-" + text[12:16] + "-" + text[16:20] + "-" + text[20:32]
+}
+
+// CustomerEvent is the payload of the customer events.
+type CustomerEvent struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	PersonType string `json:"person_type"`
+	ContractID int64  `json:"contract_id"`
+}
+
+// PetEvent is the payload of the pet events.
+type PetEvent struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	DateBirthday time.Time `json:"date_birthday"`
+	CustomerID   int64     `json:"customer_id"`
+	BreedID      int64     `json:"breed_id"`
+	ContractID   int64     `json:"contract_id"`
+}
+
+// ScheduleEvent is the payload of the schedule events; Actor and Reason come from the
+// transition that raised it, and PreviousBookedAt is only sent by a reschedule.
+type ScheduleEvent struct {
+	ID                      int64  `json:"id"`
+	Number                  string `json:"number"`
+	Status                  string `json:"status"`
+	BookedAt                string `json:"booked_at"`
+	Price                   Money  `json:"price"`
+	PetID                   int64  `json:"pet_id"`
+	AttentionTimeID         int64  `json:"attention_time_id"`
+	Actor                   string `json:"actor"`
+	Reason                  string `json:"reason,omitempty"`
+	PreviousBookedAt        string `json:"previous_booked_at,omitempty"`
+	PreviousAttentionTimeID int64  `json:"previous_attention_time_id,omitempty"`
+}
+
+// OutboxDomain is an event stored with the change it announces, waiting to be published. Its
+// payload is the JSON stored, a json.RawMessage. A failed publication is retried from
+// NextAttemptAt on, until the event is parked after too many attempts.
+type OutboxDomain struct {
+	ID            int64
+	Event         EventDomain
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	DatePublished *time.Time
+	DateParked    *time.Time
+}
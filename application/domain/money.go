@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in cents. It is read from and written to the decimal columns and the
+// JSON numbers as text, so a price such as 55.99 never goes through a float.
+type Money int64
+
+const MoneyInvalid = "the amount %q must be a number with up to two decimal places"
+
+// ParseMoney reads an amount such as "55.99", "55.9" or "-3". Decimal places past the
+// second are only accepted when they are zeros, as the decimal columns may return 112.700.
+func ParseMoney(value string) (Money, error) {
+
+	value = strings.TrimSpace(value)
+	text := value
+
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+
+	integer, fraction, _ := strings.Cut(text, ".")
+	if len(integer) == 0 || !isDigits(integer) || !isDigits(fraction) {
+		return 0, NewValidationError(MoneyInvalid, value)
+	}
+
+	if len(fraction) > 2 {
+		if strings.Trim(fraction[2:], "0") != "" {
+			return 0, NewValidationError(MoneyInvalid, value)
+		}
+		fraction = fraction[:2]
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	cents, err := strconv.ParseInt(integer+fraction, 10, 64)
+	if err != nil {
+		return 0, NewValidationError(MoneyInvalid, value)
+	}
+
+	if negative {
+		cents = -cents
+	}
+
+	return Money(cents), nil
+}
+
+// String formats the amount with two decimal places, e.g. 55.99.
+func (m Money) String() string {
+
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts the amount as a JSON number or as a string.
+func (m *Money) UnmarshalJSON(data []byte) error {
+
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+
+	money, err := ParseMoney(strings.Trim(text, `"`))
+	if err != nil {
+		return err
+	}
+
+	*m = money
+	return nil
+}
+
+func (m *Money) Scan(value any) error {
+
+	switch v := value.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case int64:
+		*m = Money(v * 100)
+		return nil
+	case []byte:
+		return m.scanText(string(v))
+	case string:
+		return m.scanText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Money", value)
+	}
+}
+
+func (m *Money) scanText(text string) error {
+
+	money, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+
+	*m = money
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func isDigits(text string) bool {
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
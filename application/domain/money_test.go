@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+
+	tests := []struct {
+		Name          string
+		Value         string
+		Expected      Money
+		ExpectedError error
+	}{
+		{Name: "WithTwoDecimalPlaces_ReturnsCents", Value: "55.99", Expected: 5599},
+		{Name: "WithOneDecimalPlace_ReturnsCents", Value: "112.7", Expected: 11270},
+		{Name: "WithoutDecimalPlaces_ReturnsCents", Value: "50", Expected: 5000},
+		{Name: "WithTrailingZeros_ReturnsCents", Value: "112.700", Expected: 11270},
+		{Name: "WithNegativeAmount_ReturnsNegativeCents", Value: "-0.05", Expected: -5},
+		{Name: "WithThirdDecimalPlace_ReturnsValidationError", Value: "10.505", ExpectedError: ErrValidation},
+		{Name: "WithLetters_ReturnsValidationError", Value: "10,50", ExpectedError: ErrValidation},
+		{Name: "WithEmptyValue_ReturnsValidationError", Value: "", ExpectedError: ErrValidation},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			money, err := ParseMoney(test.Value)
+			assert.Equal(t, test.Expected, money)
+			if test.ExpectedError == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.ExpectedError)
+			}
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+
+	var price struct {
+		Price Money `json:"price"`
+	}
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"price": 0.1}`), &price))
+	assert.Equal(t, Money(10), price.Price)
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"price": "55.99"}`), &price))
+	assert.Equal(t, Money(5599), price.Price)
+
+	body, err := json.Marshal(price)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"price": 55.99}`, string(body))
+}
+
+func TestMoney_Scan(t *testing.T) {
+
+	var money Money
+
+	assert.Nil(t, money.Scan([]byte("112.70")))
+	assert.Equal(t, Money(11270), money)
+
+	assert.Nil(t, money.Scan(int64(3)))
+	assert.Equal(t, Money(300), money)
+
+	value, err := Money(-5).Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "-0.05", value)
+}
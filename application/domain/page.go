@@ -0,0 +1,36 @@
+package domain
+
+// PageDomain asks for a page of a list ordered by ID: up to Size rows with an ID greater than
+// Cursor, the NextCursor of the previous page. A zero Cursor asks for the first page.
+type PageDomain struct {
+	Size   int
+	Cursor int64
+}
+
+// PageResultDomain is a page of a list. NextCursor is zero on the last page. TotalEstimate counts
+// the rows matching the filters, stopping at a cap on large lists; TotalCapped tells it stopped.
+type PageResultDomain[T any] struct {
+	Items         []T
+	NextCursor    int64
+	TotalEstimate int64
+	TotalCapped   bool
+}
+
+// CustomerFilterDomain filters the customers by a part of the name and by document and email.
+// Empty fields don't filter.
+type CustomerFilterDomain struct {
+	Name     string
+	Document string
+	Email    string
+}
+
+// AddressFilterDomain filters the addresses by zip code and city. Empty fields don't filter.
+type AddressFilterDomain struct {
+	ZipCode string
+	City    string
+}
+
+// PhoneFilterDomain filters the phones by number. An empty number doesn't filter.
+type PhoneFilterDomain struct {
+	Number string
+}
@@ -0,0 +1,89 @@
+package domain
+
+// ScheduleStatus is a step of the schedule lifecycle. A schedule is requested when booked, then
+// confirmed and completed; declined, cancelled and no_show are the other ways it ends.
+type ScheduleStatus string
+
+const (
+	ScheduleStatusRequested ScheduleStatus = "requested"
+	ScheduleStatusConfirmed ScheduleStatus = "confirmed"
+	ScheduleStatusCompleted ScheduleStatus = "completed"
+	ScheduleStatusDeclined  ScheduleStatus = "declined"
+	ScheduleStatusCancelled ScheduleStatus = "cancelled"
+	ScheduleStatusNoShow    ScheduleStatus = "no_show"
+)
+
+// ScheduleAction is what moves a schedule from a status to another.
+type ScheduleAction string
+
+const (
+	// ScheduleActionRequest creates the schedule; it is only recorded, never applied.
+	ScheduleActionRequest    ScheduleAction = "request"
+	ScheduleActionConfirm    ScheduleAction = "confirm"
+	ScheduleActionReschedule ScheduleAction = "reschedule"
+	ScheduleActionDecline    ScheduleAction = "decline"
+	ScheduleActionCancel     ScheduleAction = "cancel"
+	ScheduleActionComplete   ScheduleAction = "complete"
+	ScheduleActionNoShow     ScheduleAction = "no_show"
+)
+
+type scheduleTransition struct {
+	from  []ScheduleStatus
+	to    ScheduleStatus
+	event string
+}
+
+// scheduleTransitions guards the lifecycle: an action is only applied from the listed statuses.
+// A reschedule asks for a new confirmation, since the slot is another one.
+var scheduleTransitions = map[ScheduleAction]scheduleTransition{
+	ScheduleActionConfirm: {from: []ScheduleStatus{ScheduleStatusRequested},
+		to: ScheduleStatusConfirmed, event: EventScheduleConfirmed},
+	ScheduleActionReschedule: {from: []ScheduleStatus{ScheduleStatusRequested, ScheduleStatusConfirmed},
+		to: ScheduleStatusRequested, event: EventScheduleRescheduled},
+	ScheduleActionDecline: {from: []ScheduleStatus{ScheduleStatusRequested},
+		to: ScheduleStatusDeclined, event: EventScheduleDeclined},
+	ScheduleActionCancel: {from: []ScheduleStatus{ScheduleStatusRequested, ScheduleStatusConfirmed},
+		to: ScheduleStatusCancelled, event: EventScheduleCancelled},
+	ScheduleActionComplete: {from: []ScheduleStatus{ScheduleStatusConfirmed},
+		to: ScheduleStatusCompleted, event: EventScheduleCompleted},
+	ScheduleActionNoShow: {from: []ScheduleStatus{ScheduleStatusConfirmed},
+		to: ScheduleStatusNoShow, event: EventScheduleNoShow},
+}
+
+// IsKnown tells whether the action can be applied to a schedule.
+func (action ScheduleAction) IsKnown() bool {
+	_, known := scheduleTransitions[action]
+	return known
+}
+
+// Next returns the status reached by applying the action to a schedule in the status from,
+// or false when the lifecycle doesn't allow it.
+func (action ScheduleAction) Next(from ScheduleStatus) (ScheduleStatus, bool) {
+
+	transition, known := scheduleTransitions[action]
+	if !known {
+		return "", false
+	}
+
+	for _, status := range transition.from {
+		if status == from {
+			return transition.to, true
+		}
+	}
+
+	return "", false
+}
+
+// EventType is the event announcing the action, e.g. schedule.confirmed.
+func (action ScheduleAction) EventType() string {
+	if action == ScheduleActionRequest {
+		return EventScheduleRequested
+	}
+	return scheduleTransitions[action].event
+}
+
+// HoldsSlot tells whether a schedule in the status keeps its attention time booked on its date.
+// Declined and cancelled schedules release it for another booking.
+func (status ScheduleStatus) HoldsSlot() bool {
+	return status != ScheduleStatusDeclined && status != ScheduleStatusCancelled
+}
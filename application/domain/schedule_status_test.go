@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleAction_Next(t *testing.T) {
+
+	tests := []struct {
+		Name            string
+		Action          ScheduleAction
+		From            ScheduleStatus
+		Expected        ScheduleStatus
+		ExpectedAllowed bool
+	}{
+		{Name: "ConfirmRequested_ReturnsConfirmed", Action: ScheduleActionConfirm, From: ScheduleStatusRequested, Expected: ScheduleStatusConfirmed, ExpectedAllowed: true},
+		{Name: "CompleteConfirmed_ReturnsCompleted", Action: ScheduleActionComplete, From: ScheduleStatusConfirmed, Expected: ScheduleStatusCompleted, ExpectedAllowed: true},
+		{Name: "NoShowConfirmed_ReturnsNoShow", Action: ScheduleActionNoShow, From: ScheduleStatusConfirmed, Expected: ScheduleStatusNoShow, ExpectedAllowed: true},
+		{Name: "DeclineRequested_ReturnsDeclined", Action: ScheduleActionDecline, From: ScheduleStatusRequested, Expected: ScheduleStatusDeclined, ExpectedAllowed: true},
+		{Name: "CancelConfirmed_ReturnsCancelled", Action: ScheduleActionCancel, From: ScheduleStatusConfirmed, Expected: ScheduleStatusCancelled, ExpectedAllowed: true},
+		{Name: "RescheduleConfirmed_ReturnsRequested", Action: ScheduleActionReschedule, From: ScheduleStatusConfirmed, Expected: ScheduleStatusRequested, ExpectedAllowed: true},
+		{Name: "CompleteRequested_IsNotAllowed", Action: ScheduleActionComplete, From: ScheduleStatusRequested},
+		{Name: "DeclineConfirmed_IsNotAllowed", Action: ScheduleActionDecline, From: ScheduleStatusConfirmed},
+		{Name: "CancelCompleted_IsNotAllowed", Action: ScheduleActionCancel, From: ScheduleStatusCompleted},
+		{Name: "ConfirmCancelled_IsNotAllowed", Action: ScheduleActionConfirm, From: ScheduleStatusCancelled},
+		{Name: "RequestAction_IsNotAllowed", Action: ScheduleActionRequest, From: ScheduleStatusRequested},
+		{Name: "UnknownAction_IsNotAllowed", Action: "archive", From: ScheduleStatusRequested},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+			next, allowed := test.Action.Next(test.From)
+			assert.Equal(t, test.Expected, next)
+			assert.Equal(t, test.ExpectedAllowed, allowed)
+		})
+	}
+}
+
+func TestScheduleAction_EventType(t *testing.T) {
+	assert.Equal(t, EventScheduleRequested, ScheduleActionRequest.EventType())
+	assert.Equal(t, EventScheduleConfirmed, ScheduleActionConfirm.EventType())
+	assert.Equal(t, EventScheduleRescheduled, ScheduleActionReschedule.EventType())
+	assert.Equal(t, EventScheduleNoShow, ScheduleActionNoShow.EventType())
+	assert.Empty(t, ScheduleAction("archive").EventType())
+}
+
+func TestScheduleStatus_HoldsSlot(t *testing.T) {
+	assert.True(t, ScheduleStatusRequested.HoldsSlot())
+	assert.True(t, ScheduleStatusConfirmed.HoldsSlot())
+	assert.True(t, ScheduleStatusCompleted.HoldsSlot())
+	assert.True(t, ScheduleStatusNoShow.HoldsSlot())
+	assert.False(t, ScheduleStatusDeclined.HoldsSlot())
+	assert.False(t, ScheduleStatusCancelled.HoldsSlot())
+}
@@ -5,4 +5,5 @@ import "github.com/petshop-system/petshop-api/application/domain"
 type IAddressService interface {
 	Create(contextControl domain.ContextControl, customer domain.AddressDomain) (domain.AddressDomain, error)
 	GetByID(contextControl domain.ContextControl, ID int64) (domain.AddressDomain, bool, error)
+	List(contextControl domain.ContextControl, filter domain.AddressFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.AddressDomain], error)
 }
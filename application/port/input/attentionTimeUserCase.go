@@ -0,0 +1,10 @@
+package input
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type IAttentionTimeService interface {
+	Create(contextControl domain.ContextControl, attentionTime domain.AttentionTimeDomain) (domain.AttentionTimeDomain, error)
+	SetActive(contextControl domain.ContextControl, ID int64, active bool) (domain.AttentionTimeDomain, bool, error)
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.AttentionTimeDomain, bool, error)
+	GetByEmployeeID(contextControl domain.ContextControl, employeeID int64) ([]domain.AttentionTimeDomain, bool, error)
+}
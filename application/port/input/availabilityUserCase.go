@@ -0,0 +1,11 @@
+package input
+
+import (
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+type IAvailabilityService interface {
+	GetAvailability(contextControl domain.ContextControl, serviceID int64, from, to time.Time) ([]domain.EmployeeAvailabilityDomain, bool, error)
+}
@@ -0,0 +1,7 @@
+package input
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type ICacheStatsService interface {
+	GetCacheStats() map[string]domain.CacheStatsDomain
+}
@@ -0,0 +1,10 @@
+package input
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type ICatalogService interface {
+	GetSpeciesTree(contextControl domain.ContextControl) ([]domain.SpeciesDomain, error)
+	GetBreedsBySpeciesID(contextControl domain.ContextControl, speciesID int64) ([]domain.BreedDomain, bool, error)
+	CreateSpecies(contextControl domain.ContextControl, species domain.SpeciesDomain) (domain.SpeciesDomain, error)
+	CreateBreed(contextControl domain.ContextControl, breed domain.BreedDomain) (domain.BreedDomain, error)
+}
@@ -0,0 +1,9 @@
+package input
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type IContractService interface {
+	Create(contextControl domain.ContextControl, contract domain.ContractDomain) (domain.ContractDomain, error)
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error)
+	Update(contextControl domain.ContextControl, contract domain.ContractDomain) (domain.ContractDomain, bool, error)
+}
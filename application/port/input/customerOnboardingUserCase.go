@@ -0,0 +1,7 @@
+package input
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type ICustomerOnboardingService interface {
+	Onboard(contextControl domain.ContextControl, onboarding domain.CustomerOnboardingDomain) (domain.CustomerOnboardingDomain, error)
+}
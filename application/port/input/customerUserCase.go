@@ -4,6 +4,13 @@ import "github.com/petshop-system/petshop-api/application/domain"
 
 type ICustomerService interface {
 	Create(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, error)
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error)
+	Update(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, bool, error)
+	Patch(contextControl domain.ContextControl, ID int64, patch domain.CustomerPatchDomain) (domain.CustomerDomain, bool, error)
+	Delete(contextControl domain.ContextControl, ID int64) (bool, error)
+	Restore(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error)
+	List(contextControl domain.ContextControl, filter domain.CustomerFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerDomain], error)
+	GetHistory(contextControl domain.ContextControl, ID int64, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerHistoryDomain], bool, error)
 	ValidateTypePerson(customer domain.CustomerDomain) error
 	ValidateCreate(customer domain.CustomerDomain) error
 }
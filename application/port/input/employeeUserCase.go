@@ -0,0 +1,11 @@
+package input
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type IEmployeeService interface {
+	Create(contextControl domain.ContextControl, employee domain.EmployeeDomain) (domain.EmployeeDomain, error)
+	Update(contextControl domain.ContextControl, employee domain.EmployeeDomain) (domain.EmployeeDomain, bool, error)
+	SetActive(contextControl domain.ContextControl, ID int64, active bool) (domain.EmployeeDomain, bool, error)
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error)
+	GetAll(contextControl domain.ContextControl) ([]domain.EmployeeDomain, error)
+}
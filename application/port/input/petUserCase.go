@@ -0,0 +1,9 @@
+package input
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type IPetService interface {
+	Create(contextControl domain.ContextControl, pet domain.PetDomain) (domain.PetDomain, error)
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.PetDomain, bool, error)
+	GetByCustomerID(contextControl domain.ContextControl, customerID int64) ([]domain.PetDomain, error)
+}
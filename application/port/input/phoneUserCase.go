@@ -5,4 +5,9 @@ import "github.com/petshop-system/petshop-api/application/domain"
 type IPhoneService interface {
 	Create(contextControl domain.ContextControl, phone domain.PhoneDomain) (domain.PhoneDomain, error)
 	GetByID(contextControl domain.ContextControl, ID int64) (domain.PhoneDomain, bool, error)
+	Attach(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) (domain.PhoneUserDomain, bool, error)
+	Detach(contextControl domain.ContextControl, phoneID int64) (bool, error)
+	GetByOwner(contextControl domain.ContextControl, userType string, userID int64) ([]domain.PhoneUserDomain, bool, error)
+	SetPrimary(contextControl domain.ContextControl, phoneID int64) (domain.PhoneUserDomain, bool, error)
+	List(contextControl domain.ContextControl, filter domain.PhoneFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.PhoneDomain], error)
 }
@@ -4,4 +4,7 @@ import "github.com/petshop-system/petshop-api/application/domain"
 
 type IScheduleService interface {
 	CreateFromMessage(contextControl domain.ContextControl, message domain.ScheduleMessage) error
+	ApplyCommand(contextControl domain.ContextControl, command domain.ScheduleCommand) (domain.ScheduleDomain, bool, error)
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.ScheduleDomain, bool, error)
+	GetHistory(contextControl domain.ContextControl, ID int64) ([]domain.ScheduleHistoryDomain, bool, error)
 }
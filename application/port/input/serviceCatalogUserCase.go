@@ -0,0 +1,12 @@
+package input
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type IServiceCatalogService interface {
+	Create(contextControl domain.ContextControl, service domain.ServiceDomain) (domain.ServiceDomain, error)
+	Update(contextControl domain.ContextControl, service domain.ServiceDomain) (domain.ServiceDomain, bool, error)
+	SetActive(contextControl domain.ContextControl, ID int64, active bool) (domain.ServiceDomain, bool, error)
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error)
+	GetAll(contextControl domain.ContextControl) ([]domain.ServiceDomain, error)
+	GetPriceHistory(contextControl domain.ContextControl, serviceID int64) ([]domain.ServicePriceHistoryDomain, bool, error)
+}
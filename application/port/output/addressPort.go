@@ -9,6 +9,7 @@ import (
 type IAddressDomainDataBaseRepository interface {
 	Save(contextControl domain.ContextControl, address domain.AddressDomain) (domain.AddressDomain, error)
 	GetByID(contextControl domain.ContextControl, ID int64) (domain.AddressDomain, bool, error)
+	List(contextControl domain.ContextControl, filter domain.AddressFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.AddressDomain], error)
 }
 
 type IAddressDomainCacheRepository interface {
@@ -9,6 +9,7 @@ import (
 type AddressDomainDataBaseRepositoryMock struct {
 	SaveMock    func(contextControl domain.ContextControl, address domain.AddressDomain) (domain.AddressDomain, error)
 	GetByIDMock func(contextControl domain.ContextControl, ID int64) (domain.AddressDomain, bool, error)
+	ListMock    func(contextControl domain.ContextControl, filter domain.AddressFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.AddressDomain], error)
 }
 
 type AddressDomainCacheRepositoryMock struct {
@@ -31,6 +32,13 @@ func (c AddressDomainDataBaseRepositoryMock) GetByID(contextControl domain.Conte
 	return domain.AddressDomain{}, false, nil
 }
 
+func (c AddressDomainDataBaseRepositoryMock) List(contextControl domain.ContextControl, filter domain.AddressFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.AddressDomain], error) {
+	if c.ListMock != nil {
+		return c.ListMock(contextControl, filter, page)
+	}
+	return domain.PageResultDomain[domain.AddressDomain]{}, nil
+}
+
 func (c AddressDomainCacheRepositoryMock) Delete(contextControl domain.ContextControl, key string) error {
 	if c.DeleteMock != nil {
 		return c.DeleteMock(contextControl, key)
@@ -0,0 +1,11 @@
+package output
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type IAttentionTimeDomainDataBaseRepository interface {
+	Save(contextControl domain.ContextControl, attentionTime domain.AttentionTimeDomain) (domain.AttentionTimeDomain, error)
+	SetActive(contextControl domain.ContextControl, ID int64, active bool) error
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.AttentionTimeDomain, bool, error)
+	GetByEmployeeID(contextControl domain.ContextControl, employeeID int64) ([]domain.AttentionTimeDomain, error)
+	GetByServiceID(contextControl domain.ContextControl, serviceID int64) ([]domain.AttentionTimeDomain, error)
+}
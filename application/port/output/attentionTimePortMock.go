@@ -0,0 +1,46 @@
+package output
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type AttentionTimeDomainDataBaseRepositoryMock struct {
+	SaveMock            func(contextControl domain.ContextControl, attentionTime domain.AttentionTimeDomain) (domain.AttentionTimeDomain, error)
+	SetActiveMock       func(contextControl domain.ContextControl, ID int64, active bool) error
+	GetByIDMock         func(contextControl domain.ContextControl, ID int64) (domain.AttentionTimeDomain, bool, error)
+	GetByEmployeeIDMock func(contextControl domain.ContextControl, employeeID int64) ([]domain.AttentionTimeDomain, error)
+	GetByServiceIDMock  func(contextControl domain.ContextControl, serviceID int64) ([]domain.AttentionTimeDomain, error)
+}
+
+func (c AttentionTimeDomainDataBaseRepositoryMock) Save(contextControl domain.ContextControl, attentionTime domain.AttentionTimeDomain) (domain.AttentionTimeDomain, error) {
+	if c.SaveMock != nil {
+		return c.SaveMock(contextControl, attentionTime)
+	}
+	return domain.AttentionTimeDomain{}, nil
+}
+
+func (c AttentionTimeDomainDataBaseRepositoryMock) SetActive(contextControl domain.ContextControl, ID int64, active bool) error {
+	if c.SetActiveMock != nil {
+		return c.SetActiveMock(contextControl, ID, active)
+	}
+	return nil
+}
+
+func (c AttentionTimeDomainDataBaseRepositoryMock) GetByID(contextControl domain.ContextControl, ID int64) (domain.AttentionTimeDomain, bool, error) {
+	if c.GetByIDMock != nil {
+		return c.GetByIDMock(contextControl, ID)
+	}
+	return domain.AttentionTimeDomain{}, false, nil
+}
+
+func (c AttentionTimeDomainDataBaseRepositoryMock) GetByEmployeeID(contextControl domain.ContextControl, employeeID int64) ([]domain.AttentionTimeDomain, error) {
+	if c.GetByEmployeeIDMock != nil {
+		return c.GetByEmployeeIDMock(contextControl, employeeID)
+	}
+	return nil, nil
+}
+
+func (c AttentionTimeDomainDataBaseRepositoryMock) GetByServiceID(contextControl domain.ContextControl, serviceID int64) ([]domain.AttentionTimeDomain, error) {
+	if c.GetByServiceIDMock != nil {
+		return c.GetByServiceIDMock(contextControl, serviceID)
+	}
+	return nil, nil
+}
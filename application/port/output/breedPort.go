@@ -0,0 +1,10 @@
+package output
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type IBreedDomainDataBaseRepository interface {
+	Save(contextControl domain.ContextControl, breed domain.BreedDomain) (domain.BreedDomain, error)
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.BreedDomain, bool, error)
+	GetBySpeciesIDAndName(contextControl domain.ContextControl, speciesID int64, name string) (domain.BreedDomain, bool, error)
+	GetAll(contextControl domain.ContextControl) ([]domain.BreedDomain, error)
+}
@@ -0,0 +1,38 @@
+package output
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type BreedDomainDataBaseRepositoryMock struct {
+	SaveMock                  func(contextControl domain.ContextControl, breed domain.BreedDomain) (domain.BreedDomain, error)
+	GetByIDMock               func(contextControl domain.ContextControl, ID int64) (domain.BreedDomain, bool, error)
+	GetBySpeciesIDAndNameMock func(contextControl domain.ContextControl, speciesID int64, name string) (domain.BreedDomain, bool, error)
+	GetAllMock                func(contextControl domain.ContextControl) ([]domain.BreedDomain, error)
+}
+
+func (c BreedDomainDataBaseRepositoryMock) Save(contextControl domain.ContextControl, breed domain.BreedDomain) (domain.BreedDomain, error) {
+	if c.SaveMock != nil {
+		return c.SaveMock(contextControl, breed)
+	}
+	return domain.BreedDomain{}, nil
+}
+
+func (c BreedDomainDataBaseRepositoryMock) GetByID(contextControl domain.ContextControl, ID int64) (domain.BreedDomain, bool, error) {
+	if c.GetByIDMock != nil {
+		return c.GetByIDMock(contextControl, ID)
+	}
+	return domain.BreedDomain{}, false, nil
+}
+
+func (c BreedDomainDataBaseRepositoryMock) GetBySpeciesIDAndName(contextControl domain.ContextControl, speciesID int64, name string) (domain.BreedDomain, bool, error) {
+	if c.GetBySpeciesIDAndNameMock != nil {
+		return c.GetBySpeciesIDAndNameMock(contextControl, speciesID, name)
+	}
+	return domain.BreedDomain{}, false, nil
+}
+
+func (c BreedDomainDataBaseRepositoryMock) GetAll(contextControl domain.ContextControl) ([]domain.BreedDomain, error) {
+	if c.GetAllMock != nil {
+		return c.GetAllMock(contextControl)
+	}
+	return nil, nil
+}
@@ -0,0 +1,8 @@
+package output
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type ICacheKeyRepository interface {
+	GetKeysByPattern(contextControl domain.ContextControl, pattern string) ([]string, error)
+	Delete(contextControl domain.ContextControl, key string) error
+}
@@ -0,0 +1,22 @@
+package output
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type CacheKeyRepositoryMock struct {
+	GetKeysByPatternMock func(contextControl domain.ContextControl, pattern string) ([]string, error)
+	DeleteMock           func(contextControl domain.ContextControl, key string) error
+}
+
+func (c CacheKeyRepositoryMock) GetKeysByPattern(contextControl domain.ContextControl, pattern string) ([]string, error) {
+	if c.GetKeysByPatternMock != nil {
+		return c.GetKeysByPatternMock(contextControl, pattern)
+	}
+	return nil, nil
+}
+
+func (c CacheKeyRepositoryMock) Delete(contextControl domain.ContextControl, key string) error {
+	if c.DeleteMock != nil {
+		return c.DeleteMock(contextControl, key)
+	}
+	return nil
+}
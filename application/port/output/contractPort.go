@@ -0,0 +1,22 @@
+package output
+
+import (
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+type IContractDomainDataBaseRepository interface {
+	// Save stores the contract together with its address.
+	Save(contextControl domain.ContextControl, contract domain.ContractDomain) (domain.ContractDomain, error)
+	Update(contextControl domain.ContextControl, contract domain.ContractDomain) error
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error)
+	GetByDocument(contextControl domain.ContextControl, document string) (domain.ContractDomain, bool, error)
+	GetByEmail(contextControl domain.ContextControl, email string) (domain.ContractDomain, bool, error)
+}
+
+type IContractDomainCacheRepository interface {
+	Set(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error
+	Get(contextControl domain.ContextControl, key string) (string, error)
+	Delete(contextControl domain.ContextControl, key string) error
+}
@@ -0,0 +1,77 @@
+package output
+
+import (
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+type ContractDomainDataBaseRepositoryMock struct {
+	SaveMock          func(contextControl domain.ContextControl, contract domain.ContractDomain) (domain.ContractDomain, error)
+	UpdateMock        func(contextControl domain.ContextControl, contract domain.ContractDomain) error
+	GetByIDMock       func(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error)
+	GetByDocumentMock func(contextControl domain.ContextControl, document string) (domain.ContractDomain, bool, error)
+	GetByEmailMock    func(contextControl domain.ContextControl, email string) (domain.ContractDomain, bool, error)
+}
+
+type ContractDomainCacheRepositoryMock struct {
+	SetMock    func(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error
+	GetMock    func(contextControl domain.ContextControl, key string) (string, error)
+	DeleteMock func(contextControl domain.ContextControl, key string) error
+}
+
+func (c ContractDomainDataBaseRepositoryMock) Save(contextControl domain.ContextControl, contract domain.ContractDomain) (domain.ContractDomain, error) {
+	if c.SaveMock != nil {
+		return c.SaveMock(contextControl, contract)
+	}
+	return domain.ContractDomain{}, nil
+}
+
+func (c ContractDomainDataBaseRepositoryMock) Update(contextControl domain.ContextControl, contract domain.ContractDomain) error {
+	if c.UpdateMock != nil {
+		return c.UpdateMock(contextControl, contract)
+	}
+	return nil
+}
+
+func (c ContractDomainDataBaseRepositoryMock) GetByID(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error) {
+	if c.GetByIDMock != nil {
+		return c.GetByIDMock(contextControl, ID)
+	}
+	return domain.ContractDomain{}, false, nil
+}
+
+func (c ContractDomainDataBaseRepositoryMock) GetByDocument(contextControl domain.ContextControl, document string) (domain.ContractDomain, bool, error) {
+	if c.GetByDocumentMock != nil {
+		return c.GetByDocumentMock(contextControl, document)
+	}
+	return domain.ContractDomain{}, false, nil
+}
+
+func (c ContractDomainDataBaseRepositoryMock) GetByEmail(contextControl domain.ContextControl, email string) (domain.ContractDomain, bool, error) {
+	if c.GetByEmailMock != nil {
+		return c.GetByEmailMock(contextControl, email)
+	}
+	return domain.ContractDomain{}, false, nil
+}
+
+func (c ContractDomainCacheRepositoryMock) Set(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error {
+	if c.SetMock != nil {
+		return c.SetMock(contextControl, key, hash, expirationTime)
+	}
+	return nil
+}
+
+func (c ContractDomainCacheRepositoryMock) Get(contextControl domain.ContextControl, key string) (string, error) {
+	if c.GetMock != nil {
+		return c.GetMock(contextControl, key)
+	}
+	return "", nil
+}
+
+func (c ContractDomainCacheRepositoryMock) Delete(contextControl domain.ContextControl, key string) error {
+	if c.DeleteMock != nil {
+		return c.DeleteMock(contextControl, key)
+	}
+	return nil
+}
@@ -8,7 +8,14 @@ import (
 
 type ICustomerDomainDataBaseRepository interface {
 	Save(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, error)
-	GetByID(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, error)
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error)
+	GetByIDIncludingDeleted(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error)
+	Update(contextControl domain.ContextControl, customer domain.CustomerDomain) error
+	Delete(contextControl domain.ContextControl, ID int64, deletedAt time.Time) error
+	Restore(contextControl domain.ContextControl, ID int64) error
+	List(contextControl domain.ContextControl, filter domain.CustomerFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerDomain], error)
+	SaveHistory(contextControl domain.ContextControl, history domain.CustomerHistoryDomain) error
+	GetHistory(contextControl domain.ContextControl, customerID int64, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerHistoryDomain], error)
 }
 
 type ICustomerDomainCacheRepository interface {
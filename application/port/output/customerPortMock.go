@@ -7,8 +7,15 @@ import (
 )
 
 type CustomerDomainDataBaseRepositoryMock struct {
-	SaveMock    func(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, error)
-	GetByIDMock func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, error)
+	SaveMock                    func(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, error)
+	GetByIDMock                 func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error)
+	GetByIDIncludingDeletedMock func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error)
+	UpdateMock                  func(contextControl domain.ContextControl, customer domain.CustomerDomain) error
+	DeleteMock                  func(contextControl domain.ContextControl, ID int64, deletedAt time.Time) error
+	RestoreMock                 func(contextControl domain.ContextControl, ID int64) error
+	ListMock                    func(contextControl domain.ContextControl, filter domain.CustomerFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerDomain], error)
+	SaveHistoryMock             func(contextControl domain.ContextControl, history domain.CustomerHistoryDomain) error
+	GetHistoryMock              func(contextControl domain.ContextControl, customerID int64, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerHistoryDomain], error)
 }
 
 type CustomerDomainCacheRepositoryMock struct {
@@ -24,11 +31,60 @@ func (c CustomerDomainDataBaseRepositoryMock) Save(contextControl domain.Context
 	return domain.CustomerDomain{}, nil
 }
 
-func (c CustomerDomainDataBaseRepositoryMock) GetByID(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, error) {
+func (c CustomerDomainDataBaseRepositoryMock) GetByID(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
 	if c.GetByIDMock != nil {
 		return c.GetByIDMock(contextControl, ID)
 	}
-	return domain.CustomerDomain{}, nil
+	return domain.CustomerDomain{}, false, nil
+}
+
+func (c CustomerDomainDataBaseRepositoryMock) GetByIDIncludingDeleted(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+	if c.GetByIDIncludingDeletedMock != nil {
+		return c.GetByIDIncludingDeletedMock(contextControl, ID)
+	}
+	return domain.CustomerDomain{}, false, nil
+}
+
+func (c CustomerDomainDataBaseRepositoryMock) Update(contextControl domain.ContextControl, customer domain.CustomerDomain) error {
+	if c.UpdateMock != nil {
+		return c.UpdateMock(contextControl, customer)
+	}
+	return nil
+}
+
+func (c CustomerDomainDataBaseRepositoryMock) Delete(contextControl domain.ContextControl, ID int64, deletedAt time.Time) error {
+	if c.DeleteMock != nil {
+		return c.DeleteMock(contextControl, ID, deletedAt)
+	}
+	return nil
+}
+
+func (c CustomerDomainDataBaseRepositoryMock) Restore(contextControl domain.ContextControl, ID int64) error {
+	if c.RestoreMock != nil {
+		return c.RestoreMock(contextControl, ID)
+	}
+	return nil
+}
+
+func (c CustomerDomainDataBaseRepositoryMock) List(contextControl domain.ContextControl, filter domain.CustomerFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerDomain], error) {
+	if c.ListMock != nil {
+		return c.ListMock(contextControl, filter, page)
+	}
+	return domain.PageResultDomain[domain.CustomerDomain]{}, nil
+}
+
+func (c CustomerDomainDataBaseRepositoryMock) SaveHistory(contextControl domain.ContextControl, history domain.CustomerHistoryDomain) error {
+	if c.SaveHistoryMock != nil {
+		return c.SaveHistoryMock(contextControl, history)
+	}
+	return nil
+}
+
+func (c CustomerDomainDataBaseRepositoryMock) GetHistory(contextControl domain.ContextControl, customerID int64, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerHistoryDomain], error) {
+	if c.GetHistoryMock != nil {
+		return c.GetHistoryMock(contextControl, customerID, page)
+	}
+	return domain.PageResultDomain[domain.CustomerHistoryDomain]{}, nil
 }
 
 func (c CustomerDomainCacheRepositoryMock) Delete(contextControl domain.ContextControl, key string) error {
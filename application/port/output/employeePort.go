@@ -0,0 +1,13 @@
+package output
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type IEmployeeDomainDataBaseRepository interface {
+	Save(contextControl domain.ContextControl, employee domain.EmployeeDomain) (domain.EmployeeDomain, error)
+	Update(contextControl domain.ContextControl, employee domain.EmployeeDomain) error
+	SetActive(contextControl domain.ContextControl, ID int64, active bool) error
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error)
+	GetByRegister(contextControl domain.ContextControl, register string) (domain.EmployeeDomain, bool, error)
+	GetByDocument(contextControl domain.ContextControl, document string) (domain.EmployeeDomain, bool, error)
+	GetAll(contextControl domain.ContextControl) ([]domain.EmployeeDomain, error)
+}
@@ -0,0 +1,62 @@
+package output
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type EmployeeDomainDataBaseRepositoryMock struct {
+	SaveMock          func(contextControl domain.ContextControl, employee domain.EmployeeDomain) (domain.EmployeeDomain, error)
+	UpdateMock        func(contextControl domain.ContextControl, employee domain.EmployeeDomain) error
+	SetActiveMock     func(contextControl domain.ContextControl, ID int64, active bool) error
+	GetByIDMock       func(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error)
+	GetByRegisterMock func(contextControl domain.ContextControl, register string) (domain.EmployeeDomain, bool, error)
+	GetByDocumentMock func(contextControl domain.ContextControl, document string) (domain.EmployeeDomain, bool, error)
+	GetAllMock        func(contextControl domain.ContextControl) ([]domain.EmployeeDomain, error)
+}
+
+func (c EmployeeDomainDataBaseRepositoryMock) Save(contextControl domain.ContextControl, employee domain.EmployeeDomain) (domain.EmployeeDomain, error) {
+	if c.SaveMock != nil {
+		return c.SaveMock(contextControl, employee)
+	}
+	return domain.EmployeeDomain{}, nil
+}
+
+func (c EmployeeDomainDataBaseRepositoryMock) Update(contextControl domain.ContextControl, employee domain.EmployeeDomain) error {
+	if c.UpdateMock != nil {
+		return c.UpdateMock(contextControl, employee)
+	}
+	return nil
+}
+
+func (c EmployeeDomainDataBaseRepositoryMock) SetActive(contextControl domain.ContextControl, ID int64, active bool) error {
+	if c.SetActiveMock != nil {
+		return c.SetActiveMock(contextControl, ID, active)
+	}
+	return nil
+}
+
+func (c EmployeeDomainDataBaseRepositoryMock) GetByID(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+	if c.GetByIDMock != nil {
+		return c.GetByIDMock(contextControl, ID)
+	}
+	return domain.EmployeeDomain{}, false, nil
+}
+
+func (c EmployeeDomainDataBaseRepositoryMock) GetByRegister(contextControl domain.ContextControl, register string) (domain.EmployeeDomain, bool, error) {
+	if c.GetByRegisterMock != nil {
+		return c.GetByRegisterMock(contextControl, register)
+	}
+	return domain.EmployeeDomain{}, false, nil
+}
+
+func (c EmployeeDomainDataBaseRepositoryMock) GetByDocument(contextControl domain.ContextControl, document string) (domain.EmployeeDomain, bool, error) {
+	if c.GetByDocumentMock != nil {
+		return c.GetByDocumentMock(contextControl, document)
+	}
+	return domain.EmployeeDomain{}, false, nil
+}
+
+func (c EmployeeDomainDataBaseRepositoryMock) GetAll(contextControl domain.ContextControl) ([]domain.EmployeeDomain, error) {
+	if c.GetAllMock != nil {
+		return c.GetAllMock(contextControl)
+	}
+	return nil, nil
+}
@@ -0,0 +1,8 @@
+package output
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+// IEventPublisher announces domain events to the other services.
+type IEventPublisher interface {
+	Publish(contextControl domain.ContextControl, events ...domain.EventDomain) error
+}
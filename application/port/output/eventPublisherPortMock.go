@@ -0,0 +1,14 @@
+package output
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type EventPublisherMock struct {
+	PublishMock func(contextControl domain.ContextControl, events ...domain.EventDomain) error
+}
+
+func (c EventPublisherMock) Publish(contextControl domain.ContextControl, events ...domain.EventDomain) error {
+	if c.PublishMock != nil {
+		return c.PublishMock(contextControl, events...)
+	}
+	return nil
+}
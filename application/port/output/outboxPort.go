@@ -0,0 +1,17 @@
+package output
+
+import (
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+type IOutboxDomainDataBaseRepository interface {
+	Save(contextControl domain.ContextControl, events ...domain.EventDomain) error
+	TryLock(contextControl domain.ContextControl) (bool, error)
+	ClaimPending(contextControl domain.ContextControl, now, claimUntil time.Time, limit int) ([]domain.OutboxDomain, error)
+	MarkPublished(contextControl domain.ContextControl, ID int64, publishedAt time.Time) error
+	MarkFailed(contextControl domain.ContextControl, ID int64, attempts int, nextAttemptAt time.Time, lastError string) error
+	Park(contextControl domain.ContextControl, ID int64, attempts int, parkedAt time.Time, lastError string) error
+	DeletePublishedBefore(contextControl domain.ContextControl, before time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+type OutboxDomainDataBaseRepositoryMock struct {
+	SaveMock                  func(contextControl domain.ContextControl, events ...domain.EventDomain) error
+	TryLockMock               func(contextControl domain.ContextControl) (bool, error)
+	ClaimPendingMock          func(contextControl domain.ContextControl, now, claimUntil time.Time, limit int) ([]domain.OutboxDomain, error)
+	MarkPublishedMock         func(contextControl domain.ContextControl, ID int64, publishedAt time.Time) error
+	MarkFailedMock            func(contextControl domain.ContextControl, ID int64, attempts int, nextAttemptAt time.Time, lastError string) error
+	ParkMock                  func(contextControl domain.ContextControl, ID int64, attempts int, parkedAt time.Time, lastError string) error
+	DeletePublishedBeforeMock func(contextControl domain.ContextControl, before time.Time, limit int) (int64, error)
+}
+
+func (c OutboxDomainDataBaseRepositoryMock) Save(contextControl domain.ContextControl, events ...domain.EventDomain) error {
+	if c.SaveMock != nil {
+		return c.SaveMock(contextControl, events...)
+	}
+	return nil
+}
+
+func (c OutboxDomainDataBaseRepositoryMock) TryLock(contextControl domain.ContextControl) (bool, error) {
+	if c.TryLockMock != nil {
+		return c.TryLockMock(contextControl)
+	}
+	return false, nil
+}
+
+func (c OutboxDomainDataBaseRepositoryMock) ClaimPending(contextControl domain.ContextControl, now, claimUntil time.Time, limit int) ([]domain.OutboxDomain, error) {
+	if c.ClaimPendingMock != nil {
+		return c.ClaimPendingMock(contextControl, now, claimUntil, limit)
+	}
+	return nil, nil
+}
+
+func (c OutboxDomainDataBaseRepositoryMock) MarkPublished(contextControl domain.ContextControl, ID int64, publishedAt time.Time) error {
+	if c.MarkPublishedMock != nil {
+		return c.MarkPublishedMock(contextControl, ID, publishedAt)
+	}
+	return nil
+}
+
+func (c OutboxDomainDataBaseRepositoryMock) MarkFailed(contextControl domain.ContextControl, ID int64, attempts int, nextAttemptAt time.Time, lastError string) error {
+	if c.MarkFailedMock != nil {
+		return c.MarkFailedMock(contextControl, ID, attempts, nextAttemptAt, lastError)
+	}
+	return nil
+}
+
+func (c OutboxDomainDataBaseRepositoryMock) Park(contextControl domain.ContextControl, ID int64, attempts int, parkedAt time.Time, lastError string) error {
+	if c.ParkMock != nil {
+		return c.ParkMock(contextControl, ID, attempts, parkedAt, lastError)
+	}
+	return nil
+}
+
+func (c OutboxDomainDataBaseRepositoryMock) DeletePublishedBefore(contextControl domain.ContextControl, before time.Time, limit int) (int64, error) {
+	if c.DeletePublishedBeforeMock != nil {
+		return c.DeletePublishedBeforeMock(contextControl, before, limit)
+	}
+	return 0, nil
+}
@@ -0,0 +1,19 @@
+package output
+
+import (
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+type IPetDomainDataBaseRepository interface {
+	Save(contextControl domain.ContextControl, pet domain.PetDomain) (domain.PetDomain, error)
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.PetDomain, bool, error)
+	GetByCustomerID(contextControl domain.ContextControl, customerID int64) ([]domain.PetDomain, error)
+}
+
+type IPetDomainCacheRepository interface {
+	Set(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error
+	Get(contextControl domain.ContextControl, key string) (string, error)
+	Delete(contextControl domain.ContextControl, key string) error
+}
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+type PetDomainDataBaseRepositoryMock struct {
+	SaveMock            func(contextControl domain.ContextControl, pet domain.PetDomain) (domain.PetDomain, error)
+	GetByIDMock         func(contextControl domain.ContextControl, ID int64) (domain.PetDomain, bool, error)
+	GetByCustomerIDMock func(contextControl domain.ContextControl, customerID int64) ([]domain.PetDomain, error)
+}
+
+type PetDomainCacheRepositoryMock struct {
+	SetMock    func(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error
+	GetMock    func(contextControl domain.ContextControl, key string) (string, error)
+	DeleteMock func(contextControl domain.ContextControl, key string) error
+}
+
+func (c PetDomainDataBaseRepositoryMock) Save(contextControl domain.ContextControl, pet domain.PetDomain) (domain.PetDomain, error) {
+	if c.SaveMock != nil {
+		return c.SaveMock(contextControl, pet)
+	}
+	return domain.PetDomain{}, nil
+}
+
+func (c PetDomainDataBaseRepositoryMock) GetByID(contextControl domain.ContextControl, ID int64) (domain.PetDomain, bool, error) {
+	if c.GetByIDMock != nil {
+		return c.GetByIDMock(contextControl, ID)
+	}
+	return domain.PetDomain{}, false, nil
+}
+
+func (c PetDomainDataBaseRepositoryMock) GetByCustomerID(contextControl domain.ContextControl, customerID int64) ([]domain.PetDomain, error) {
+	if c.GetByCustomerIDMock != nil {
+		return c.GetByCustomerIDMock(contextControl, customerID)
+	}
+	return nil, nil
+}
+
+func (c PetDomainCacheRepositoryMock) Delete(contextControl domain.ContextControl, key string) error {
+	if c.DeleteMock != nil {
+		return c.DeleteMock(contextControl, key)
+	}
+	return nil
+}
+
+func (c PetDomainCacheRepositoryMock) Get(contextControl domain.ContextControl, key string) (string, error) {
+	if c.GetMock != nil {
+		return c.GetMock(contextControl, key)
+	}
+	return "", nil
+}
+
+func (c PetDomainCacheRepositoryMock) Set(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error {
+	if c.SetMock != nil {
+		return c.SetMock(contextControl, key, hash, expirationTime)
+	}
+	return nil
+}
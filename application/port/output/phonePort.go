@@ -9,6 +9,12 @@ import (
 type IPhoneDomainDataBaseRepository interface {
 	Save(contextControl domain.ContextControl, phone domain.PhoneDomain) (domain.PhoneDomain, error)
 	GetByID(contextControl domain.ContextControl, ID int64) (domain.PhoneDomain, bool, error)
+	SaveUser(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) (domain.PhoneUserDomain, error)
+	GetUserByPhoneID(contextControl domain.ContextControl, phoneID int64) (domain.PhoneUserDomain, bool, error)
+	GetUsersByOwner(contextControl domain.ContextControl, userType string, userID int64) ([]domain.PhoneUserDomain, error)
+	DeleteUser(contextControl domain.ContextControl, phoneID int64) error
+	SetPrimaryUser(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) error
+	List(contextControl domain.ContextControl, filter domain.PhoneFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.PhoneDomain], error)
 }
 
 type IPhoneDomainCacheRepository interface {
@@ -7,8 +7,14 @@ import (
 )
 
 type PhoneDomainDataBaseRepositoryMock struct {
-	SaveMock    func(contextControl domain.ContextControl, phone domain.PhoneDomain) (domain.PhoneDomain, error)
-	GetByIDMock func(contextControl domain.ContextControl, ID int64) (domain.PhoneDomain, bool, error)
+	SaveMock             func(contextControl domain.ContextControl, phone domain.PhoneDomain) (domain.PhoneDomain, error)
+	GetByIDMock          func(contextControl domain.ContextControl, ID int64) (domain.PhoneDomain, bool, error)
+	SaveUserMock         func(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) (domain.PhoneUserDomain, error)
+	GetUserByPhoneIDMock func(contextControl domain.ContextControl, phoneID int64) (domain.PhoneUserDomain, bool, error)
+	GetUsersByOwnerMock  func(contextControl domain.ContextControl, userType string, userID int64) ([]domain.PhoneUserDomain, error)
+	DeleteUserMock       func(contextControl domain.ContextControl, phoneID int64) error
+	SetPrimaryUserMock   func(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) error
+	ListMock             func(contextControl domain.ContextControl, filter domain.PhoneFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.PhoneDomain], error)
 }
 
 type PhoneDomainCacheRepositoryMock struct {
@@ -31,6 +37,48 @@ func (c PhoneDomainDataBaseRepositoryMock) GetByID(contextControl domain.Context
 	return domain.PhoneDomain{}, false, nil
 }
 
+func (c PhoneDomainDataBaseRepositoryMock) SaveUser(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) (domain.PhoneUserDomain, error) {
+	if c.SaveUserMock != nil {
+		return c.SaveUserMock(contextControl, phoneUser)
+	}
+	return domain.PhoneUserDomain{}, nil
+}
+
+func (c PhoneDomainDataBaseRepositoryMock) GetUserByPhoneID(contextControl domain.ContextControl, phoneID int64) (domain.PhoneUserDomain, bool, error) {
+	if c.GetUserByPhoneIDMock != nil {
+		return c.GetUserByPhoneIDMock(contextControl, phoneID)
+	}
+	return domain.PhoneUserDomain{}, false, nil
+}
+
+func (c PhoneDomainDataBaseRepositoryMock) GetUsersByOwner(contextControl domain.ContextControl, userType string, userID int64) ([]domain.PhoneUserDomain, error) {
+	if c.GetUsersByOwnerMock != nil {
+		return c.GetUsersByOwnerMock(contextControl, userType, userID)
+	}
+	return nil, nil
+}
+
+func (c PhoneDomainDataBaseRepositoryMock) DeleteUser(contextControl domain.ContextControl, phoneID int64) error {
+	if c.DeleteUserMock != nil {
+		return c.DeleteUserMock(contextControl, phoneID)
+	}
+	return nil
+}
+
+func (c PhoneDomainDataBaseRepositoryMock) SetPrimaryUser(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) error {
+	if c.SetPrimaryUserMock != nil {
+		return c.SetPrimaryUserMock(contextControl, phoneUser)
+	}
+	return nil
+}
+
+func (c PhoneDomainDataBaseRepositoryMock) List(contextControl domain.ContextControl, filter domain.PhoneFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.PhoneDomain], error) {
+	if c.ListMock != nil {
+		return c.ListMock(contextControl, filter, page)
+	}
+	return domain.PageResultDomain[domain.PhoneDomain]{}, nil
+}
+
 func (c PhoneDomainCacheRepositoryMock) Delete(contextControl domain.ContextControl, key string) error {
 	if c.DeleteMock != nil {
 		return c.DeleteMock(contextControl, key)
@@ -0,0 +1,19 @@
+package output
+
+import (
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+type IScheduleDomainDataBaseRepository interface {
+	Save(contextControl domain.ContextControl, schedule domain.ScheduleDomain, history domain.ScheduleHistoryDomain) (domain.ScheduleDomain, error)
+	NextNumberSequence(contextControl domain.ContextControl) (int64, error)
+	GetByBooking(contextControl domain.ContextControl, petID, attentionTimeID int64, bookedAt time.Time) (domain.ScheduleDomain, bool, error)
+	GetBySlot(contextControl domain.ContextControl, attentionTimeID int64, bookedAt time.Time) (domain.ScheduleDomain, bool, error)
+	GetActiveByAttentionTimes(contextControl domain.ContextControl, attentionTimeIDs []int64, from, to time.Time) ([]domain.ScheduleDomain, error)
+	LockEmployee(contextControl domain.ContextControl, employeeID int64) error
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.ScheduleDomain, bool, error)
+	Transition(contextControl domain.ContextControl, schedule domain.ScheduleDomain, from domain.ScheduleStatus, history domain.ScheduleHistoryDomain) error
+	GetHistory(contextControl domain.ContextControl, scheduleID int64) ([]domain.ScheduleHistoryDomain, error)
+}
@@ -0,0 +1,82 @@
+package output
+
+import (
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+type ScheduleDomainDataBaseRepositoryMock struct {
+	SaveMock                      func(contextControl domain.ContextControl, schedule domain.ScheduleDomain, history domain.ScheduleHistoryDomain) (domain.ScheduleDomain, error)
+	NextNumberSequenceMock        func(contextControl domain.ContextControl) (int64, error)
+	GetByBookingMock              func(contextControl domain.ContextControl, petID, attentionTimeID int64, bookedAt time.Time) (domain.ScheduleDomain, bool, error)
+	GetBySlotMock                 func(contextControl domain.ContextControl, attentionTimeID int64, bookedAt time.Time) (domain.ScheduleDomain, bool, error)
+	GetActiveByAttentionTimesMock func(contextControl domain.ContextControl, attentionTimeIDs []int64, from, to time.Time) ([]domain.ScheduleDomain, error)
+	LockEmployeeMock              func(contextControl domain.ContextControl, employeeID int64) error
+	GetByIDMock                   func(contextControl domain.ContextControl, ID int64) (domain.ScheduleDomain, bool, error)
+	TransitionMock                func(contextControl domain.ContextControl, schedule domain.ScheduleDomain, from domain.ScheduleStatus, history domain.ScheduleHistoryDomain) error
+	GetHistoryMock                func(contextControl domain.ContextControl, scheduleID int64) ([]domain.ScheduleHistoryDomain, error)
+}
+
+func (c ScheduleDomainDataBaseRepositoryMock) Save(contextControl domain.ContextControl, schedule domain.ScheduleDomain, history domain.ScheduleHistoryDomain) (domain.ScheduleDomain, error) {
+	if c.SaveMock != nil {
+		return c.SaveMock(contextControl, schedule, history)
+	}
+	return domain.ScheduleDomain{}, nil
+}
+
+func (c ScheduleDomainDataBaseRepositoryMock) NextNumberSequence(contextControl domain.ContextControl) (int64, error) {
+	if c.NextNumberSequenceMock != nil {
+		return c.NextNumberSequenceMock(contextControl)
+	}
+	return 0, nil
+}
+
+func (c ScheduleDomainDataBaseRepositoryMock) GetByBooking(contextControl domain.ContextControl, petID, attentionTimeID int64, bookedAt time.Time) (domain.ScheduleDomain, bool, error) {
+	if c.GetByBookingMock != nil {
+		return c.GetByBookingMock(contextControl, petID, attentionTimeID, bookedAt)
+	}
+	return domain.ScheduleDomain{}, false, nil
+}
+
+func (c ScheduleDomainDataBaseRepositoryMock) GetBySlot(contextControl domain.ContextControl, attentionTimeID int64, bookedAt time.Time) (domain.ScheduleDomain, bool, error) {
+	if c.GetBySlotMock != nil {
+		return c.GetBySlotMock(contextControl, attentionTimeID, bookedAt)
+	}
+	return domain.ScheduleDomain{}, false, nil
+}
+
+func (c ScheduleDomainDataBaseRepositoryMock) GetActiveByAttentionTimes(contextControl domain.ContextControl, attentionTimeIDs []int64, from, to time.Time) ([]domain.ScheduleDomain, error) {
+	if c.GetActiveByAttentionTimesMock != nil {
+		return c.GetActiveByAttentionTimesMock(contextControl, attentionTimeIDs, from, to)
+	}
+	return nil, nil
+}
+
+func (c ScheduleDomainDataBaseRepositoryMock) LockEmployee(contextControl domain.ContextControl, employeeID int64) error {
+	if c.LockEmployeeMock != nil {
+		return c.LockEmployeeMock(contextControl, employeeID)
+	}
+	return nil
+}
+
+func (c ScheduleDomainDataBaseRepositoryMock) GetByID(contextControl domain.ContextControl, ID int64) (domain.ScheduleDomain, bool, error) {
+	if c.GetByIDMock != nil {
+		return c.GetByIDMock(contextControl, ID)
+	}
+	return domain.ScheduleDomain{}, false, nil
+}
+
+func (c ScheduleDomainDataBaseRepositoryMock) Transition(contextControl domain.ContextControl, schedule domain.ScheduleDomain, from domain.ScheduleStatus, history domain.ScheduleHistoryDomain) error {
+	if c.TransitionMock != nil {
+		return c.TransitionMock(contextControl, schedule, from, history)
+	}
+	return nil
+}
+
+func (c ScheduleDomainDataBaseRepositoryMock) GetHistory(contextControl domain.ContextControl, scheduleID int64) ([]domain.ScheduleHistoryDomain, error) {
+	if c.GetHistoryMock != nil {
+		return c.GetHistoryMock(contextControl, scheduleID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,13 @@
+package output
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type IServiceDomainDataBaseRepository interface {
+	Save(contextControl domain.ContextControl, service domain.ServiceDomain) (domain.ServiceDomain, error)
+	Update(contextControl domain.ContextControl, service domain.ServiceDomain) error
+	SetActive(contextControl domain.ContextControl, ID int64, active bool) error
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error)
+	GetAll(contextControl domain.ContextControl) ([]domain.ServiceDomain, error)
+	GetCurrentPrice(contextControl domain.ContextControl, serviceID int64) (domain.ServicePriceHistoryDomain, bool, error)
+	GetPriceHistory(contextControl domain.ContextControl, serviceID int64) ([]domain.ServicePriceHistoryDomain, error)
+}
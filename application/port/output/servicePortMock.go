@@ -0,0 +1,62 @@
+package output
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type ServiceDomainDataBaseRepositoryMock struct {
+	SaveMock            func(contextControl domain.ContextControl, service domain.ServiceDomain) (domain.ServiceDomain, error)
+	UpdateMock          func(contextControl domain.ContextControl, service domain.ServiceDomain) error
+	SetActiveMock       func(contextControl domain.ContextControl, ID int64, active bool) error
+	GetByIDMock         func(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error)
+	GetAllMock          func(contextControl domain.ContextControl) ([]domain.ServiceDomain, error)
+	GetCurrentPriceMock func(contextControl domain.ContextControl, serviceID int64) (domain.ServicePriceHistoryDomain, bool, error)
+	GetPriceHistoryMock func(contextControl domain.ContextControl, serviceID int64) ([]domain.ServicePriceHistoryDomain, error)
+}
+
+func (c ServiceDomainDataBaseRepositoryMock) Save(contextControl domain.ContextControl, service domain.ServiceDomain) (domain.ServiceDomain, error) {
+	if c.SaveMock != nil {
+		return c.SaveMock(contextControl, service)
+	}
+	return domain.ServiceDomain{}, nil
+}
+
+func (c ServiceDomainDataBaseRepositoryMock) Update(contextControl domain.ContextControl, service domain.ServiceDomain) error {
+	if c.UpdateMock != nil {
+		return c.UpdateMock(contextControl, service)
+	}
+	return nil
+}
+
+func (c ServiceDomainDataBaseRepositoryMock) SetActive(contextControl domain.ContextControl, ID int64, active bool) error {
+	if c.SetActiveMock != nil {
+		return c.SetActiveMock(contextControl, ID, active)
+	}
+	return nil
+}
+
+func (c ServiceDomainDataBaseRepositoryMock) GetByID(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error) {
+	if c.GetByIDMock != nil {
+		return c.GetByIDMock(contextControl, ID)
+	}
+	return domain.ServiceDomain{}, false, nil
+}
+
+func (c ServiceDomainDataBaseRepositoryMock) GetAll(contextControl domain.ContextControl) ([]domain.ServiceDomain, error) {
+	if c.GetAllMock != nil {
+		return c.GetAllMock(contextControl)
+	}
+	return nil, nil
+}
+
+func (c ServiceDomainDataBaseRepositoryMock) GetCurrentPrice(contextControl domain.ContextControl, serviceID int64) (domain.ServicePriceHistoryDomain, bool, error) {
+	if c.GetCurrentPriceMock != nil {
+		return c.GetCurrentPriceMock(contextControl, serviceID)
+	}
+	return domain.ServicePriceHistoryDomain{}, false, nil
+}
+
+func (c ServiceDomainDataBaseRepositoryMock) GetPriceHistory(contextControl domain.ContextControl, serviceID int64) ([]domain.ServicePriceHistoryDomain, error) {
+	if c.GetPriceHistoryMock != nil {
+		return c.GetPriceHistoryMock(contextControl, serviceID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,20 @@
+package output
+
+import (
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+type ISpeciesDomainDataBaseRepository interface {
+	Save(contextControl domain.ContextControl, species domain.SpeciesDomain) (domain.SpeciesDomain, error)
+	GetByID(contextControl domain.ContextControl, ID int64) (domain.SpeciesDomain, bool, error)
+	GetByName(contextControl domain.ContextControl, name string) (domain.SpeciesDomain, bool, error)
+	GetAll(contextControl domain.ContextControl) ([]domain.SpeciesDomain, error)
+}
+
+type ICatalogDomainCacheRepository interface {
+	Set(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error
+	Get(contextControl domain.ContextControl, key string) (string, error)
+	Delete(contextControl domain.ContextControl, key string) error
+}
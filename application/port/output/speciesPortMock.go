@@ -0,0 +1,69 @@
+package output
+
+import (
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+type SpeciesDomainDataBaseRepositoryMock struct {
+	SaveMock      func(contextControl domain.ContextControl, species domain.SpeciesDomain) (domain.SpeciesDomain, error)
+	GetByIDMock   func(contextControl domain.ContextControl, ID int64) (domain.SpeciesDomain, bool, error)
+	GetByNameMock func(contextControl domain.ContextControl, name string) (domain.SpeciesDomain, bool, error)
+	GetAllMock    func(contextControl domain.ContextControl) ([]domain.SpeciesDomain, error)
+}
+
+type CatalogDomainCacheRepositoryMock struct {
+	SetMock    func(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error
+	GetMock    func(contextControl domain.ContextControl, key string) (string, error)
+	DeleteMock func(contextControl domain.ContextControl, key string) error
+}
+
+func (c SpeciesDomainDataBaseRepositoryMock) Save(contextControl domain.ContextControl, species domain.SpeciesDomain) (domain.SpeciesDomain, error) {
+	if c.SaveMock != nil {
+		return c.SaveMock(contextControl, species)
+	}
+	return domain.SpeciesDomain{}, nil
+}
+
+func (c SpeciesDomainDataBaseRepositoryMock) GetByID(contextControl domain.ContextControl, ID int64) (domain.SpeciesDomain, bool, error) {
+	if c.GetByIDMock != nil {
+		return c.GetByIDMock(contextControl, ID)
+	}
+	return domain.SpeciesDomain{}, false, nil
+}
+
+func (c SpeciesDomainDataBaseRepositoryMock) GetByName(contextControl domain.ContextControl, name string) (domain.SpeciesDomain, bool, error) {
+	if c.GetByNameMock != nil {
+		return c.GetByNameMock(contextControl, name)
+	}
+	return domain.SpeciesDomain{}, false, nil
+}
+
+func (c SpeciesDomainDataBaseRepositoryMock) GetAll(contextControl domain.ContextControl) ([]domain.SpeciesDomain, error) {
+	if c.GetAllMock != nil {
+		return c.GetAllMock(contextControl)
+	}
+	return nil, nil
+}
+
+func (c CatalogDomainCacheRepositoryMock) Delete(contextControl domain.ContextControl, key string) error {
+	if c.DeleteMock != nil {
+		return c.DeleteMock(contextControl, key)
+	}
+	return nil
+}
+
+func (c CatalogDomainCacheRepositoryMock) Get(contextControl domain.ContextControl, key string) (string, error) {
+	if c.GetMock != nil {
+		return c.GetMock(contextControl, key)
+	}
+	return "", nil
+}
+
+func (c CatalogDomainCacheRepositoryMock) Set(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error {
+	if c.SetMock != nil {
+		return c.SetMock(contextControl, key, hash, expirationTime)
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package output
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+// ITransactionManager runs fn in a transaction: the repository calls made with the contextControl
+// given to fn are committed together when fn returns nil and rolled back otherwise.
+type ITransactionManager interface {
+	WithinTransaction(contextControl domain.ContextControl, fn func(contextControl domain.ContextControl) error) error
+}
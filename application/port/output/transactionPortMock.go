@@ -0,0 +1,79 @@
+package output
+
+import (
+	"context"
+	"sync"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+type transactionMockKey struct{}
+
+// TransactionManagerMock runs the functions without any database and counts how their
+// transactions ended. Repository mocks tell whether they were called inside one of its
+// transactions through InTransaction.
+type TransactionManagerMock struct {
+	WithinTransactionMock func(contextControl domain.ContextControl, fn func(contextControl domain.ContextControl) error) error
+	// Err, when set, fails the commit: WithinTransaction returns it and counts a rollback.
+	Err error
+
+	mutex      sync.Mutex
+	committed  int
+	rolledBack int
+}
+
+// WithinTransaction runs fn in a transaction, or in the one of the contextControl when there is
+// one, whose outcome is only counted when the outermost transaction ends.
+func (c *TransactionManagerMock) WithinTransaction(contextControl domain.ContextControl, fn func(contextControl domain.ContextControl) error) error {
+	if c.WithinTransactionMock != nil {
+		return c.WithinTransactionMock(contextControl, fn)
+	}
+
+	if InTransaction(contextControl) {
+		return fn(contextControl)
+	}
+
+	txControl := contextControl
+	txControl.Context = context.WithValue(contextControl.Context, transactionMockKey{}, c)
+
+	err := fn(txControl)
+	if err == nil {
+		err = c.Err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err != nil {
+		c.rolledBack++
+		return err
+	}
+
+	c.committed++
+	return nil
+}
+
+// Committed returns how many transactions were committed.
+func (c *TransactionManagerMock) Committed() int {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.committed
+}
+
+// RolledBack returns how many transactions were rolled back.
+func (c *TransactionManagerMock) RolledBack() int {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.rolledBack
+}
+
+// InTransaction tells whether the contextControl runs inside a transaction of a
+// TransactionManagerMock.
+func InTransaction(contextControl domain.ContextControl) bool {
+	_, ok := contextControl.Context.Value(transactionMockKey{}).(*TransactionManagerMock)
+	return ok
+}
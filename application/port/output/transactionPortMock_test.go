@@ -0,0 +1,56 @@
+package output
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionManagerMock_WithinTransaction(t *testing.T) {
+
+	contextControl := domain.ContextControl{Context: context.Background()}
+
+	tests := []struct {
+		Name               string
+		CommitErr          error
+		FnErr              error
+		ExpectedErr        error
+		ExpectedCommitted  int
+		ExpectedRolledBack int
+	}{
+		{Name: "WithoutError_Commits", ExpectedCommitted: 1},
+		{Name: "WithFnError_RollsBack", FnErr: errors.New("address not saved"),
+			ExpectedErr: errors.New("address not saved"), ExpectedRolledBack: 1},
+		{Name: "WithCommitError_RollsBack", CommitErr: errors.New("connection lost"),
+			ExpectedErr: errors.New("connection lost"), ExpectedRolledBack: 1},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			transactionManager := &TransactionManagerMock{Err: test.CommitErr}
+
+			var joined, nestedJoined bool
+			err := transactionManager.WithinTransaction(contextControl, func(txControl domain.ContextControl) error {
+				joined = InTransaction(txControl)
+				_ = transactionManager.WithinTransaction(txControl, func(nestedControl domain.ContextControl) error {
+					nestedJoined = InTransaction(nestedControl)
+					return nil
+				})
+				return test.FnErr
+			})
+
+			assert.Equal(t, test.ExpectedErr, err)
+			assert.True(t, joined)
+			assert.True(t, nestedJoined)
+			assert.False(t, InTransaction(contextControl))
+			// the nested call joins the outer transaction, only the outer one is counted
+			assert.Equal(t, test.ExpectedCommitted, transactionManager.Committed())
+			assert.Equal(t, test.ExpectedRolledBack, transactionManager.RolledBack())
+		})
+	}
+}
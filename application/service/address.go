@@ -4,12 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/petshop-system/petshop-api/application/domain"
 	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/petshop-system/petshop-api/application/utils"
 	"go.uber.org/zap"
 )
 
@@ -21,10 +21,6 @@ type AddressService struct {
 
 var AddressCacheTTL = 10 * time.Minute
 
-const (
-	AddressCacheKeyTypeID = "ID"
-)
-
 const (
 	AddressErrorToSaveInCache    = "error to save an address in cache"
 	AddressErrorToGetByIDInCache = "error to getting an address in cache"
@@ -40,10 +36,6 @@ const (
 	CountryIsRequired      = "country is required"
 )
 
-func (service AddressService) getCacheKey(cacheKeyType string, value string) string {
-	return fmt.Sprintf("%s.%s", cacheKeyType, value)
-}
-
 func (service AddressService) Create(contextControl domain.ContextControl, address domain.AddressDomain) (domain.AddressDomain, error) {
 
 	if err := service.ValidateAddress(address); err != nil {
@@ -61,7 +53,7 @@ func (service AddressService) Create(contextControl domain.ContextControl, addre
 	}
 
 	if err = service.AddressDomainCacheRepository.Set(contextControl,
-		service.getCacheKey(AddressCacheKeyTypeID, strconv.FormatInt(save.ID, 10)),
+		AddressCacheKey.BuildScopedID(contextControl, save.ID),
 		string(hash), AddressCacheTTL); err != nil {
 		service.LoggerSugar.Infow(AddressErrorToSaveInCache, "address_id", save.ID, "error", err)
 	}
@@ -70,12 +62,34 @@ func (service AddressService) Create(contextControl domain.ContextControl, addre
 }
 
 func (service AddressService) GetByID(contextControl domain.ContextControl, ID int64) (domain.AddressDomain, bool, error) {
+
+	counter := getCacheCounter(AddressCacheStatsName)
+	cacheKey := AddressCacheKey.BuildScopedID(contextControl, ID)
+	if hash, err := service.AddressDomainCacheRepository.Get(contextControl, cacheKey); err == nil && len(hash) > 0 {
+		if hash == CacheNotFoundValue {
+			counter.Hit()
+			return domain.AddressDomain{}, false, nil
+		}
+
+		var address domain.AddressDomain
+		if err = json.Unmarshal([]byte(hash), &address); err == nil {
+			counter.Hit()
+			return address, true, nil
+		}
+		service.LoggerSugar.Warnw(AddressErrorToGetByIDInCache, "address_id", ID, "error", err)
+	}
+	counter.Miss()
+
 	address, exists, err := service.AddressDomainDataBaseRepository.GetByID(contextControl, ID)
 	if err != nil {
 		return domain.AddressDomain{}, exists, err
 	}
 
 	if !exists {
+		if err = service.AddressDomainCacheRepository.Set(contextControl, cacheKey,
+			CacheNotFoundValue, NotFoundCacheTTL); err != nil {
+			service.LoggerSugar.Infow(AddressErrorToSaveInCache, "address_id", ID, "error", err)
+		}
 		return domain.AddressDomain{}, exists, nil
 	}
 
@@ -84,10 +98,9 @@ func (service AddressService) GetByID(contextControl domain.ContextControl, ID i
 		service.LoggerSugar.Warnw("failed to marshal address for cache", "address_id", address.ID, "error", err)
 	}
 
-	if err = service.AddressDomainCacheRepository.Set(contextControl,
-		service.getCacheKey(AddressCacheKeyTypeID, strconv.FormatInt(address.ID, 10)),
+	if err = service.AddressDomainCacheRepository.Set(contextControl, cacheKey,
 		string(hash), AddressCacheTTL); err != nil {
-		service.LoggerSugar.Infow(AddressErrorToGetByIDInCache, "address_id", address.ID, "error", err)
+		service.LoggerSugar.Infow(AddressErrorToSaveInCache, "address_id", address.ID, "error", err)
 	}
 
 	return address, exists, nil
@@ -134,3 +147,18 @@ func (service AddressService) ValidateAddress(address domain.AddressDomain) erro
 	}
 	return fmt.Errorf("error to validate address: %s", strings.Join(errorMessages, ", "))
 }
+
+// List returns a page of the addresses matching the filter. The zip code matches with or without
+// its dash and the city regardless of case.
+func (service AddressService) List(contextControl domain.ContextControl, filter domain.AddressFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.AddressDomain], error) {
+
+	page, err := ValidatePage(page)
+	if err != nil {
+		return domain.PageResultDomain[domain.AddressDomain]{}, err
+	}
+
+	filter.ZipCode = utils.RemoveNonAlphaNumericCharacters(filter.ZipCode)
+	filter.City = strings.ToLower(strings.TrimSpace(filter.City))
+
+	return service.AddressDomainDataBaseRepository.List(contextControl, filter, page)
+}
@@ -7,6 +7,7 @@ import "github.com/petshop-system/petshop-api/application/domain"
 type AddressMock struct {
 	CreateMock  func(contextControl domain.ContextControl, address domain.AddressDomain) (domain.AddressDomain, error)
 	GetByIDMock func(contextControl domain.ContextControl, ID int64) (domain.AddressDomain, bool, error)
+	ListMock    func(contextControl domain.ContextControl, filter domain.AddressFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.AddressDomain], error)
 }
 
 func (c AddressMock) Create(contextControl domain.ContextControl, address domain.AddressDomain) (domain.AddressDomain, error) {
@@ -22,3 +23,10 @@ func (c AddressMock) GetByID(contextControl domain.ContextControl, ID int64) (do
 	}
 	return domain.AddressDomain{}, false, nil
 }
+
+func (c AddressMock) List(contextControl domain.ContextControl, filter domain.AddressFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.AddressDomain], error) {
+	if c.ListMock != nil {
+		return c.ListMock(contextControl, filter, page)
+	}
+	return domain.PageResultDomain[domain.AddressDomain]{}, nil
+}
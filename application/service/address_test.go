@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -260,6 +261,61 @@ func TestAddressService_GetById(t *testing.T) {
 			ExpectedExists: true,
 			ExpectedError:  nil,
 		},
+		{
+			Name: "WithAddressInCache_DoesNotQueryTheDatabase",
+			AddressDomainDataBaseRepository: output.AddressDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.AddressDomain, bool, error) {
+					return domain.AddressDomain{}, false, errors.New(database.AddressNotFound)
+				},
+			},
+			AddressDomainCacheRepository: output.AddressDomainCacheRepositoryMock{
+				GetMock: func(contextControl domain.ContextControl, key string) (string, error) {
+					address := utils.GetMockAddress()
+					address.ID = 1
+					hash, _ := json.Marshal(address)
+					return string(hash), nil
+				},
+			},
+			ExpectedResult: func() domain.AddressDomain {
+				address := utils.GetMockAddress()
+				address.ID = 1
+				return address
+			}(),
+			ExpectedExists: true,
+			ExpectedError:  nil,
+		},
+		{
+			Name: "WithNotFoundInCache_DoesNotQueryTheDatabase",
+			AddressDomainDataBaseRepository: output.AddressDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.AddressDomain, bool, error) {
+					return domain.AddressDomain{}, false, errors.New(database.AddressNotFound)
+				},
+			},
+			AddressDomainCacheRepository: output.AddressDomainCacheRepositoryMock{
+				GetMock: func(contextControl domain.ContextControl, key string) (string, error) {
+					return CacheNotFoundValue, nil
+				},
+			},
+			ExpectedResult: domain.AddressDomain{},
+			ExpectedExists: false,
+			ExpectedError:  nil,
+		},
+		{
+			Name: "WithUndecodableCacheValue_FallsBackToTheDatabase",
+			AddressDomainDataBaseRepository: output.AddressDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.AddressDomain, bool, error) {
+					return utils.GetMockAddress(), true, nil
+				},
+			},
+			AddressDomainCacheRepository: output.AddressDomainCacheRepositoryMock{
+				GetMock: func(contextControl domain.ContextControl, key string) (string, error) {
+					return "{", nil
+				},
+			},
+			ExpectedResult: utils.GetMockAddress(),
+			ExpectedExists: true,
+			ExpectedError:  nil,
+		},
 	}
 
 	for _, test := range tests {
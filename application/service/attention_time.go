@@ -0,0 +1,202 @@
+package service
+
+import (
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"go.uber.org/zap"
+)
+
+type AttentionTimeService struct {
+	LoggerSugar                           *zap.SugaredLogger
+	AttentionTimeDomainDataBaseRepository output.IAttentionTimeDomainDataBaseRepository
+	EmployeeDomainDataBaseRepository      output.IEmployeeDomainDataBaseRepository
+	ServiceDomainDataBaseRepository       output.IServiceDomainDataBaseRepository
+}
+
+// AttentionTimeLayout is the layout of the initial and final times of an attention time.
+const AttentionTimeLayout = "15:04"
+
+const (
+	AttentionTimeInvalidTime                = "the time %q must follow the layout HH:MM"
+	AttentionTimeFinalBeforeInitial         = "the final time %s must be after the initial time %s"
+	AttentionTimeEmployeeNotFound           = "the employee with id %d wasn't found"
+	AttentionTimeEmployeeInactive           = "the employee with id %d isn't active"
+	AttentionTimeServiceNotFound            = "the service with id %d wasn't found"
+	AttentionTimeServiceInactive            = "the service with id %d isn't active"
+	AttentionTimeServiceFromAnotherContract = "the service %d doesn't belong to the contract of the employee %d"
+	AttentionTimeOverlaps                   = "the employee %d already attends the service %d from %s to %s"
+)
+
+// Create validates the slot and stores it, active, in the contract of its employee.
+func (service *AttentionTimeService) Create(contextControl domain.ContextControl, attentionTime domain.AttentionTimeDomain) (domain.AttentionTimeDomain, error) {
+
+	initialTime, finalTime, err := service.ValidateTimes(attentionTime)
+	if err != nil {
+		return domain.AttentionTimeDomain{}, err
+	}
+	attentionTime.InitialTime = initialTime.Format(AttentionTimeLayout)
+	attentionTime.FinalTime = finalTime.Format(AttentionTimeLayout)
+
+	employee, err := service.activeEmployee(contextControl, attentionTime.EmployeeID)
+	if err != nil {
+		return domain.AttentionTimeDomain{}, err
+	}
+
+	petshopService, exists, err := service.ServiceDomainDataBaseRepository.GetByID(contextControl, attentionTime.ServiceID)
+	if err != nil {
+		return domain.AttentionTimeDomain{}, err
+	}
+	if !exists {
+		return domain.AttentionTimeDomain{}, domain.NewValidationError(AttentionTimeServiceNotFound, attentionTime.ServiceID)
+	}
+	if !petshopService.Active {
+		return domain.AttentionTimeDomain{}, domain.NewValidationError(AttentionTimeServiceInactive, attentionTime.ServiceID)
+	}
+	if petshopService.ContractID != employee.ContractID {
+		return domain.AttentionTimeDomain{}, domain.NewValidationError(AttentionTimeServiceFromAnotherContract,
+			attentionTime.ServiceID, attentionTime.EmployeeID)
+	}
+
+	attentionTime.ContractID = employee.ContractID
+	attentionTime.Active = true
+	if err = service.checkOverlap(contextControl, attentionTime); err != nil {
+		return domain.AttentionTimeDomain{}, err
+	}
+
+	return service.AttentionTimeDomainDataBaseRepository.Save(contextControl, attentionTime)
+}
+
+// SetActive activates or deactivates a known slot. A slot is only activated when its
+// employee is active and it doesn't overlap another active slot.
+func (service *AttentionTimeService) SetActive(contextControl domain.ContextControl, ID int64, active bool) (domain.AttentionTimeDomain, bool, error) {
+
+	attentionTime, exists, err := service.AttentionTimeDomainDataBaseRepository.GetByID(contextControl, ID)
+	if err != nil || !exists {
+		return domain.AttentionTimeDomain{}, false, err
+	}
+
+	if attentionTime.Active == active {
+		return attentionTime, true, nil
+	}
+
+	if active {
+		if _, err = service.activeEmployee(contextControl, attentionTime.EmployeeID); err != nil {
+			return domain.AttentionTimeDomain{}, true, err
+		}
+		if err = service.checkOverlap(contextControl, attentionTime); err != nil {
+			return domain.AttentionTimeDomain{}, true, err
+		}
+	}
+
+	if err = service.AttentionTimeDomainDataBaseRepository.SetActive(contextControl, ID, active); err != nil {
+		return domain.AttentionTimeDomain{}, true, err
+	}
+
+	attentionTime.Active = active
+	return attentionTime, true, nil
+}
+
+func (service *AttentionTimeService) GetByID(contextControl domain.ContextControl, ID int64) (domain.AttentionTimeDomain, bool, error) {
+	return service.AttentionTimeDomainDataBaseRepository.GetByID(contextControl, ID)
+}
+
+// GetByEmployeeID lists the slots of a known employee, active or not, ordered by the initial time.
+func (service *AttentionTimeService) GetByEmployeeID(contextControl domain.ContextControl, employeeID int64) ([]domain.AttentionTimeDomain, bool, error) {
+
+	_, exists, err := service.EmployeeDomainDataBaseRepository.GetByID(contextControl, employeeID)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+
+	attentionTimes, err := service.AttentionTimeDomainDataBaseRepository.GetByEmployeeID(contextControl, employeeID)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return attentionTimes, true, nil
+}
+
+// ValidateTimes parses the initial and final times, accepting a single digit hour such as 9:00,
+// and checks the slot ends after it starts.
+func (service *AttentionTimeService) ValidateTimes(attentionTime domain.AttentionTimeDomain) (time.Time, time.Time, error) {
+
+	initialTime, err := parseAttentionTime(attentionTime.InitialTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	finalTime, err := parseAttentionTime(attentionTime.FinalTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if !finalTime.After(initialTime) {
+		return time.Time{}, time.Time{}, domain.NewValidationError(AttentionTimeFinalBeforeInitial,
+			attentionTime.FinalTime, attentionTime.InitialTime)
+	}
+
+	return initialTime, finalTime, nil
+}
+
+func (service *AttentionTimeService) activeEmployee(contextControl domain.ContextControl, employeeID int64) (domain.EmployeeDomain, error) {
+
+	employee, exists, err := service.EmployeeDomainDataBaseRepository.GetByID(contextControl, employeeID)
+	if err != nil {
+		return domain.EmployeeDomain{}, err
+	}
+	if !exists {
+		return domain.EmployeeDomain{}, domain.NewValidationError(AttentionTimeEmployeeNotFound, employeeID)
+	}
+	if !employee.Active {
+		return domain.EmployeeDomain{}, domain.NewValidationError(AttentionTimeEmployeeInactive, employeeID)
+	}
+
+	return employee, nil
+}
+
+// checkOverlap reports a conflict when the employee already has an active slot of the same
+// service that overlaps the given one. Slots of different services may share the same time,
+// the employee attends whichever is booked.
+func (service *AttentionTimeService) checkOverlap(contextControl domain.ContextControl, attentionTime domain.AttentionTimeDomain) error {
+
+	initialTime, finalTime, err := service.ValidateTimes(attentionTime)
+	if err != nil {
+		return err
+	}
+
+	attentionTimes, err := service.AttentionTimeDomainDataBaseRepository.GetByEmployeeID(contextControl, attentionTime.EmployeeID)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range attentionTimes {
+		if other.ID == attentionTime.ID || !other.Active || other.ServiceID != attentionTime.ServiceID {
+			continue
+		}
+
+		otherInitial, otherFinal, err := service.ValidateTimes(other)
+		if err != nil {
+			// slots stored before final_time existed can't be compared
+			continue
+		}
+
+		if initialTime.Before(otherFinal) && otherInitial.Before(finalTime) {
+			return domain.NewConflictError(AttentionTimeOverlaps, attentionTime.EmployeeID, attentionTime.ServiceID,
+				other.InitialTime, other.FinalTime)
+		}
+	}
+
+	return nil
+}
+
+func parseAttentionTime(value string) (time.Time, error) {
+
+	parsed, err := time.Parse(AttentionTimeLayout, value)
+	if err != nil {
+		return time.Time{}, domain.NewValidationError(AttentionTimeInvalidTime, value)
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttentionTimeService_Create(t *testing.T) {
+
+	employeeActive := output.EmployeeDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+			return domain.EmployeeDomain{ID: ID, Active: true, ContractID: 1}, true, nil
+		},
+	}
+
+	serviceActive := output.ServiceDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error) {
+			return domain.ServiceDomain{ID: ID, Active: true, ContractID: 1}, true, nil
+		},
+	}
+
+	morningSlots := output.AttentionTimeDomainDataBaseRepositoryMock{
+		GetByEmployeeIDMock: func(contextControl domain.ContextControl, employeeID int64) ([]domain.AttentionTimeDomain, error) {
+			return []domain.AttentionTimeDomain{
+				{ID: 1, InitialTime: "09:00", FinalTime: "10:00", Active: true, ServiceID: 2, EmployeeID: employeeID},
+				{ID: 2, InitialTime: "10:00", FinalTime: "11:00", Active: false, ServiceID: 2, EmployeeID: employeeID},
+				{ID: 3, InitialTime: "11:00", FinalTime: "12:00", Active: true, ServiceID: 3, EmployeeID: employeeID},
+			}, nil
+		},
+		SaveMock: func(contextControl domain.ContextControl, attentionTime domain.AttentionTimeDomain) (domain.AttentionTimeDomain, error) {
+			attentionTime.ID = 10
+			return attentionTime, nil
+		},
+	}
+
+	tests := []struct {
+		Name                             string
+		AttentionTime                    domain.AttentionTimeDomain
+		EmployeeDomainDataBaseRepository output.IEmployeeDomainDataBaseRepository
+		ServiceDomainDataBaseRepository  output.IServiceDomainDataBaseRepository
+		ExpectedResult                   domain.AttentionTimeDomain
+		ExpectedError                    error
+	}{
+		{
+			Name:                             "WithFreeSlot_SavesItActiveAndNormalized",
+			AttentionTime:                    domain.AttentionTimeDomain{InitialTime: "8:00", FinalTime: "9:00", ServiceID: 2, EmployeeID: 1},
+			EmployeeDomainDataBaseRepository: employeeActive,
+			ServiceDomainDataBaseRepository:  serviceActive,
+			ExpectedResult: domain.AttentionTimeDomain{ID: 10, InitialTime: "08:00", FinalTime: "09:00", Active: true,
+				ServiceID: 2, ContractID: 1, EmployeeID: 1},
+		},
+		{
+			Name:                             "WithSlotOverlappingAnInactiveOne_SavesIt",
+			AttentionTime:                    domain.AttentionTimeDomain{InitialTime: "10:30", FinalTime: "11:00", ServiceID: 2, EmployeeID: 1},
+			EmployeeDomainDataBaseRepository: employeeActive,
+			ServiceDomainDataBaseRepository:  serviceActive,
+			ExpectedResult: domain.AttentionTimeDomain{ID: 10, InitialTime: "10:30", FinalTime: "11:00", Active: true,
+				ServiceID: 2, ContractID: 1, EmployeeID: 1},
+		},
+		{
+			Name:                             "WithSlotOverlappingAnActiveOne_ReturnsConflict",
+			AttentionTime:                    domain.AttentionTimeDomain{InitialTime: "09:30", FinalTime: "10:30", ServiceID: 2, EmployeeID: 1},
+			EmployeeDomainDataBaseRepository: employeeActive,
+			ServiceDomainDataBaseRepository:  serviceActive,
+			ExpectedError:                    domain.ErrConflict,
+		},
+		{
+			Name:                             "WithFinalTimeBeforeInitialTime_ReturnsValidationError",
+			AttentionTime:                    domain.AttentionTimeDomain{InitialTime: "14:00", FinalTime: "13:00", ServiceID: 2, EmployeeID: 1},
+			EmployeeDomainDataBaseRepository: employeeActive,
+			ServiceDomainDataBaseRepository:  serviceActive,
+			ExpectedError:                    domain.ErrValidation,
+		},
+		{
+			Name:                             "WithInvalidTime_ReturnsValidationError",
+			AttentionTime:                    domain.AttentionTimeDomain{InitialTime: "25:00", FinalTime: "26:00", ServiceID: 2, EmployeeID: 1},
+			EmployeeDomainDataBaseRepository: employeeActive,
+			ServiceDomainDataBaseRepository:  serviceActive,
+			ExpectedError:                    domain.ErrValidation,
+		},
+		{
+			Name:          "WithInactiveEmployee_ReturnsValidationError",
+			AttentionTime: domain.AttentionTimeDomain{InitialTime: "8:00", FinalTime: "9:00", ServiceID: 2, EmployeeID: 1},
+			EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+					return domain.EmployeeDomain{ID: ID, Active: false, ContractID: 1}, true, nil
+				},
+			},
+			ServiceDomainDataBaseRepository: serviceActive,
+			ExpectedError:                   domain.ErrValidation,
+		},
+		{
+			Name:                             "WithServiceOfAnotherContract_ReturnsValidationError",
+			AttentionTime:                    domain.AttentionTimeDomain{InitialTime: "8:00", FinalTime: "9:00", ServiceID: 2, EmployeeID: 1},
+			EmployeeDomainDataBaseRepository: employeeActive,
+			ServiceDomainDataBaseRepository: output.ServiceDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error) {
+					return domain.ServiceDomain{ID: ID, Active: true, ContractID: 2}, true, nil
+				},
+			},
+			ExpectedError: domain.ErrValidation,
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			attentionTimeService := AttentionTimeService{
+				LoggerSugar:                           loggerSugar,
+				AttentionTimeDomainDataBaseRepository: morningSlots,
+				EmployeeDomainDataBaseRepository:      test.EmployeeDomainDataBaseRepository,
+				ServiceDomainDataBaseRepository:       test.ServiceDomainDataBaseRepository,
+			}
+
+			attentionTime, err := attentionTimeService.Create(domain.ContextControl{Context: context.Background()}, test.AttentionTime)
+			assert.Equal(t, test.ExpectedResult, attentionTime)
+			if test.ExpectedError == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.ExpectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"go.uber.org/zap"
+)
+
+type AvailabilityService struct {
+	LoggerSugar                           *zap.SugaredLogger
+	ServiceDomainDataBaseRepository       output.IServiceDomainDataBaseRepository
+	EmployeeDomainDataBaseRepository      output.IEmployeeDomainDataBaseRepository
+	AttentionTimeDomainDataBaseRepository output.IAttentionTimeDomainDataBaseRepository
+	ScheduleDomainDataBaseRepository      output.IScheduleDomainDataBaseRepository
+}
+
+// AvailabilityMaxDays bounds the dates searched by a single availability request.
+const AvailabilityMaxDays = 31
+
+const (
+	AvailabilityPeriodIsInvalid = "the date to %s must not be before the date from %s"
+	AvailabilityPeriodTooLong   = "the period can't be longer than %d days"
+)
+
+// AvailabilityInput is everything ComputeAvailability needs, loaded beforehand so the
+// computation doesn't depend on any repository.
+type AvailabilityInput struct {
+	Service domain.ServiceDomain
+	// From and To are the dates searched, both included; dates before Today are skipped.
+	From, To, Today time.Time
+	Employees       map[int64]domain.EmployeeDomain
+	// AttentionTimes holds every attention time of the employees, of any service, as a booking
+	// of another service keeps the employee busy too.
+	AttentionTimes   []domain.AttentionTimeDomain
+	ServiceDurations map[int64]int
+	// Schedules holds the bookings not declined of the attention times in the period.
+	Schedules []domain.ScheduleDomain
+}
+
+// GetAvailability lists, per employee, the free attention times of the service from the date from
+// to the date to. It returns false when the service isn't found.
+func (service *AvailabilityService) GetAvailability(contextControl domain.ContextControl, serviceID int64, from, to time.Time) ([]domain.EmployeeAvailabilityDomain, bool, error) {
+
+	if to.Before(from) {
+		return nil, true, domain.NewValidationError(AvailabilityPeriodIsInvalid,
+			to.Format(ScheduleBookingLayout), from.Format(ScheduleBookingLayout))
+	}
+	if to.Sub(from) >= AvailabilityMaxDays*24*time.Hour {
+		return nil, true, domain.NewValidationError(AvailabilityPeriodTooLong, AvailabilityMaxDays)
+	}
+
+	petshopService, exists, err := service.ServiceDomainDataBaseRepository.GetByID(contextControl, serviceID)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+
+	input := AvailabilityInput{
+		Service:          petshopService,
+		From:             from,
+		To:               to,
+		Today:            today(),
+		Employees:        make(map[int64]domain.EmployeeDomain),
+		ServiceDurations: map[int64]int{petshopService.ID: petshopService.DurationMinutes},
+	}
+
+	serviceAttentionTimes, err := service.AttentionTimeDomainDataBaseRepository.GetByServiceID(contextControl, serviceID)
+	if err != nil {
+		return nil, true, err
+	}
+
+	for _, attentionTime := range serviceAttentionTimes {
+
+		if _, loaded := input.Employees[attentionTime.EmployeeID]; loaded {
+			continue
+		}
+
+		employee, exists, err := service.EmployeeDomainDataBaseRepository.GetByID(contextControl, attentionTime.EmployeeID)
+		if err != nil {
+			return nil, true, err
+		}
+		if !exists {
+			continue
+		}
+		input.Employees[employee.ID] = employee
+
+		employeeAttentionTimes, err := service.AttentionTimeDomainDataBaseRepository.GetByEmployeeID(contextControl, employee.ID)
+		if err != nil {
+			return nil, true, err
+		}
+		input.AttentionTimes = append(input.AttentionTimes, employeeAttentionTimes...)
+	}
+
+	attentionTimeIDs := make([]int64, 0, len(input.AttentionTimes))
+	for _, attentionTime := range input.AttentionTimes {
+
+		attentionTimeIDs = append(attentionTimeIDs, attentionTime.ID)
+		if _, loaded := input.ServiceDurations[attentionTime.ServiceID]; loaded {
+			continue
+		}
+
+		other, exists, err := service.ServiceDomainDataBaseRepository.GetByID(contextControl, attentionTime.ServiceID)
+		if err != nil {
+			return nil, true, err
+		}
+		if exists {
+			input.ServiceDurations[other.ID] = other.DurationMinutes
+		}
+	}
+
+	input.Schedules, err = service.ScheduleDomainDataBaseRepository.GetActiveByAttentionTimes(contextControl,
+		attentionTimeIDs, from, to)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return ComputeAvailability(input), true, nil
+}
+
+type busyInterval struct {
+	start, end time.Duration
+}
+
+// ComputeAvailability lists, per employee ordered by id, the attention times of the service that
+// are free on each date of the period, ordered by date and initial time. An attention time is
+// free on a date when:
+//   - the service, the employee and the attention time are active;
+//   - the attention time is long enough for the service duration;
+//   - no booking of that date, of any attention time of the employee, overlaps the time from
+//     its initial time to the end of the service.
+func ComputeAvailability(input AvailabilityInput) []domain.EmployeeAvailabilityDomain {
+
+	if !input.Service.Active || input.Service.DurationMinutes <= 0 {
+		return []domain.EmployeeAvailabilityDomain{}
+	}
+	duration := time.Duration(input.Service.DurationMinutes) * time.Minute
+
+	busy := busyByEmployee(input.AttentionTimes, input.ServiceDurations, input.Schedules)
+
+	// attention times of the service that can hold it, per employee
+	type candidate struct {
+		attentionTime domain.AttentionTimeDomain
+		start, end    time.Duration
+	}
+	candidates := make(map[int64][]candidate)
+	for _, attentionTime := range input.AttentionTimes {
+
+		if attentionTime.ServiceID != input.Service.ID || !attentionTime.Active {
+			continue
+		}
+
+		employee, known := input.Employees[attentionTime.EmployeeID]
+		if !known || !employee.Active {
+			continue
+		}
+
+		initialTime, finalTime, err := (&AttentionTimeService{}).ValidateTimes(attentionTime)
+		if err != nil {
+			continue
+		}
+
+		start := sinceMidnight(initialTime)
+		if start+duration > sinceMidnight(finalTime) {
+			continue
+		}
+
+		candidates[employee.ID] = append(candidates[employee.ID], candidate{
+			attentionTime: attentionTime, start: start, end: start + duration,
+		})
+	}
+
+	employeeIDs := make([]int64, 0, len(candidates))
+	for employeeID := range candidates {
+		employeeIDs = append(employeeIDs, employeeID)
+		sort.Slice(candidates[employeeID], func(i, j int) bool {
+			a, b := candidates[employeeID][i], candidates[employeeID][j]
+			if a.start != b.start {
+				return a.start < b.start
+			}
+			return a.attentionTime.ID < b.attentionTime.ID
+		})
+	}
+	sort.Slice(employeeIDs, func(i, j int) bool { return employeeIDs[i] < employeeIDs[j] })
+
+	from := input.From
+	if from.Before(input.Today) {
+		from = input.Today
+	}
+
+	availability := make([]domain.EmployeeAvailabilityDomain, 0, len(employeeIDs))
+	for _, employeeID := range employeeIDs {
+
+		employeeAvailability := domain.EmployeeAvailabilityDomain{
+			EmployeeID:   employeeID,
+			EmployeeName: input.Employees[employeeID].Name,
+			Slots:        []domain.AvailableSlotDomain{},
+		}
+
+		for date := from; !date.After(input.To); date = date.AddDate(0, 0, 1) {
+
+			busyOnDate := busy[employeeID][date.Format(ScheduleBookingLayout)]
+			for _, c := range candidates[employeeID] {
+
+				if overlapsAny(c.start, c.end, busyOnDate) {
+					continue
+				}
+
+				employeeAvailability.Slots = append(employeeAvailability.Slots, domain.AvailableSlotDomain{
+					Date:            date,
+					AttentionTimeID: c.attentionTime.ID,
+					InitialTime:     formatSinceMidnight(c.start),
+					FinalTime:       formatSinceMidnight(c.end),
+				})
+			}
+		}
+
+		availability = append(availability, employeeAvailability)
+	}
+
+	return availability
+}
+
+// busyByEmployee lists the busy intervals of the schedules not declined, per employee and booking date.
+func busyByEmployee(attentionTimes []domain.AttentionTimeDomain, serviceDurations map[int64]int,
+	schedules []domain.ScheduleDomain) map[int64]map[string][]busyInterval {
+
+	attentionTimesByID := make(map[int64]domain.AttentionTimeDomain, len(attentionTimes))
+	for _, attentionTime := range attentionTimes {
+		attentionTimesByID[attentionTime.ID] = attentionTime
+	}
+
+	busy := make(map[int64]map[string][]busyInterval)
+	for _, schedule := range schedules {
+
+		attentionTime, known := attentionTimesByID[schedule.AttentionTimeID]
+		if !known || schedule.DateDeclined != nil {
+			continue
+		}
+
+		interval, err := busyIntervalOf(attentionTime, serviceDurations[attentionTime.ServiceID])
+		if err != nil {
+			continue
+		}
+
+		date := schedule.BookedAt.Format(ScheduleBookingLayout)
+		if busy[attentionTime.EmployeeID] == nil {
+			busy[attentionTime.EmployeeID] = make(map[string][]busyInterval)
+		}
+		busy[attentionTime.EmployeeID][date] = append(busy[attentionTime.EmployeeID][date], interval)
+	}
+
+	return busy
+}
+
+// busyIntervalOf is the time a booking of the attention time keeps its employee busy, from its
+// initial time to the end of the service.
+func busyIntervalOf(attentionTime domain.AttentionTimeDomain, durationMinutes int) (busyInterval, error) {
+
+	start, err := parseAttentionTime(attentionTime.InitialTime)
+	if err != nil {
+		return busyInterval{}, err
+	}
+
+	end := sinceMidnight(start) + time.Duration(durationMinutes)*time.Minute
+	if durationMinutes <= 0 {
+		// a service without a known duration keeps the employee busy for the whole attention time
+		end = sinceMidnight(start) + DefaultServiceDurationMinutes*time.Minute
+		if finalTime, err := parseAttentionTime(attentionTime.FinalTime); err == nil {
+			end = sinceMidnight(finalTime)
+		}
+	}
+
+	return busyInterval{start: sinceMidnight(start), end: end}, nil
+}
+
+func overlapsAny(start, end time.Duration, intervals []busyInterval) bool {
+	for _, interval := range intervals {
+		if start < interval.end && interval.start < end {
+			return true
+		}
+	}
+	return false
+}
+
+func sinceMidnight(clock time.Time) time.Duration {
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute
+}
+
+func formatSinceMidnight(duration time.Duration) string {
+	return time.Time{}.Add(duration).Format(AttentionTimeLayout)
+}
+
+// today is the current date at midnight UTC, the location of the booking dates.
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeAvailability(t *testing.T) {
+
+	day1 := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	declinedAt := day1.Add(-time.Hour)
+
+	bath := domain.ServiceDomain{ID: 2, Name: "BANHO", Active: true, DurationMinutes: 60, ContractID: 1}
+
+	employees := map[int64]domain.EmployeeDomain{
+		1: {ID: 1, Name: "Fulana", Active: true},
+		2: {ID: 2, Name: "Ciclano", Active: false},
+		3: {ID: 3, Name: "Beltrana", Active: true},
+	}
+
+	attentionTimes := []domain.AttentionTimeDomain{
+		{ID: 2, InitialTime: "10:00", FinalTime: "11:00", Active: true, ServiceID: 2, EmployeeID: 1},
+		{ID: 1, InitialTime: "09:00", FinalTime: "10:00", Active: true, ServiceID: 2, EmployeeID: 1},
+		{ID: 3, InitialTime: "09:30", FinalTime: "10:00", Active: true, ServiceID: 1, EmployeeID: 1},
+		{ID: 6, InitialTime: "11:00", FinalTime: "12:00", Active: false, ServiceID: 2, EmployeeID: 1},
+		{ID: 4, InitialTime: "09:00", FinalTime: "10:00", Active: true, ServiceID: 2, EmployeeID: 2},
+		{ID: 5, InitialTime: "13:00", FinalTime: "13:30", Active: true, ServiceID: 2, EmployeeID: 3},
+	}
+
+	durations := map[int64]int{1: 30, 2: 60}
+
+	tests := []struct {
+		Name      string
+		Service   domain.ServiceDomain
+		From      time.Time
+		Today     time.Time
+		Schedules []domain.ScheduleDomain
+		Expected  []domain.EmployeeAvailabilityDomain
+	}{
+		{
+			Name:    "WithoutSchedules_ReturnsEveryActiveSlotOfActiveEmployeesLongEnough",
+			Service: bath,
+			From:    day1,
+			Today:   day1,
+			Expected: []domain.EmployeeAvailabilityDomain{
+				{EmployeeID: 1, EmployeeName: "Fulana", Slots: []domain.AvailableSlotDomain{
+					{Date: day1, AttentionTimeID: 1, InitialTime: "09:00", FinalTime: "10:00"},
+					{Date: day1, AttentionTimeID: 2, InitialTime: "10:00", FinalTime: "11:00"},
+					{Date: day2, AttentionTimeID: 1, InitialTime: "09:00", FinalTime: "10:00"},
+					{Date: day2, AttentionTimeID: 2, InitialTime: "10:00", FinalTime: "11:00"},
+				}},
+			},
+		},
+		{
+			Name:    "WithBookingOfAnotherServiceOverlapping_HidesTheSlotOnThatDate",
+			Service: bath,
+			From:    day1,
+			Today:   day1,
+			Schedules: []domain.ScheduleDomain{
+				{ID: 1, BookedAt: day1, AttentionTimeID: 3},
+				{ID: 2, BookedAt: day2, AttentionTimeID: 2, DateDeclined: &declinedAt},
+			},
+			Expected: []domain.EmployeeAvailabilityDomain{
+				{EmployeeID: 1, EmployeeName: "Fulana", Slots: []domain.AvailableSlotDomain{
+					{Date: day1, AttentionTimeID: 2, InitialTime: "10:00", FinalTime: "11:00"},
+					{Date: day2, AttentionTimeID: 1, InitialTime: "09:00", FinalTime: "10:00"},
+					{Date: day2, AttentionTimeID: 2, InitialTime: "10:00", FinalTime: "11:00"},
+				}},
+			},
+		},
+		{
+			Name:    "WithPeriodStartingInThePast_StartsToday",
+			Service: bath,
+			From:    day1,
+			Today:   day2,
+			Schedules: []domain.ScheduleDomain{
+				{ID: 1, BookedAt: day2, AttentionTimeID: 1},
+			},
+			Expected: []domain.EmployeeAvailabilityDomain{
+				{EmployeeID: 1, EmployeeName: "Fulana", Slots: []domain.AvailableSlotDomain{
+					{Date: day2, AttentionTimeID: 2, InitialTime: "10:00", FinalTime: "11:00"},
+				}},
+			},
+		},
+		{
+			Name: "WithInactiveService_ReturnsNothing",
+			Service: func() domain.ServiceDomain {
+				inactive := bath
+				inactive.Active = false
+				return inactive
+			}(),
+			From:     day1,
+			Today:    day1,
+			Expected: []domain.EmployeeAvailabilityDomain{},
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			availability := ComputeAvailability(AvailabilityInput{
+				Service:          test.Service,
+				From:             test.From,
+				To:               day2,
+				Today:            test.Today,
+				Employees:        employees,
+				AttentionTimes:   attentionTimes,
+				ServiceDurations: durations,
+				Schedules:        test.Schedules,
+			})
+			assert.Equal(t, test.Expected, availability)
+		})
+	}
+}
+
+func TestAvailabilityService_GetAvailability(t *testing.T) {
+
+	from := today()
+	attentionTimes := []domain.AttentionTimeDomain{
+		{ID: 1, InitialTime: "09:00", FinalTime: "10:00", Active: true, ServiceID: 2, EmployeeID: 1},
+		{ID: 3, InitialTime: "09:00", FinalTime: "10:00", Active: true, ServiceID: 1, EmployeeID: 1},
+	}
+
+	var requestedIDs []int64
+	availabilityService := AvailabilityService{
+		LoggerSugar: loggerSugar,
+		ServiceDomainDataBaseRepository: output.ServiceDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error) {
+				return map[int64]domain.ServiceDomain{
+					1: {ID: 1, Active: true, DurationMinutes: 30},
+					2: {ID: 2, Active: true, DurationMinutes: 60},
+				}[ID], ID <= 2, nil
+			},
+		},
+		EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+				return domain.EmployeeDomain{ID: ID, Name: "Fulana", Active: true}, true, nil
+			},
+		},
+		AttentionTimeDomainDataBaseRepository: output.AttentionTimeDomainDataBaseRepositoryMock{
+			GetByServiceIDMock: func(contextControl domain.ContextControl, serviceID int64) ([]domain.AttentionTimeDomain, error) {
+				return attentionTimes[:1], nil
+			},
+			GetByEmployeeIDMock: func(contextControl domain.ContextControl, employeeID int64) ([]domain.AttentionTimeDomain, error) {
+				return attentionTimes, nil
+			},
+		},
+		ScheduleDomainDataBaseRepository: output.ScheduleDomainDataBaseRepositoryMock{
+			GetActiveByAttentionTimesMock: func(contextControl domain.ContextControl, attentionTimeIDs []int64, from, to time.Time) ([]domain.ScheduleDomain, error) {
+				requestedIDs = attentionTimeIDs
+				return []domain.ScheduleDomain{{ID: 1, BookedAt: from, AttentionTimeID: 3}}, nil
+			},
+		},
+	}
+
+	t.Run("WithBookingOfAnotherServiceOfTheEmployee_ReturnsTheOtherDates", func(t *testing.T) {
+
+		availability, exists, err := availabilityService.GetAvailability(domain.ContextControl{Context: context.Background()},
+			2, from, from.AddDate(0, 0, 1))
+		assert.Nil(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, []int64{1, 3}, requestedIDs)
+		assert.Equal(t, []domain.EmployeeAvailabilityDomain{
+			{EmployeeID: 1, EmployeeName: "Fulana", Slots: []domain.AvailableSlotDomain{
+				{Date: from.AddDate(0, 0, 1), AttentionTimeID: 1, InitialTime: "09:00", FinalTime: "10:00"},
+			}},
+		}, availability)
+	})
+
+	t.Run("WithUnknownService_ReturnsNotFound", func(t *testing.T) {
+
+		_, exists, err := availabilityService.GetAvailability(domain.ContextControl{Context: context.Background()},
+			9, from, from)
+		assert.Nil(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("WithPeriodTooLong_ReturnsValidationError", func(t *testing.T) {
+
+		_, _, err := availabilityService.GetAvailability(domain.ContextControl{Context: context.Background()},
+			2, from, from.AddDate(0, 0, AvailabilityMaxDays))
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+)
+
+// CacheNotFoundValue is cached under the key of an ID that doesn't exist in the database,
+// so repeated lookups of a missing ID don't reach Postgres until NotFoundCacheTTL expires.
+const CacheNotFoundValue = "NOT_FOUND"
+
+var NotFoundCacheTTL = 30 * time.Second
+
+const (
+	AddressCacheStatsName = "address"
+	PhoneCacheStatsName   = "phone"
+)
+
+// cacheCounter counts the read-through lookups of one entity. A negative entry is a hit.
+type cacheCounter struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+var cacheCounters sync.Map
+
+func getCacheCounter(name string) *cacheCounter {
+	counter, _ := cacheCounters.LoadOrStore(name, &cacheCounter{})
+	return counter.(*cacheCounter)
+}
+
+func (counter *cacheCounter) Hit() {
+	counter.hits.Add(1)
+}
+
+func (counter *cacheCounter) Miss() {
+	counter.misses.Add(1)
+}
+
+type CacheStatsService struct{}
+
+func (service CacheStatsService) GetCacheStats() map[string]domain.CacheStatsDomain {
+
+	stats := make(map[string]domain.CacheStatsDomain)
+	cacheCounters.Range(func(name, counter any) bool {
+		stats[name.(string)] = domain.CacheStatsDomain{
+			Hits:   counter.(*cacheCounter).hits.Load(),
+			Misses: counter.(*cacheCounter).misses.Load(),
+		}
+		return true
+	})
+
+	return stats
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"go.uber.org/zap"
+)
+
+// CacheKeyServiceName is the first segment of every key this API writes to the cache.
+const CacheKeyServiceName = "petshop-api"
+
+const (
+	CacheErrorToGetStaleKeys      = "error to get the stale keys of an entity in cache"
+	CacheErrorToDeleteStaleKey    = "error to delete a stale key in cache"
+	CacheSuccessToDeleteStaleKeys = "stale keys of an entity deleted from cache"
+)
+
+// CacheKey builds the cache keys of one entity as <service>:<entity>:v<schemaVersion>:<id>.
+// Entities owned by a contract use <contractID>:<id> as id when the request is scoped to one.
+// SchemaVersion must be bumped whenever the cached JSON of the entity changes, so entries
+// written by a previous release are never decoded into the new struct.
+type CacheKey struct {
+	Entity        string
+	SchemaVersion int
+}
+
+var (
+	ContractCacheKey = CacheKey{Entity: "contract", SchemaVersion: 1}
+	CustomerCacheKey = CacheKey{Entity: "customer", SchemaVersion: 2}
+	AddressCacheKey  = CacheKey{Entity: "address", SchemaVersion: 1}
+	PhoneCacheKey    = CacheKey{Entity: "phone", SchemaVersion: 1}
+	PetCacheKey      = CacheKey{Entity: "pet", SchemaVersion: 1}
+	CatalogCacheKey  = CacheKey{Entity: "catalog", SchemaVersion: 1}
+)
+
+// CacheKeys lists the keys of every cached entity, checked by InvalidateStaleVersions.
+func CacheKeys() []CacheKey {
+	return []CacheKey{ContractCacheKey, CustomerCacheKey, AddressCacheKey, PhoneCacheKey, PetCacheKey, CatalogCacheKey}
+}
+
+func (key CacheKey) Build(id string) string {
+	return fmt.Sprintf("%s:%s:v%d:%s", CacheKeyServiceName, key.Entity, key.SchemaVersion, id)
+}
+
+func (key CacheKey) BuildID(ID int64) string {
+	return key.Build(strconv.FormatInt(ID, 10))
+}
+
+// BuildScopedID builds the key of an entity owned by a contract, so the entry cached for
+// one contract is never served to another.
+func (key CacheKey) BuildScopedID(contextControl domain.ContextControl, ID int64) string {
+	if contextControl.ContractID == 0 {
+		return key.BuildID(ID)
+	}
+	return key.Build(fmt.Sprintf("%d:%d", contextControl.ContractID, ID))
+}
+
+// Bump returns the key with the next schema version. Entries of the previous versions
+// are removed by InvalidateStaleVersions on the next start.
+func (key CacheKey) Bump() CacheKey {
+	key.SchemaVersion++
+	return key
+}
+
+// entityPattern matches the keys of every schema version of the entity.
+func (key CacheKey) entityPattern() string {
+	return fmt.Sprintf("%s:%s:v*", CacheKeyServiceName, key.Entity)
+}
+
+func (key CacheKey) isStale(cacheKey string) bool {
+	return !strings.HasPrefix(cacheKey, fmt.Sprintf("%s:%s:v%d:", CacheKeyServiceName, key.Entity, key.SchemaVersion))
+}
+
+type CacheKeyService struct {
+	LoggerSugar        *zap.SugaredLogger
+	CacheKeyRepository output.ICacheKeyRepository
+}
+
+// InvalidateStaleVersions deletes the entries written with an older (or newer) schema
+// version than the current one of each key, returning how many were deleted.
+func (service CacheKeyService) InvalidateStaleVersions(contextControl domain.ContextControl, keys ...CacheKey) (int, error) {
+
+	deleted := 0
+	for _, key := range keys {
+
+		cacheKeys, err := service.CacheKeyRepository.GetKeysByPattern(contextControl, key.entityPattern())
+		if err != nil {
+			service.LoggerSugar.Errorw(CacheErrorToGetStaleKeys, "entity", key.Entity, "error", err)
+			return deleted, err
+		}
+
+		entityDeleted := 0
+		for _, cacheKey := range cacheKeys {
+			if !key.isStale(cacheKey) {
+				continue
+			}
+
+			if err = service.CacheKeyRepository.Delete(contextControl, cacheKey); err != nil {
+				service.LoggerSugar.Errorw(CacheErrorToDeleteStaleKey, "key", cacheKey, "error", err)
+				return deleted, err
+			}
+			entityDeleted++
+		}
+
+		if entityDeleted > 0 {
+			service.LoggerSugar.Infow(CacheSuccessToDeleteStaleKeys, "entity", key.Entity,
+				"schema_version", key.SchemaVersion, "deleted", entityDeleted)
+		}
+		deleted += entityDeleted
+	}
+
+	return deleted, nil
+}
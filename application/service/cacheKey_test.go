@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheKey_Build(t *testing.T) {
+
+	assert.Equal(t, "petshop-api:address:v1:5", AddressCacheKey.BuildID(5))
+	assert.Equal(t, "petshop-api:phone:v1:5", PhoneCacheKey.BuildID(5))
+	assert.Equal(t, "petshop-api:catalog:v1:tree", CatalogCacheKey.Build(CatalogCacheKeyTree))
+	assert.Equal(t, "petshop-api:address:v2:5", AddressCacheKey.Bump().BuildID(5))
+	assert.Equal(t, 1, AddressCacheKey.SchemaVersion)
+}
+
+func TestCacheKeyService_InvalidateStaleVersions(t *testing.T) {
+
+	addressCacheKey := AddressCacheKey.Bump()
+
+	tests := []struct {
+		Name            string
+		Keys            []string
+		ScanError       error
+		ExpectedDeleted []string
+		ExpectedError   error
+	}{
+		{
+			Name: "WithKeysOfPreviousVersion_DeletesOnlyTheStaleOnes",
+			Keys: []string{
+				"petshop-api:address:v1:1",
+				"petshop-api:address:v2:1",
+				"petshop-api:address:v1:2",
+				"petshop-api:address:v20:2",
+			},
+			ExpectedDeleted: []string{"petshop-api:address:v1:1", "petshop-api:address:v1:2", "petshop-api:address:v20:2"},
+		},
+		{
+			Name:          "WithScanError_ReturnsError",
+			ScanError:     errors.New("scan failed"),
+			ExpectedError: errors.New("scan failed"),
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			var deleted []string
+			cacheKeyService := CacheKeyService{
+				LoggerSugar: loggerSugar,
+				CacheKeyRepository: output.CacheKeyRepositoryMock{
+					GetKeysByPatternMock: func(contextControl domain.ContextControl, pattern string) ([]string, error) {
+						assert.Equal(t, "petshop-api:address:v*", pattern)
+						return test.Keys, test.ScanError
+					},
+					DeleteMock: func(contextControl domain.ContextControl, key string) error {
+						deleted = append(deleted, key)
+						return nil
+					},
+				},
+			}
+
+			count, err := cacheKeyService.InvalidateStaleVersions(domain.ContextControl{Context: context.Background()}, addressCacheKey)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedDeleted, deleted)
+			assert.Equal(t, len(test.ExpectedDeleted), count)
+		})
+	}
+}
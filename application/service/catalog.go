@@ -0,0 +1,175 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"go.uber.org/zap"
+)
+
+// CatalogService serves the species and breed catalog. The whole species→breeds tree
+// is cached under a single key because it is small and almost never changes.
+type CatalogService struct {
+	LoggerSugar                     *zap.SugaredLogger
+	SpeciesDomainDataBaseRepository output.ISpeciesDomainDataBaseRepository
+	BreedDomainDataBaseRepository   output.IBreedDomainDataBaseRepository
+	CatalogDomainCacheRepository    output.ICatalogDomainCacheRepository
+}
+
+var CatalogCacheTTL = 24 * time.Hour
+
+const (
+	CatalogCacheKeyTree = "tree"
+)
+
+const (
+	CatalogErrorToSaveInCache   = "error to save the catalog in cache"
+	CatalogErrorToGetInCache    = "error to get the catalog in cache"
+	CatalogErrorToDeleteInCache = "error to delete the catalog in cache"
+	SpeciesNameIsRequired       = "species name is required"
+	BreedNameIsRequired         = "breed name is required"
+	SpeciesAlreadyExists        = "the species %s already exists"
+	BreedAlreadyExistsInSpecies = "the breed %s already exists for the species %d"
+	BreedSpeciesNotFound        = "the species with id %d wasn't found"
+)
+
+// GetSpeciesTree returns every species with its breeds, served from cache whenever possible.
+func (service *CatalogService) GetSpeciesTree(contextControl domain.ContextControl) ([]domain.SpeciesDomain, error) {
+
+	cacheKey := CatalogCacheKey.Build(CatalogCacheKeyTree)
+	if hash, err := service.CatalogDomainCacheRepository.Get(contextControl, cacheKey); err == nil && len(hash) > 0 {
+		var tree []domain.SpeciesDomain
+		if err = json.Unmarshal([]byte(hash), &tree); err == nil {
+			return tree, nil
+		}
+		service.LoggerSugar.Warnw(CatalogErrorToGetInCache, "error", err)
+	}
+
+	speciesList, err := service.SpeciesDomainDataBaseRepository.GetAll(contextControl)
+	if err != nil {
+		return nil, err
+	}
+
+	breeds, err := service.BreedDomainDataBaseRepository.GetAll(contextControl)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := buildSpeciesTree(speciesList, breeds)
+
+	hash, err := json.Marshal(tree)
+	if err != nil {
+		service.LoggerSugar.Warnw("failed to marshal catalog for cache", "error", err)
+	}
+
+	if err = service.CatalogDomainCacheRepository.Set(contextControl, cacheKey,
+		string(hash), CatalogCacheTTL); err != nil {
+		service.LoggerSugar.Infow(CatalogErrorToSaveInCache, "error", err)
+	}
+
+	return tree, nil
+}
+
+// GetBreedsBySpeciesID returns the breeds of a species and whether the species exists.
+func (service *CatalogService) GetBreedsBySpeciesID(contextControl domain.ContextControl, speciesID int64) ([]domain.BreedDomain, bool, error) {
+
+	tree, err := service.GetSpeciesTree(contextControl)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, species := range tree {
+		if species.ID == speciesID {
+			return species.Breeds, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+func (service *CatalogService) CreateSpecies(contextControl domain.ContextControl, species domain.SpeciesDomain) (domain.SpeciesDomain, error) {
+
+	species.Name = strings.TrimSpace(species.Name)
+	if len(species.Name) == 0 {
+		return domain.SpeciesDomain{}, domain.NewValidationError(SpeciesNameIsRequired)
+	}
+
+	_, exists, err := service.SpeciesDomainDataBaseRepository.GetByName(contextControl, species.Name)
+	if err != nil {
+		return domain.SpeciesDomain{}, err
+	}
+	if exists {
+		return domain.SpeciesDomain{}, domain.NewConflictError(SpeciesAlreadyExists, species.Name)
+	}
+
+	save, err := service.SpeciesDomainDataBaseRepository.Save(contextControl, species)
+	if err != nil {
+		return domain.SpeciesDomain{}, err
+	}
+
+	service.invalidateSpeciesTree(contextControl)
+
+	return save, nil
+}
+
+func (service *CatalogService) CreateBreed(contextControl domain.ContextControl, breed domain.BreedDomain) (domain.BreedDomain, error) {
+
+	breed.Name = strings.TrimSpace(breed.Name)
+	if len(breed.Name) == 0 {
+		return domain.BreedDomain{}, domain.NewValidationError(BreedNameIsRequired)
+	}
+
+	_, exists, err := service.SpeciesDomainDataBaseRepository.GetByID(contextControl, breed.SpeciesID)
+	if err != nil {
+		return domain.BreedDomain{}, err
+	}
+	if !exists {
+		return domain.BreedDomain{}, domain.NewValidationError(BreedSpeciesNotFound, breed.SpeciesID)
+	}
+
+	_, exists, err = service.BreedDomainDataBaseRepository.GetBySpeciesIDAndName(contextControl, breed.SpeciesID, breed.Name)
+	if err != nil {
+		return domain.BreedDomain{}, err
+	}
+	if exists {
+		return domain.BreedDomain{}, domain.NewConflictError(BreedAlreadyExistsInSpecies, breed.Name, breed.SpeciesID)
+	}
+
+	save, err := service.BreedDomainDataBaseRepository.Save(contextControl, breed)
+	if err != nil {
+		return domain.BreedDomain{}, err
+	}
+
+	service.invalidateSpeciesTree(contextControl)
+
+	return save, nil
+}
+
+func (service *CatalogService) invalidateSpeciesTree(contextControl domain.ContextControl) {
+	if err := service.CatalogDomainCacheRepository.Delete(contextControl,
+		CatalogCacheKey.Build(CatalogCacheKeyTree)); err != nil {
+		service.LoggerSugar.Warnw(CatalogErrorToDeleteInCache, "error", err)
+	}
+}
+
+func buildSpeciesTree(speciesList []domain.SpeciesDomain, breeds []domain.BreedDomain) []domain.SpeciesDomain {
+
+	breedsBySpecies := make(map[int64][]domain.BreedDomain, len(speciesList))
+	for _, breed := range breeds {
+		breedsBySpecies[breed.SpeciesID] = append(breedsBySpecies[breed.SpeciesID], breed)
+	}
+
+	tree := make([]domain.SpeciesDomain, 0, len(speciesList))
+	for _, species := range speciesList {
+		species.Breeds = breedsBySpecies[species.ID]
+		if species.Breeds == nil {
+			species.Breeds = []domain.BreedDomain{}
+		}
+		tree = append(tree, species)
+	}
+
+	return tree
+}
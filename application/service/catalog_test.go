@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogService_GetSpeciesTree(t *testing.T) {
+
+	speciesRepository := output.SpeciesDomainDataBaseRepositoryMock{
+		GetAllMock: func(contextControl domain.ContextControl) ([]domain.SpeciesDomain, error) {
+			return []domain.SpeciesDomain{{ID: 1, Name: "Canino"}, {ID: 2, Name: "Felino"}, {ID: 3, Name: "Ave"}}, nil
+		},
+	}
+
+	breedRepository := output.BreedDomainDataBaseRepositoryMock{
+		GetAllMock: func(contextControl domain.ContextControl) ([]domain.BreedDomain, error) {
+			return []domain.BreedDomain{
+				{ID: 1, Name: "Pastor Alemao", SpeciesID: 1},
+				{ID: 2, Name: "Siames", SpeciesID: 2},
+				{ID: 3, Name: "Poodle", SpeciesID: 1},
+			}, nil
+		},
+	}
+
+	expectedTree := []domain.SpeciesDomain{
+		{ID: 1, Name: "Canino", Breeds: []domain.BreedDomain{{ID: 1, Name: "Pastor Alemao", SpeciesID: 1}, {ID: 3, Name: "Poodle", SpeciesID: 1}}},
+		{ID: 2, Name: "Felino", Breeds: []domain.BreedDomain{{ID: 2, Name: "Siames", SpeciesID: 2}}},
+		{ID: 3, Name: "Ave", Breeds: []domain.BreedDomain{}},
+	}
+
+	t.Run("WithCacheMiss_BuildsTreeAndCachesIt", func(t *testing.T) {
+
+		var cached string
+		catalogService := CatalogService{
+			LoggerSugar:                     loggerSugar,
+			SpeciesDomainDataBaseRepository: speciesRepository,
+			BreedDomainDataBaseRepository:   breedRepository,
+			CatalogDomainCacheRepository: output.CatalogDomainCacheRepositoryMock{
+				SetMock: func(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error {
+					cached = hash
+					return nil
+				},
+			},
+		}
+
+		tree, err := catalogService.GetSpeciesTree(domain.ContextControl{Context: context.Background()})
+		assert.Nil(t, err)
+		assert.Equal(t, expectedTree, tree)
+		assert.NotEmpty(t, cached)
+	})
+
+	t.Run("WithCacheHit_DoesNotReachTheDatabase", func(t *testing.T) {
+
+		hash, _ := json.Marshal(expectedTree)
+		catalogService := CatalogService{
+			LoggerSugar: loggerSugar,
+			SpeciesDomainDataBaseRepository: output.SpeciesDomainDataBaseRepositoryMock{
+				GetAllMock: func(contextControl domain.ContextControl) ([]domain.SpeciesDomain, error) {
+					return nil, errors.New("database must not be called")
+				},
+			},
+			BreedDomainDataBaseRepository: breedRepository,
+			CatalogDomainCacheRepository: output.CatalogDomainCacheRepositoryMock{
+				GetMock: func(contextControl domain.ContextControl, key string) (string, error) {
+					return string(hash), nil
+				},
+			},
+		}
+
+		breeds, exists, err := catalogService.GetBreedsBySpeciesID(domain.ContextControl{Context: context.Background()}, 2)
+		assert.Nil(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, expectedTree[1].Breeds, breeds)
+	})
+}
+
+func TestCatalogService_CreateBreed(t *testing.T) {
+
+	speciesFound := output.SpeciesDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.SpeciesDomain, bool, error) {
+			return domain.SpeciesDomain{ID: ID, Name: "Canino"}, true, nil
+		},
+	}
+
+	tests := []struct {
+		Name                            string
+		Breed                           domain.BreedDomain
+		SpeciesDomainDataBaseRepository output.ISpeciesDomainDataBaseRepository
+		BreedDomainDataBaseRepository   output.IBreedDomainDataBaseRepository
+		ExpectedResult                  domain.BreedDomain
+		ExpectedError                   error
+		ExpectedInvalidation            bool
+	}{
+		{
+			Name:                            "WithNewBreed_SavesAndInvalidatesCache",
+			Breed:                           domain.BreedDomain{Name: " Poodle ", SpeciesID: 1},
+			SpeciesDomainDataBaseRepository: speciesFound,
+			BreedDomainDataBaseRepository: output.BreedDomainDataBaseRepositoryMock{
+				SaveMock: func(contextControl domain.ContextControl, breed domain.BreedDomain) (domain.BreedDomain, error) {
+					breed.ID = 3
+					return breed, nil
+				},
+			},
+			ExpectedResult:       domain.BreedDomain{ID: 3, Name: "Poodle", SpeciesID: 1},
+			ExpectedError:        nil,
+			ExpectedInvalidation: true,
+		},
+		{
+			Name:                            "WithBreedAlreadyInSpecies_ReturnsConflict",
+			Breed:                           domain.BreedDomain{Name: "pastor alemao", SpeciesID: 1},
+			SpeciesDomainDataBaseRepository: speciesFound,
+			BreedDomainDataBaseRepository: output.BreedDomainDataBaseRepositoryMock{
+				GetBySpeciesIDAndNameMock: func(contextControl domain.ContextControl, speciesID int64, name string) (domain.BreedDomain, bool, error) {
+					return domain.BreedDomain{ID: 1, Name: "Pastor Alemao", SpeciesID: speciesID}, true, nil
+				},
+			},
+			ExpectedResult: domain.BreedDomain{},
+			ExpectedError:  domain.NewConflictError(BreedAlreadyExistsInSpecies, "pastor alemao", 1),
+		},
+		{
+			Name:                            "WithUnknownSpecies_ReturnsValidationError",
+			Breed:                           domain.BreedDomain{Name: "Poodle", SpeciesID: 99},
+			SpeciesDomainDataBaseRepository: output.SpeciesDomainDataBaseRepositoryMock{},
+			BreedDomainDataBaseRepository:   output.BreedDomainDataBaseRepositoryMock{},
+			ExpectedResult:                  domain.BreedDomain{},
+			ExpectedError:                   domain.NewValidationError(BreedSpeciesNotFound, 99),
+		},
+		{
+			Name:                            "WithoutName_ReturnsValidationError",
+			Breed:                           domain.BreedDomain{Name: "", SpeciesID: 1},
+			SpeciesDomainDataBaseRepository: speciesFound,
+			BreedDomainDataBaseRepository:   output.BreedDomainDataBaseRepositoryMock{},
+			ExpectedResult:                  domain.BreedDomain{},
+			ExpectedError:                   domain.NewValidationError(BreedNameIsRequired),
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			invalidated := false
+			catalogService := CatalogService{
+				LoggerSugar:                     loggerSugar,
+				SpeciesDomainDataBaseRepository: test.SpeciesDomainDataBaseRepository,
+				BreedDomainDataBaseRepository:   test.BreedDomainDataBaseRepository,
+				CatalogDomainCacheRepository: output.CatalogDomainCacheRepositoryMock{
+					DeleteMock: func(contextControl domain.ContextControl, key string) error {
+						invalidated = true
+						return nil
+					},
+				},
+			}
+
+			breed, err := catalogService.CreateBreed(domain.ContextControl{Context: context.Background()}, test.Breed)
+			assert.Equal(t, test.ExpectedResult, breed)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedInvalidation, invalidated)
+		})
+	}
+}
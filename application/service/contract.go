@@ -0,0 +1,175 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/petshop-system/petshop-api/application/utils"
+	"go.uber.org/zap"
+)
+
+type ContractService struct {
+	LoggerSugar                      *zap.SugaredLogger
+	ContractDomainDataBaseRepository output.IContractDomainDataBaseRepository
+	ContractDomainCacheRepository    output.IContractDomainCacheRepository
+}
+
+var ContractCacheTTL = 10 * time.Minute
+
+const (
+	ContractErrorToSaveInCache    = "error to save contract in cache"
+	ContractErrorToGetByIDInCache = "error to get contract in cache"
+	ContractErrorToDeleteInCache  = "error to delete contract in cache"
+	ContractNameIsRequired        = "contract name is required"
+	ContractEmailIsInvalid        = "contract email is invalid"
+	ContractDocumentIsInvalid     = "contract document is invalid: %s"
+	ContractAddressIsInvalid      = "contract address is invalid: %s"
+	ContractDocumentAlreadyExists = "there is already a contract with the document %s"
+	ContractEmailAlreadyExists    = "there is already a contract with the email %s"
+)
+
+// Create validates the contract and stores it with its address.
+func (service *ContractService) Create(contextControl domain.ContextControl, contract domain.ContractDomain) (domain.ContractDomain, error) {
+
+	contract = normalizeContract(contract)
+	if err := service.ValidateContract(contract); err != nil {
+		return domain.ContractDomain{}, err
+	}
+
+	if err := (AddressService{}).ValidateAddress(contract.Address); err != nil {
+		return domain.ContractDomain{}, domain.NewValidationError(ContractAddressIsInvalid, err.Error())
+	}
+
+	if err := service.checkUniqueKeys(contextControl, contract); err != nil {
+		return domain.ContractDomain{}, err
+	}
+
+	save, err := service.ContractDomainDataBaseRepository.Save(contextControl, contract)
+	if err != nil {
+		return domain.ContractDomain{}, err
+	}
+
+	service.setInCache(contextControl, save)
+
+	return save, nil
+}
+
+func (service *ContractService) GetByID(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error) {
+
+	cacheKey := ContractCacheKey.BuildID(ID)
+	if hash, err := service.ContractDomainCacheRepository.Get(contextControl, cacheKey); err == nil && len(hash) > 0 {
+		var contract domain.ContractDomain
+		if err = json.Unmarshal([]byte(hash), &contract); err == nil {
+			return contract, true, nil
+		}
+		service.LoggerSugar.Warnw(ContractErrorToGetByIDInCache, "contract_id", ID, "error", err)
+	}
+
+	contract, exists, err := service.ContractDomainDataBaseRepository.GetByID(contextControl, ID)
+	if err != nil || !exists {
+		return domain.ContractDomain{}, false, err
+	}
+
+	service.setInCache(contextControl, contract)
+
+	return contract, true, nil
+}
+
+// Update changes the name, email, document and person type of a known contract.
+// The address and the creation date are kept.
+func (service *ContractService) Update(contextControl domain.ContextControl, contract domain.ContractDomain) (domain.ContractDomain, bool, error) {
+
+	current, exists, err := service.ContractDomainDataBaseRepository.GetByID(contextControl, contract.ID)
+	if err != nil || !exists {
+		return domain.ContractDomain{}, false, err
+	}
+
+	contract = normalizeContract(contract)
+	if err = service.ValidateContract(contract); err != nil {
+		return domain.ContractDomain{}, true, err
+	}
+
+	if err = service.checkUniqueKeys(contextControl, contract); err != nil {
+		return domain.ContractDomain{}, true, err
+	}
+
+	if err = service.ContractDomainDataBaseRepository.Update(contextControl, contract); err != nil {
+		return domain.ContractDomain{}, true, err
+	}
+
+	if err = service.ContractDomainCacheRepository.Delete(contextControl, ContractCacheKey.BuildID(contract.ID)); err != nil {
+		service.LoggerSugar.Warnw(ContractErrorToDeleteInCache, "contract_id", contract.ID, "error", err)
+	}
+
+	current.Name = contract.Name
+	current.Email = contract.Email
+	current.Document = contract.Document
+	current.PersonType = contract.PersonType
+
+	return current, true, nil
+}
+
+// ValidateContract checks the name, the email and the document of the contract against its person type.
+func (service *ContractService) ValidateContract(contract domain.ContractDomain) error {
+
+	if len(contract.Name) == 0 {
+		return domain.NewValidationError(ContractNameIsRequired)
+	}
+
+	if at := strings.Index(contract.Email, "@"); at <= 0 || at == len(contract.Email)-1 {
+		return domain.NewValidationError(ContractEmailIsInvalid)
+	}
+
+	if err := validatePersonDocument(contract.PersonType, contract.Document); err != nil {
+		return domain.NewValidationError(ContractDocumentIsInvalid, err.Error())
+	}
+
+	return nil
+}
+
+// checkUniqueKeys reports a conflict when another contract already uses the document or the email.
+// The unique indexes still guard against concurrent requests.
+func (service *ContractService) checkUniqueKeys(contextControl domain.ContextControl, contract domain.ContractDomain) error {
+
+	byDocument, exists, err := service.ContractDomainDataBaseRepository.GetByDocument(contextControl, contract.Document)
+	if err != nil {
+		return err
+	}
+	if exists && byDocument.ID != contract.ID {
+		return domain.NewConflictError(ContractDocumentAlreadyExists, contract.Document)
+	}
+
+	byEmail, exists, err := service.ContractDomainDataBaseRepository.GetByEmail(contextControl, contract.Email)
+	if err != nil {
+		return err
+	}
+	if exists && byEmail.ID != contract.ID {
+		return domain.NewConflictError(ContractEmailAlreadyExists, contract.Email)
+	}
+
+	return nil
+}
+
+func (service *ContractService) setInCache(contextControl domain.ContextControl, contract domain.ContractDomain) {
+
+	hash, err := json.Marshal(contract)
+	if err != nil {
+		service.LoggerSugar.Warnw("failed to marshal contract for cache", "contract_id", contract.ID, "error", err)
+		return
+	}
+
+	if err = service.ContractDomainCacheRepository.Set(contextControl, ContractCacheKey.BuildID(contract.ID),
+		string(hash), ContractCacheTTL); err != nil {
+		service.LoggerSugar.Infow(ContractErrorToSaveInCache, "contract_id", contract.ID, "error", err)
+	}
+}
+
+func normalizeContract(contract domain.ContractDomain) domain.ContractDomain {
+	contract.Name = strings.TrimSpace(contract.Name)
+	contract.Email = strings.ToLower(strings.TrimSpace(contract.Email))
+	contract.Document = utils.RemoveNonAlphaNumericCharacters(contract.Document)
+	return contract
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/petshop-system/petshop-api/application/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractService_Create(t *testing.T) {
+
+	validContract := domain.ContractDomain{
+		Name:       "Petshop Centro",
+		Email:      " Contato@Petshop.com ",
+		Document:   "79.626.068/0001-30",
+		PersonType: TypePersonLegal,
+		Address:    utils.GetMockAddress(),
+	}
+
+	savedContract := domain.ContractDomain{
+		ID:         1,
+		Name:       "Petshop Centro",
+		Email:      "contato@petshop.com",
+		Document:   "79626068000130",
+		PersonType: TypePersonLegal,
+		AddressID:  1,
+		Address:    utils.GetMockAddress(),
+	}
+
+	tests := []struct {
+		Name                             string
+		Contract                         domain.ContractDomain
+		ContractDomainDataBaseRepository output.IContractDomainDataBaseRepository
+		ExpectedResult                   domain.ContractDomain
+		ExpectedError                    error
+	}{
+		{
+			Name:     "WithValidContract_SavesItWithTheAddress",
+			Contract: validContract,
+			ContractDomainDataBaseRepository: output.ContractDomainDataBaseRepositoryMock{
+				SaveMock: func(contextControl domain.ContextControl, contract domain.ContractDomain) (domain.ContractDomain, error) {
+					assert.Equal(t, "contato@petshop.com", contract.Email)
+					assert.Equal(t, "79626068000130", contract.Document)
+					return savedContract, nil
+				},
+			},
+			ExpectedResult: savedContract,
+		},
+		{
+			Name: "WithInvalidCnpj_ReturnsValidationError",
+			Contract: func() domain.ContractDomain {
+				contract := validContract
+				contract.Document = "11.111.111/1111-11"
+				return contract
+			}(),
+			ContractDomainDataBaseRepository: output.ContractDomainDataBaseRepositoryMock{},
+			ExpectedError:                    domain.ErrValidation,
+		},
+		{
+			Name: "WithoutAddressStreet_ReturnsValidationError",
+			Contract: func() domain.ContractDomain {
+				contract := validContract
+				contract.Address.Street = ""
+				return contract
+			}(),
+			ContractDomainDataBaseRepository: output.ContractDomainDataBaseRepositoryMock{},
+			ExpectedError:                    domain.ErrValidation,
+		},
+		{
+			Name:     "WithDocumentOfAnotherContract_ReturnsConflict",
+			Contract: validContract,
+			ContractDomainDataBaseRepository: output.ContractDomainDataBaseRepositoryMock{
+				GetByDocumentMock: func(contextControl domain.ContextControl, document string) (domain.ContractDomain, bool, error) {
+					return domain.ContractDomain{ID: 2}, true, nil
+				},
+			},
+			ExpectedError: domain.ErrConflict,
+		},
+		{
+			Name:     "WithEmailOfAnotherContract_ReturnsConflict",
+			Contract: validContract,
+			ContractDomainDataBaseRepository: output.ContractDomainDataBaseRepositoryMock{
+				GetByEmailMock: func(contextControl domain.ContextControl, email string) (domain.ContractDomain, bool, error) {
+					return domain.ContractDomain{ID: 2}, true, nil
+				},
+			},
+			ExpectedError: domain.ErrConflict,
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			contractService := ContractService{
+				LoggerSugar:                      loggerSugar,
+				ContractDomainDataBaseRepository: test.ContractDomainDataBaseRepository,
+				ContractDomainCacheRepository:    output.ContractDomainCacheRepositoryMock{},
+			}
+
+			contract, err := contractService.Create(domain.ContextControl{Context: context.Background()}, test.Contract)
+			assert.Equal(t, test.ExpectedResult, contract)
+			if test.ExpectedError == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.ExpectedError)
+			}
+		})
+	}
+}
+
+func TestContractService_Update(t *testing.T) {
+
+	storedContract := domain.ContractDomain{
+		ID:         1,
+		Name:       "Petshop Centro",
+		Email:      "contato@petshop.com",
+		Document:   "79626068000130",
+		PersonType: TypePersonLegal,
+		AddressID:  3,
+		Address:    utils.GetMockAddress(),
+	}
+
+	update := domain.ContractDomain{
+		ID:         1,
+		Name:       "Petshop Bairro",
+		Email:      "bairro@petshop.com",
+		Document:   "79626068000130",
+		PersonType: TypePersonLegal,
+	}
+
+	t.Run("WithUnknownContract_ReturnsNotFound", func(t *testing.T) {
+
+		contractService := ContractService{
+			LoggerSugar:                      loggerSugar,
+			ContractDomainDataBaseRepository: output.ContractDomainDataBaseRepositoryMock{},
+			ContractDomainCacheRepository:    output.ContractDomainCacheRepositoryMock{},
+		}
+
+		_, exists, err := contractService.Update(domain.ContextControl{Context: context.Background()}, update)
+		assert.Nil(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("WithOwnDocument_UpdatesAndInvalidatesTheCache", func(t *testing.T) {
+
+		var deletedKey string
+		contractService := ContractService{
+			LoggerSugar: loggerSugar,
+			ContractDomainDataBaseRepository: output.ContractDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error) {
+					return storedContract, true, nil
+				},
+				GetByDocumentMock: func(contextControl domain.ContextControl, document string) (domain.ContractDomain, bool, error) {
+					return storedContract, true, nil
+				},
+			},
+			ContractDomainCacheRepository: output.ContractDomainCacheRepositoryMock{
+				DeleteMock: func(contextControl domain.ContextControl, key string) error {
+					deletedKey = key
+					return nil
+				},
+			},
+		}
+
+		contract, exists, err := contractService.Update(domain.ContextControl{Context: context.Background()}, update)
+		assert.Nil(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, "Petshop Bairro", contract.Name)
+		assert.Equal(t, "bairro@petshop.com", contract.Email)
+		assert.Equal(t, storedContract.Address, contract.Address)
+		assert.Equal(t, ContractCacheKey.BuildID(1), deletedKey)
+	})
+
+	t.Run("WithRepositoryError_ReturnsError", func(t *testing.T) {
+
+		contractService := ContractService{
+			LoggerSugar: loggerSugar,
+			ContractDomainDataBaseRepository: output.ContractDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error) {
+					return storedContract, true, nil
+				},
+				UpdateMock: func(contextControl domain.ContextControl, contract domain.ContractDomain) error {
+					return errors.New("update failed")
+				},
+			},
+			ContractDomainCacheRepository: output.ContractDomainCacheRepositoryMock{},
+		}
+
+		_, exists, err := contractService.Update(domain.ContextControl{Context: context.Background()}, update)
+		assert.EqualError(t, err, "update failed")
+		assert.True(t, exists)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/petshop-system/petshop-api/application/domain"
@@ -16,29 +17,36 @@ type CustomerService struct {
 	LoggerSugar                      *zap.SugaredLogger
 	CustomerDomainDataBaseRepository output.ICustomerDomainDataBaseRepository
 	CustomerDomainCacheRepository    output.ICustomerDomainCacheRepository
+	ContractDomainDataBaseRepository output.IContractDomainDataBaseRepository
+	TransactionManager               output.ITransactionManager
+	OutboxDomainDataBaseRepository   output.IOutboxDomainDataBaseRepository
 }
 
 var ClientCacheTTL = 10 * time.Minute
 
-const (
-	CustomerCacheKeyTypeID = "CUSTOMER_ID"
-)
-
 const (
 	CustomerErrorToSaveInCache    = "error to save customer in cache."
 	CustomerErrorToGetByIDInCache = "error to get person in cache"
 	InvalidTypeOfDocument         = "invalid type of person"
+	CustomerContractNotFound      = "the contract with id %d wasn't found"
+	CustomerErrorToDeleteInCache  = "error to delete customer in cache"
+	CustomerNameIsRequired        = "customer name is required"
+	CustomerEmailIsInvalid        = "customer email is invalid"
+	CustomerDocumentIsInvalid     = "customer document is invalid: %s"
+	CustomerSuccessToUpdate       = "customer updated with success"
+	CustomerSuccessToDelete       = "customer deleted with success"
+	CustomerSuccessToRestore      = "customer restored with success"
+	CustomerHistoryDescription    = "customer %s"
 )
 
+// CustomerHistoryUnknownActor is recorded as the author of the changes whose request has no actor.
+const CustomerHistoryUnknownActor = "unknown"
+
 const (
 	TypePersonLegal      = "legal"
 	TypePersonIndividual = "individual"
 )
 
-func (service *CustomerService) getCacheKey(cacheKeyType string, value string) string {
-	return fmt.Sprintf("%s.%s", cacheKeyType, value)
-}
-
 func (service *CustomerService) Create(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, error) {
 
 	err := service.ValidateTypePerson(customer)
@@ -46,18 +54,46 @@ func (service *CustomerService) Create(contextControl domain.ContextControl, cus
 		return domain.CustomerDomain{}, err
 	}
 
-	customer.Document = utils.RemoveNonAlphaNumericCharacters(customer.Document)
-	save, err := service.CustomerDomainDataBaseRepository.Save(contextControl, customer)
+	customer.ContractID = contextControl.ScopedContractID(customer.ContractID)
+	_, exists, err := service.ContractDomainDataBaseRepository.GetByID(contextControl, customer.ContractID)
 	if err != nil {
 		return domain.CustomerDomain{}, err
 	}
+	if !exists {
+		return domain.CustomerDomain{}, domain.NewValidationError(CustomerContractNotFound, customer.ContractID)
+	}
+
+	customer.Document = utils.RemoveNonAlphaNumericCharacters(customer.Document)
+	var save domain.CustomerDomain
+	if err = saveWithEvents(service.TransactionManager, service.OutboxDomainDataBaseRepository, contextControl,
+		func(txControl domain.ContextControl) ([]domain.EventDomain, error) {
+			var err error
+			if save, err = service.CustomerDomainDataBaseRepository.Save(txControl, customer); err != nil {
+				return nil, err
+			}
+			if err = service.recordHistory(txControl, domain.CustomerActionCreated, nil, save); err != nil {
+				return nil, err
+			}
+			return []domain.EventDomain{
+				domain.NewEvent(txControl, domain.EventCustomerCreated, domain.CustomerEventVersion,
+					strconv.FormatInt(save.ID, 10), domain.CustomerEvent{
+						ID:         save.ID,
+						Name:       save.Name,
+						Email:      save.Email,
+						PersonType: save.PersonType,
+						ContractID: save.ContractID,
+					}),
+			}, nil
+		}); err != nil {
+		return domain.CustomerDomain{}, err
+	}
 
 	hash, err := json.Marshal(save)
 	if err != nil {
 		service.LoggerSugar.Warnw("failed to marshal customer for cache", "customer_id", save.ID, "error", err)
 	}
 	if err = service.CustomerDomainCacheRepository.Set(contextControl,
-		service.getCacheKey(CustomerCacheKeyTypeID, strconv.FormatInt(save.ID, 10)),
+		CustomerCacheKey.BuildScopedID(contextControl, save.ID),
 		string(hash), ClientCacheTTL); err != nil {
 		service.LoggerSugar.Infow(CustomerErrorToSaveInCache, "customer_id", save.ID)
 	}
@@ -65,14 +101,273 @@ func (service *CustomerService) Create(contextControl domain.ContextControl, cus
 	return save, nil
 }
 
+func (service *CustomerService) GetByID(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+
+	cacheKey := CustomerCacheKey.BuildScopedID(contextControl, ID)
+	if hash, err := service.CustomerDomainCacheRepository.Get(contextControl, cacheKey); err == nil && len(hash) > 0 {
+		var customer domain.CustomerDomain
+		if err = json.Unmarshal([]byte(hash), &customer); err == nil {
+			return customer, true, nil
+		}
+		service.LoggerSugar.Warnw(CustomerErrorToGetByIDInCache, "customer_id", ID, "error", err)
+	}
+
+	customer, exists, err := service.CustomerDomainDataBaseRepository.GetByID(contextControl, ID)
+	if err != nil {
+		return domain.CustomerDomain{}, false, err
+	}
+
+	if !exists {
+		return domain.CustomerDomain{}, false, nil
+	}
+
+	hash, err := json.Marshal(customer)
+	if err != nil {
+		service.LoggerSugar.Warnw("failed to marshal customer for cache", "customer_id", customer.ID, "error", err)
+	}
+
+	if err = service.CustomerDomainCacheRepository.Set(contextControl, cacheKey,
+		string(hash), ClientCacheTTL); err != nil {
+		service.LoggerSugar.Infow(CustomerErrorToSaveInCache, "customer_id", customer.ID)
+	}
+
+	return customer, true, nil
+}
+
+// Update replaces the name, email, document and person type of the customer, returning false
+// when it doesn't exist or is deleted.
+func (service *CustomerService) Update(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, bool, error) {
+
+	current, exists, err := service.CustomerDomainDataBaseRepository.GetByID(contextControl, customer.ID)
+	if err != nil || !exists {
+		return domain.CustomerDomain{}, false, err
+	}
+
+	updated := current
+	updated.Name = customer.Name
+	updated.Email = customer.Email
+	updated.Document = customer.Document
+	updated.PersonType = customer.PersonType
+
+	return service.update(contextControl, current, updated)
+}
+
+// Patch changes only the fields present in the patch, returning false when the customer doesn't
+// exist or is deleted. The document and person type are validated together, whichever is changed.
+func (service *CustomerService) Patch(contextControl domain.ContextControl, ID int64, patch domain.CustomerPatchDomain) (domain.CustomerDomain, bool, error) {
+
+	current, exists, err := service.CustomerDomainDataBaseRepository.GetByID(contextControl, ID)
+	if err != nil || !exists {
+		return domain.CustomerDomain{}, false, err
+	}
+
+	updated := current
+	if patch.Name != nil {
+		updated.Name = *patch.Name
+	}
+	if patch.Email != nil {
+		updated.Email = *patch.Email
+	}
+	if patch.Document != nil {
+		updated.Document = *patch.Document
+	}
+	if patch.PersonType != nil {
+		updated.PersonType = *patch.PersonType
+	}
+
+	return service.update(contextControl, current, updated)
+}
+
+// update stores the customer changed from current, recording the change in the same transaction.
+func (service *CustomerService) update(contextControl domain.ContextControl, current, customer domain.CustomerDomain) (domain.CustomerDomain, bool, error) {
+
+	customer = normalizeCustomer(customer)
+	if err := service.ValidateCustomer(customer); err != nil {
+		return domain.CustomerDomain{}, true, err
+	}
+
+	if err := withinTransaction(service.TransactionManager, contextControl, func(txControl domain.ContextControl) error {
+		if err := service.CustomerDomainDataBaseRepository.Update(txControl, customer); err != nil {
+			return err
+		}
+		return service.recordHistory(txControl, domain.CustomerActionUpdated, &current, customer)
+	}); err != nil {
+		return domain.CustomerDomain{}, true, err
+	}
+
+	service.invalidateCache(contextControl, customer.ID)
+	service.LoggerSugar.Infow(CustomerSuccessToUpdate, "customer_id", customer.ID)
+
+	return customer, true, nil
+}
+
+// Delete soft deletes the customer, hiding it from reads until restored. It returns false when
+// the customer doesn't exist or is already deleted.
+func (service *CustomerService) Delete(contextControl domain.ContextControl, ID int64) (bool, error) {
+
+	customer, exists, err := service.CustomerDomainDataBaseRepository.GetByID(contextControl, ID)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	deleted := customer
+	deletedAt := time.Now()
+	deleted.DateDeleted = &deletedAt
+
+	if err = withinTransaction(service.TransactionManager, contextControl, func(txControl domain.ContextControl) error {
+		if err := service.CustomerDomainDataBaseRepository.Delete(txControl, ID, deletedAt); err != nil {
+			return err
+		}
+		return service.recordHistory(txControl, domain.CustomerActionDeleted, &customer, deleted)
+	}); err != nil {
+		return true, err
+	}
+
+	service.invalidateCache(contextControl, ID)
+	service.LoggerSugar.Infow(CustomerSuccessToDelete, "customer_id", ID)
+
+	return true, nil
+}
+
+// Restore brings a deleted customer back; restoring a customer not deleted changes nothing. It
+// returns false when the customer doesn't exist.
+func (service *CustomerService) Restore(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+
+	customer, exists, err := service.CustomerDomainDataBaseRepository.GetByIDIncludingDeleted(contextControl, ID)
+	if err != nil || !exists {
+		return domain.CustomerDomain{}, false, err
+	}
+
+	if customer.DateDeleted == nil {
+		return customer, true, nil
+	}
+
+	restored := customer
+	restored.DateDeleted = nil
+
+	if err = withinTransaction(service.TransactionManager, contextControl, func(txControl domain.ContextControl) error {
+		if err := service.CustomerDomainDataBaseRepository.Restore(txControl, ID); err != nil {
+			return err
+		}
+		return service.recordHistory(txControl, domain.CustomerActionRestored, &customer, restored)
+	}); err != nil {
+		return domain.CustomerDomain{}, true, err
+	}
+
+	service.invalidateCache(contextControl, ID)
+	service.LoggerSugar.Infow(CustomerSuccessToRestore, "customer_id", ID)
+
+	return restored, true, nil
+}
+
+// GetHistory returns a page of the changes of the customer, the oldest first, returning false when
+// the customer doesn't exist. The history of a deleted customer is still returned.
+func (service *CustomerService) GetHistory(contextControl domain.ContextControl, ID int64, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerHistoryDomain], bool, error) {
+
+	page, err := ValidatePage(page)
+	if err != nil {
+		return domain.PageResultDomain[domain.CustomerHistoryDomain]{}, false, err
+	}
+
+	_, exists, err := service.CustomerDomainDataBaseRepository.GetByIDIncludingDeleted(contextControl, ID)
+	if err != nil || !exists {
+		return domain.PageResultDomain[domain.CustomerHistoryDomain]{}, false, err
+	}
+
+	history, err := service.CustomerDomainDataBaseRepository.GetHistory(contextControl, ID, page)
+	if err != nil {
+		return domain.PageResultDomain[domain.CustomerHistoryDomain]{}, true, err
+	}
+
+	return history, true, nil
+}
+
+// recordHistory records the change of the customer from before to after, before being nil for its
+// creation, on behalf of the actor of the request. Nothing is recorded when no field changed.
+func (service *CustomerService) recordHistory(contextControl domain.ContextControl, action string, before *domain.CustomerDomain, after domain.CustomerDomain) error {
+
+	changes := domain.CustomerChanges(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	actor := strings.TrimSpace(contextControl.Actor)
+	if len(actor) == 0 {
+		actor = CustomerHistoryUnknownActor
+	}
+
+	return service.CustomerDomainDataBaseRepository.SaveHistory(contextControl, domain.CustomerHistoryDomain{
+		CustomerID:  after.ID,
+		Action:      action,
+		Actor:       actor,
+		RequestID:   contextControl.RequestID,
+		Description: fmt.Sprintf(CustomerHistoryDescription, action),
+		Changes:     changes,
+	})
+}
+
+// List returns a page of the customers not deleted matching the filter. The name matches any
+// part of it and the email and document match whole, regardless of case and punctuation.
+func (service *CustomerService) List(contextControl domain.ContextControl, filter domain.CustomerFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerDomain], error) {
+
+	page, err := ValidatePage(page)
+	if err != nil {
+		return domain.PageResultDomain[domain.CustomerDomain]{}, err
+	}
+
+	filter.Name = strings.ToLower(strings.TrimSpace(filter.Name))
+	filter.Email = strings.ToLower(strings.TrimSpace(filter.Email))
+	filter.Document = utils.RemoveNonAlphaNumericCharacters(filter.Document)
+
+	return service.CustomerDomainDataBaseRepository.List(contextControl, filter, page)
+}
+
+// invalidateCache drops the cached customer, so the next read sees the change.
+func (service *CustomerService) invalidateCache(contextControl domain.ContextControl, ID int64) {
+	if err := service.CustomerDomainCacheRepository.Delete(contextControl,
+		CustomerCacheKey.BuildScopedID(contextControl, ID)); err != nil {
+		service.LoggerSugar.Warnw(CustomerErrorToDeleteInCache, "customer_id", ID, "error", err)
+	}
+}
+
+// ValidateCustomer checks the fields that can be changed after the creation.
+func (service *CustomerService) ValidateCustomer(customer domain.CustomerDomain) error {
+
+	if len(customer.Name) == 0 {
+		return domain.NewValidationError(CustomerNameIsRequired)
+	}
+
+	if at := strings.Index(customer.Email, "@"); at <= 0 || at == len(customer.Email)-1 {
+		return domain.NewValidationError(CustomerEmailIsInvalid)
+	}
+
+	if err := service.ValidateTypePerson(customer); err != nil {
+		return domain.NewValidationError(CustomerDocumentIsInvalid, err.Error())
+	}
+
+	return nil
+}
+
+func normalizeCustomer(customer domain.CustomerDomain) domain.CustomerDomain {
+	customer.Name = strings.TrimSpace(customer.Name)
+	customer.Email = strings.ToLower(strings.TrimSpace(customer.Email))
+	customer.Document = utils.RemoveNonAlphaNumericCharacters(customer.Document)
+	return customer
+}
+
 func (service *CustomerService) ValidateTypePerson(customer domain.CustomerDomain) error { //TODO: Change the method name to ValidatePerson
-	switch customer.PersonType {
+	return validatePersonDocument(customer.PersonType, customer.Document)
+}
+
+// validatePersonDocument checks the document as a CNPJ for legal persons and as a CPF for individuals.
+func validatePersonDocument(personType, document string) error {
+	switch personType {
 	case TypePersonLegal:
-		if err := utils.ValidateCnpj(customer.Document); err != nil {
+		if err := utils.ValidateCnpj(document); err != nil {
 			return err
 		}
 	case TypePersonIndividual:
-		if err := utils.ValidateCpf(customer.Document); err != nil {
+		if err := utils.ValidateCpf(document); err != nil {
 			return err
 		}
 	default:
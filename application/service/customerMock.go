@@ -3,8 +3,14 @@ package service
 import "github.com/petshop-system/petshop-api/application/domain"
 
 type CustomerMock struct {
-	CreateMock  func(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, error)
-	GetByIDMock func(ID int64) (domain.CustomerDomain, error)
+	CreateMock     func(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, error)
+	GetByIDMock    func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error)
+	UpdateMock     func(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, bool, error)
+	PatchMock      func(contextControl domain.ContextControl, ID int64, patch domain.CustomerPatchDomain) (domain.CustomerDomain, bool, error)
+	DeleteMock     func(contextControl domain.ContextControl, ID int64) (bool, error)
+	RestoreMock    func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error)
+	ListMock       func(contextControl domain.ContextControl, filter domain.CustomerFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerDomain], error)
+	GetHistoryMock func(contextControl domain.ContextControl, ID int64, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerHistoryDomain], bool, error)
 }
 
 func (c CustomerMock) Create(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, error) {
@@ -14,9 +20,51 @@ func (c CustomerMock) Create(contextControl domain.ContextControl, customer doma
 	return domain.CustomerDomain{}, nil
 }
 
-func (c CustomerMock) GetByID(ID int64) (domain.CustomerDomain, error) {
+func (c CustomerMock) GetByID(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
 	if c.GetByIDMock != nil {
-		return c.GetByIDMock(ID)
+		return c.GetByIDMock(contextControl, ID)
 	}
-	return domain.CustomerDomain{}, nil
+	return domain.CustomerDomain{}, false, nil
+}
+
+func (c CustomerMock) Update(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, bool, error) {
+	if c.UpdateMock != nil {
+		return c.UpdateMock(contextControl, customer)
+	}
+	return domain.CustomerDomain{}, false, nil
+}
+
+func (c CustomerMock) Patch(contextControl domain.ContextControl, ID int64, patch domain.CustomerPatchDomain) (domain.CustomerDomain, bool, error) {
+	if c.PatchMock != nil {
+		return c.PatchMock(contextControl, ID, patch)
+	}
+	return domain.CustomerDomain{}, false, nil
+}
+
+func (c CustomerMock) Delete(contextControl domain.ContextControl, ID int64) (bool, error) {
+	if c.DeleteMock != nil {
+		return c.DeleteMock(contextControl, ID)
+	}
+	return false, nil
+}
+
+func (c CustomerMock) Restore(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+	if c.RestoreMock != nil {
+		return c.RestoreMock(contextControl, ID)
+	}
+	return domain.CustomerDomain{}, false, nil
+}
+
+func (c CustomerMock) List(contextControl domain.ContextControl, filter domain.CustomerFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerDomain], error) {
+	if c.ListMock != nil {
+		return c.ListMock(contextControl, filter, page)
+	}
+	return domain.PageResultDomain[domain.CustomerDomain]{}, nil
+}
+
+func (c CustomerMock) GetHistory(contextControl domain.ContextControl, ID int64, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerHistoryDomain], bool, error) {
+	if c.GetHistoryMock != nil {
+		return c.GetHistoryMock(contextControl, ID, page)
+	}
+	return domain.PageResultDomain[domain.CustomerHistoryDomain]{}, false, nil
 }
@@ -0,0 +1,14 @@
+package service
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type CustomerOnboardingMock struct {
+	OnboardMock func(contextControl domain.ContextControl, onboarding domain.CustomerOnboardingDomain) (domain.CustomerOnboardingDomain, error)
+}
+
+func (c CustomerOnboardingMock) Onboard(contextControl domain.ContextControl, onboarding domain.CustomerOnboardingDomain) (domain.CustomerOnboardingDomain, error) {
+	if c.OnboardMock != nil {
+		return c.OnboardMock(contextControl, onboarding)
+	}
+	return domain.CustomerOnboardingDomain{}, nil
+}
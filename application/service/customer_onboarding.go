@@ -0,0 +1,156 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"go.uber.org/zap"
+)
+
+// CustomerOnboardingService creates a customer together with its address and phones through the
+// services of each, so the rules, events and history of every one of them apply.
+type CustomerOnboardingService struct {
+	LoggerSugar        *zap.SugaredLogger
+	CustomerService    *CustomerService
+	AddressService     AddressService
+	PhoneService       *PhoneService
+	TransactionManager output.ITransactionManager
+}
+
+const (
+	CustomerOnboardingIsInvalid        = "the onboarding is invalid: %s"
+	CustomerOnboardingManyPrimaries    = "only one phone can be the primary one"
+	CustomerOnboardingSuccess          = "customer onboarded with success"
+	CustomerOnboardingRolledBack       = "customer onboarding rolled back"
+	CustomerOnboardingErrorToForgetKey = "error to drop the cache of an entity rolled back"
+)
+
+// Onboard creates the address, the customer living there and its phones in one transaction, so a
+// failure leaves none of them behind. Every validation failure is returned together before any write.
+func (service *CustomerOnboardingService) Onboard(contextControl domain.ContextControl, onboarding domain.CustomerOnboardingDomain) (domain.CustomerOnboardingDomain, error) {
+
+	if err := service.ValidateOnboarding(onboarding); err != nil {
+		return domain.CustomerOnboardingDomain{}, err
+	}
+
+	var created domain.CustomerOnboardingDomain
+	if err := withinTransaction(service.TransactionManager, contextControl, func(txControl domain.ContextControl) error {
+
+		created = domain.CustomerOnboardingDomain{}
+
+		address, err := service.AddressService.Create(txControl, onboarding.Address)
+		if err != nil {
+			return err
+		}
+		created.Address = address
+
+		customer := onboarding.Customer
+		customer.AddressID = address.ID
+		if created.Customer, err = service.CustomerService.Create(txControl, customer); err != nil {
+			return err
+		}
+
+		for _, phoneUser := range onboarding.Phones {
+
+			phone, err := service.PhoneService.Create(txControl, phoneUser.Phone)
+			if err != nil {
+				return err
+			}
+			created.Phones = append(created.Phones, domain.PhoneUserDomain{PhoneID: phone.ID, Phone: phone})
+
+			link, _, err := service.PhoneService.Attach(txControl, domain.PhoneUserDomain{
+				PhoneID:  phone.ID,
+				UserID:   created.Customer.ID,
+				UserType: PhoneUserTypeCustomer,
+				Primary:  phoneUser.Primary,
+			})
+			if err != nil {
+				return err
+			}
+			created.Phones[len(created.Phones)-1] = link
+		}
+
+		return nil
+	}); err != nil {
+		service.LoggerSugar.Infow(CustomerOnboardingRolledBack, "error", err.Error())
+		service.forget(contextControl, created)
+		return domain.CustomerOnboardingDomain{}, err
+	}
+
+	// the attach of a primary phone took the flag from the first one
+	primary := 0
+	for i, phoneUser := range onboarding.Phones {
+		if phoneUser.Primary {
+			primary = i
+		}
+	}
+	for i := range created.Phones {
+		created.Phones[i].Primary = i == primary
+	}
+
+	service.LoggerSugar.Infow(CustomerOnboardingSuccess, "customer_id", created.Customer.ID,
+		"address_id", created.Address.ID, "phones", len(created.Phones))
+
+	return created, nil
+}
+
+// ValidateOnboarding runs the validations of the customer, the address and every phone, returning
+// all their failures in a single validation error.
+func (service *CustomerOnboardingService) ValidateOnboarding(onboarding domain.CustomerOnboardingDomain) error {
+
+	var failures []string
+
+	if err := service.CustomerService.ValidateTypePerson(onboarding.Customer); err != nil {
+		failures = append(failures, "customer: "+err.Error())
+	}
+
+	if err := service.AddressService.ValidateAddress(onboarding.Address); err != nil {
+		failures = append(failures, "address: "+err.Error())
+	}
+
+	primaries := 0
+	for i, phoneUser := range onboarding.Phones {
+		if err := service.PhoneService.ValidatePhone(phoneUser.Phone); err != nil {
+			failures = append(failures, fmt.Sprintf("phone %d: %s", i+1, err.Error()))
+		}
+		if phoneUser.Primary {
+			primaries++
+		}
+	}
+	if primaries > 1 {
+		failures = append(failures, "phones: "+CustomerOnboardingManyPrimaries)
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return domain.NewValidationError(CustomerOnboardingIsInvalid, strings.Join(failures, "; "))
+}
+
+// forget drops the entries cached by the creates of a rolled back onboarding, whose rows were
+// never committed.
+func (service *CustomerOnboardingService) forget(contextControl domain.ContextControl, created domain.CustomerOnboardingDomain) {
+
+	if created.Address.ID != 0 {
+		service.forgetKey(contextControl, service.AddressService.AddressDomainCacheRepository.Delete,
+			AddressCacheKey.BuildScopedID(contextControl, created.Address.ID))
+	}
+	if created.Customer.ID != 0 {
+		service.forgetKey(contextControl, service.CustomerService.CustomerDomainCacheRepository.Delete,
+			CustomerCacheKey.BuildScopedID(contextControl, created.Customer.ID))
+	}
+	for _, phoneUser := range created.Phones {
+		service.forgetKey(contextControl, service.PhoneService.PhoneDomainCacheRepository.Delete,
+			PhoneCacheKey.BuildScopedID(contextControl, phoneUser.PhoneID))
+	}
+}
+
+func (service *CustomerOnboardingService) forgetKey(contextControl domain.ContextControl,
+	deleteKey func(contextControl domain.ContextControl, key string) error, key string) {
+	if err := deleteKey(contextControl, key); err != nil {
+		service.LoggerSugar.Warnw(CustomerOnboardingErrorToForgetKey, "key", key, "error", err)
+	}
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/petshop-system/petshop-api/application/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// onboardingFake keeps the rows written by an onboarding and whether each write ran inside the
+// transaction of the in-memory manager.
+type onboardingFake struct {
+	writesOutsideTransaction int
+	phoneUsers               []domain.PhoneUserDomain
+	deletedKeys              []string
+	saveUserErr              error
+}
+
+func (fake *onboardingFake) write(contextControl domain.ContextControl) {
+	if !output.InTransaction(contextControl) {
+		fake.writesOutsideTransaction++
+	}
+}
+
+func (fake *onboardingFake) service(transactionManager output.ITransactionManager) *CustomerOnboardingService {
+
+	cache := func() (func(domain.ContextControl, string, string, time.Duration) error, func(domain.ContextControl, string) error) {
+		return func(domain.ContextControl, string, string, time.Duration) error { return nil },
+			func(contextControl domain.ContextControl, key string) error {
+				fake.deletedKeys = append(fake.deletedKeys, key)
+				return nil
+			}
+	}
+	setMock, deleteMock := cache()
+
+	customerRepository := output.CustomerDomainDataBaseRepositoryMock{
+		SaveMock: func(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, error) {
+			fake.write(contextControl)
+			customer.ID = 20
+			return customer, nil
+		},
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+			return domain.CustomerDomain{ID: ID}, ID == 20, nil
+		},
+		SaveHistoryMock: func(contextControl domain.ContextControl, history domain.CustomerHistoryDomain) error {
+			fake.write(contextControl)
+			return nil
+		},
+	}
+
+	nextPhoneID := int64(30)
+	phoneRepository := output.PhoneDomainDataBaseRepositoryMock{
+		SaveMock: func(contextControl domain.ContextControl, phone domain.PhoneDomain) (domain.PhoneDomain, error) {
+			fake.write(contextControl)
+			phone.ID = nextPhoneID
+			nextPhoneID++
+			return phone, nil
+		},
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.PhoneDomain, bool, error) {
+			return domain.PhoneDomain{ID: ID}, true, nil
+		},
+		GetUsersByOwnerMock: func(contextControl domain.ContextControl, userType string, userID int64) ([]domain.PhoneUserDomain, error) {
+			return fake.phoneUsers, nil
+		},
+		SaveUserMock: func(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) (domain.PhoneUserDomain, error) {
+			fake.write(contextControl)
+			if fake.saveUserErr != nil {
+				return domain.PhoneUserDomain{}, fake.saveUserErr
+			}
+			fake.phoneUsers = append(fake.phoneUsers, phoneUser)
+			return phoneUser, nil
+		},
+		SetPrimaryUserMock: func(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) error {
+			fake.write(contextControl)
+			return nil
+		},
+	}
+
+	return &CustomerOnboardingService{
+		LoggerSugar: loggerSugar,
+		CustomerService: &CustomerService{
+			LoggerSugar:                      loggerSugar,
+			CustomerDomainDataBaseRepository: customerRepository,
+			CustomerDomainCacheRepository:    output.CustomerDomainCacheRepositoryMock{SetMock: setMock, DeleteMock: deleteMock},
+			ContractDomainDataBaseRepository: output.ContractDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error) {
+					return domain.ContractDomain{ID: ID}, true, nil
+				},
+			},
+			OutboxDomainDataBaseRepository: output.OutboxDomainDataBaseRepositoryMock{},
+			TransactionManager:             transactionManager,
+		},
+		AddressService: AddressService{
+			LoggerSugar: loggerSugar,
+			AddressDomainDataBaseRepository: output.AddressDomainDataBaseRepositoryMock{
+				SaveMock: func(contextControl domain.ContextControl, address domain.AddressDomain) (domain.AddressDomain, error) {
+					fake.write(contextControl)
+					address.ID = 10
+					return address, nil
+				},
+			},
+			AddressDomainCacheRepository: output.AddressDomainCacheRepositoryMock{SetMock: setMock, DeleteMock: deleteMock},
+		},
+		PhoneService: &PhoneService{
+			LoggerSugar:                      loggerSugar,
+			PhoneDomainDataBaseRepository:    phoneRepository,
+			PhoneDomainCacheRepository:       output.PhoneDomainCacheRepositoryMock{SetMock: setMock, DeleteMock: deleteMock},
+			CustomerDomainDataBaseRepository: customerRepository,
+			TransactionManager:               transactionManager,
+		},
+		TransactionManager: transactionManager,
+	}
+}
+
+func validOnboarding() domain.CustomerOnboardingDomain {
+	return domain.CustomerOnboardingDomain{
+		Customer: domain.CustomerDomain{
+			Name:       "Fulano",
+			Email:      "fulano@email.com",
+			Document:   "296.230.570-91",
+			PersonType: TypePersonIndividual,
+		},
+		Address: utils.GetMockAddress(),
+		Phones: []domain.PhoneUserDomain{
+			{Phone: domain.PhoneDomain{Number: "91234-5678", CodeArea: "32", PhoneType: MobilePhone}},
+			{Phone: domain.PhoneDomain{Number: "3215-4321", CodeArea: "32", PhoneType: LandLinePhone}, Primary: true},
+		},
+	}
+}
+
+func TestCustomerOnboardingService_Onboard(t *testing.T) {
+
+	contextControl := domain.ContextControl{Context: context.Background(), ContractID: 1}
+
+	t.Run("WithValidOnboarding_CreatesEverythingInOneTransaction", func(t *testing.T) {
+
+		fake := &onboardingFake{}
+		transactionManager := &output.TransactionManagerMock{}
+
+		onboarded, err := fake.service(transactionManager).Onboard(contextControl, validOnboarding())
+		assert.Nil(t, err)
+
+		assert.Equal(t, int64(10), onboarded.Address.ID)
+		assert.Equal(t, int64(20), onboarded.Customer.ID)
+		assert.Equal(t, int64(10), onboarded.Customer.AddressID)
+		assert.Equal(t, int64(1), onboarded.Customer.ContractID)
+		assert.Len(t, onboarded.Phones, 2)
+		assert.Equal(t, domain.PhoneUserDomain{PhoneID: 30, UserID: 20, UserType: PhoneUserTypeCustomer,
+			Phone: domain.PhoneDomain{ID: 30}}, onboarded.Phones[0])
+		assert.Equal(t, int64(31), onboarded.Phones[1].PhoneID)
+		assert.True(t, onboarded.Phones[1].Primary)
+
+		assert.Equal(t, 0, fake.writesOutsideTransaction)
+		assert.Equal(t, 1, transactionManager.Committed())
+		assert.Empty(t, fake.deletedKeys)
+	})
+
+	t.Run("WithEveryPartInvalid_ReturnsAllTheFailuresTogether", func(t *testing.T) {
+
+		fake := &onboardingFake{}
+		transactionManager := &output.TransactionManagerMock{}
+
+		onboarding := validOnboarding()
+		onboarding.Customer.Document = "123"
+		onboarding.Address.Street = ""
+		onboarding.Phones[0].Phone.Number = "123"
+		onboarding.Phones[0].Primary = true
+
+		_, err := fake.service(transactionManager).Onboard(contextControl, onboarding)
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.Contains(t, err.Error(), "customer: ")
+		assert.Contains(t, err.Error(), "address: ")
+		assert.Contains(t, err.Error(), "phone 1: "+ErrorInvalidMobilePhoneLength)
+		assert.Contains(t, err.Error(), "phones: "+CustomerOnboardingManyPrimaries)
+
+		assert.Equal(t, 0, transactionManager.Committed()+transactionManager.RolledBack())
+	})
+
+	t.Run("WithPhoneAttachError_RollsBackAndForgetsTheCachedRows", func(t *testing.T) {
+
+		fake := &onboardingFake{saveUserErr: errors.New("error to link the phone to its owner")}
+		transactionManager := &output.TransactionManagerMock{}
+
+		_, err := fake.service(transactionManager).Onboard(contextControl, validOnboarding())
+		assert.EqualError(t, err, "error to link the phone to its owner")
+
+		assert.Equal(t, 0, transactionManager.Committed())
+		assert.Equal(t, 1, transactionManager.RolledBack())
+		assert.ElementsMatch(t, []string{
+			AddressCacheKey.BuildScopedID(contextControl, 10),
+			CustomerCacheKey.BuildScopedID(contextControl, 20),
+			PhoneCacheKey.BuildScopedID(contextControl, 30),
+		}, fake.deletedKeys)
+	})
+}
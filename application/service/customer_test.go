@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
@@ -42,11 +43,18 @@ func init() {
 
 func TestCustomerService_Create(t *testing.T) {
 
+	existingContract := output.ContractDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error) {
+			return domain.ContractDomain{ID: ID}, true, nil
+		},
+	}
+
 	tests := []struct {
 		Name                             string
 		Customer                         domain.CustomerDomain
 		CustomerDomainDataBaseRepository output.ICustomerDomainDataBaseRepository
 		CustomerDomainCacheRepository    output.ICustomerDomainCacheRepository
+		ContractDomainDataBaseRepository output.IContractDomainDataBaseRepository
 		ExpectedResult                   domain.CustomerDomain
 		ExpectedError                    error
 	}{
@@ -77,6 +85,7 @@ func TestCustomerService_Create(t *testing.T) {
 					return nil
 				},
 			},
+			ContractDomainDataBaseRepository: existingContract,
 			ExpectedResult: domain.CustomerDomain{
 				Name:       "Fulano",
 				Document:   "296.230.570-91",
@@ -107,8 +116,29 @@ func TestCustomerService_Create(t *testing.T) {
 					return nil
 				},
 			},
-			ExpectedResult: domain.CustomerDomain{},
-			ExpectedError:  fmt.Errorf(database.CustomerSaveDBError),
+			ContractDomainDataBaseRepository: existingContract,
+			ExpectedResult:                   domain.CustomerDomain{},
+			ExpectedError:                    fmt.Errorf(database.CustomerSaveDBError),
+		},
+		{
+			Name: "WithUnknownContract_ReturnsValidationError",
+			Customer: domain.CustomerDomain{
+				Name:       "Fulano",
+				Document:   "296.230.570-91",
+				PersonType: TypePersonIndividual,
+				AddressID:  1,
+				ContractID: 99,
+				Email:      "fulano@email.com",
+			},
+			CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+				SaveMock: func(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, error) {
+					return domain.CustomerDomain{}, fmt.Errorf("customer must not be saved")
+				},
+			},
+			CustomerDomainCacheRepository:    output.CustomerDomainCacheRepositoryMock{},
+			ContractDomainDataBaseRepository: output.ContractDomainDataBaseRepositoryMock{},
+			ExpectedResult:                   domain.CustomerDomain{},
+			ExpectedError:                    domain.NewValidationError(CustomerContractNotFound, 99),
 		},
 	}
 
@@ -118,8 +148,10 @@ func TestCustomerService_Create(t *testing.T) {
 
 			customerService := CustomerService{
 				LoggerSugar:                      loggerSugar,
+				OutboxDomainDataBaseRepository:   output.OutboxDomainDataBaseRepositoryMock{},
 				CustomerDomainCacheRepository:    test.CustomerDomainCacheRepository,
 				CustomerDomainDataBaseRepository: test.CustomerDomainDataBaseRepository,
+				ContractDomainDataBaseRepository: test.ContractDomainDataBaseRepository,
 			}
 
 			contextControl := domain.ContextControl{
@@ -133,3 +165,607 @@ func TestCustomerService_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomerService_GetByID(t *testing.T) {
+
+	customerMocked := domain.CustomerDomain{
+		ID:         1,
+		Name:       "Fulano",
+		Document:   "29623057091",
+		PersonType: TypePersonIndividual,
+		AddressID:  1,
+		ContractID: 1,
+		Email:      "fulano@email.com",
+	}
+
+	tests := []struct {
+		Name                             string
+		ID                               int64
+		CustomerDomainDataBaseRepository output.ICustomerDomainDataBaseRepository
+		CustomerDomainCacheRepository    output.ICustomerDomainCacheRepository
+		ExpectedResult                   domain.CustomerDomain
+		ExpectedExists                   bool
+		ExpectedError                    error
+	}{
+		{
+			Name: "WithCachedCustomer_ReturnsFromCache",
+			ID:   1,
+			CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+					return domain.CustomerDomain{}, false, fmt.Errorf("database must not be called")
+				},
+			},
+			CustomerDomainCacheRepository: output.CustomerDomainCacheRepositoryMock{
+				GetMock: func(contextControl domain.ContextControl, key string) (string, error) {
+					hash, _ := json.Marshal(customerMocked)
+					return string(hash), nil
+				},
+			},
+			ExpectedResult: customerMocked,
+			ExpectedExists: true,
+			ExpectedError:  nil,
+		},
+		{
+			Name: "WithCacheMiss_ReturnsFromDatabase",
+			ID:   1,
+			CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+					return customerMocked, true, nil
+				},
+			},
+			CustomerDomainCacheRepository: output.CustomerDomainCacheRepositoryMock{
+				GetMock: func(contextControl domain.ContextControl, key string) (string, error) {
+					return "", fmt.Errorf("redis: nil")
+				},
+			},
+			ExpectedResult: customerMocked,
+			ExpectedExists: true,
+			ExpectedError:  nil,
+		},
+		{
+			Name: "WithUnknownID_ReturnsNotFound",
+			ID:   2,
+			CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+					return domain.CustomerDomain{}, false, nil
+				},
+			},
+			CustomerDomainCacheRepository: output.CustomerDomainCacheRepositoryMock{},
+			ExpectedResult:                domain.CustomerDomain{},
+			ExpectedExists:                false,
+			ExpectedError:                 nil,
+		},
+		{
+			Name: "WithDatabaseError_ReturnsError",
+			ID:   1,
+			CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+					return domain.CustomerDomain{}, false, fmt.Errorf(database.CustomerGetByIDDBError)
+				},
+			},
+			CustomerDomainCacheRepository: output.CustomerDomainCacheRepositoryMock{},
+			ExpectedResult:                domain.CustomerDomain{},
+			ExpectedExists:                false,
+			ExpectedError:                 fmt.Errorf(database.CustomerGetByIDDBError),
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			customerService := CustomerService{
+				LoggerSugar:                      loggerSugar,
+				CustomerDomainCacheRepository:    test.CustomerDomainCacheRepository,
+				CustomerDomainDataBaseRepository: test.CustomerDomainDataBaseRepository,
+			}
+
+			contextControl := domain.ContextControl{
+				Context: context.Background(),
+			}
+
+			customer, exists, err := customerService.GetByID(contextControl, test.ID)
+			assert.Equal(t, test.ExpectedResult, customer)
+			assert.Equal(t, test.ExpectedExists, exists)
+			assert.Equal(t, test.ExpectedError, err)
+
+		})
+	}
+}
+
+func TestCustomerService_GetByID_CrossTenant(t *testing.T) {
+
+	// the repository mock behaves as the contract scope of the postgres adapter
+	storedCustomer := domain.CustomerDomain{ID: 5, Name: "Fulano", ContractID: 1}
+	customerRepository := output.CustomerDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+			if ID != storedCustomer.ID || contextControl.ScopedContractID(storedCustomer.ContractID) != storedCustomer.ContractID {
+				return domain.CustomerDomain{}, false, nil
+			}
+			return storedCustomer, true, nil
+		},
+	}
+
+	cache := map[string]string{}
+	customerService := CustomerService{
+		LoggerSugar:                      loggerSugar,
+		CustomerDomainDataBaseRepository: customerRepository,
+		CustomerDomainCacheRepository: output.CustomerDomainCacheRepositoryMock{
+			GetMock: func(contextControl domain.ContextControl, key string) (string, error) {
+				return cache[key], nil
+			},
+			SetMock: func(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error {
+				cache[key] = hash
+				return nil
+			},
+		},
+	}
+
+	ownerContext := domain.ContextControl{Context: context.Background(), ContractID: 1}
+	otherContext := domain.ContextControl{Context: context.Background(), ContractID: 2}
+
+	customer, exists, err := customerService.GetByID(ownerContext, 5)
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, storedCustomer, customer)
+	assert.Contains(t, cache, CustomerCacheKey.Build("1:5"))
+
+	// the entry cached for contract 1 must not be served to contract 2
+	customer, exists, err = customerService.GetByID(otherContext, 5)
+	assert.Nil(t, err)
+	assert.False(t, exists)
+	assert.Equal(t, domain.CustomerDomain{}, customer)
+}
+
+func TestCustomerService_Update(t *testing.T) {
+
+	storedCustomer := domain.CustomerDomain{
+		ID:         1,
+		Name:       "Fulano",
+		Document:   "29623057091",
+		PersonType: TypePersonIndividual,
+		AddressID:  1,
+		ContractID: 1,
+		Email:      "fulano@email.com",
+	}
+
+	stored := output.CustomerDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+			return storedCustomer, true, nil
+		},
+	}
+
+	tests := []struct {
+		Name                             string
+		Customer                         domain.CustomerDomain
+		CustomerDomainDataBaseRepository output.ICustomerDomainDataBaseRepository
+		ExpectedResult                   domain.CustomerDomain
+		ExpectedExists                   bool
+		ExpectedError                    error
+		ExpectedCacheDeleted             bool
+	}{
+		{
+			Name: "WithValidCustomer_UpdatesAndInvalidatesTheCache",
+			Customer: domain.CustomerDomain{ID: 1, Name: " Ciclano ", Email: "Ciclano@Email.com",
+				Document: "11.222.333/0001-81", PersonType: TypePersonLegal},
+			CustomerDomainDataBaseRepository: stored,
+			ExpectedResult: domain.CustomerDomain{ID: 1, Name: "Ciclano", Email: "ciclano@email.com",
+				Document: "11222333000181", PersonType: TypePersonLegal, AddressID: 1, ContractID: 1},
+			ExpectedExists:       true,
+			ExpectedCacheDeleted: true,
+		},
+		{
+			Name: "WithDocumentNotMatchingThePersonType_ReturnsValidationError",
+			Customer: domain.CustomerDomain{ID: 1, Name: "Fulano", Email: "fulano@email.com",
+				Document: "29623057091", PersonType: TypePersonLegal},
+			CustomerDomainDataBaseRepository: stored,
+			ExpectedExists:                   true,
+			ExpectedError:                    domain.ErrValidation,
+		},
+		{
+			Name: "WithoutName_ReturnsValidationError",
+			Customer: domain.CustomerDomain{ID: 1, Email: "fulano@email.com",
+				Document: "29623057091", PersonType: TypePersonIndividual},
+			CustomerDomainDataBaseRepository: stored,
+			ExpectedExists:                   true,
+			ExpectedError:                    domain.ErrValidation,
+		},
+		{
+			Name:     "WithUnknownOrDeletedCustomer_ReturnsNotFound",
+			Customer: domain.CustomerDomain{ID: 2, Name: "Fulano"},
+			CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+					return domain.CustomerDomain{}, false, nil
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			cacheDeleted := false
+			customerService := CustomerService{
+				LoggerSugar:                      loggerSugar,
+				CustomerDomainDataBaseRepository: test.CustomerDomainDataBaseRepository,
+				CustomerDomainCacheRepository: output.CustomerDomainCacheRepositoryMock{
+					DeleteMock: func(contextControl domain.ContextControl, key string) error {
+						cacheDeleted = key == CustomerCacheKey.BuildID(1)
+						return nil
+					},
+				},
+			}
+
+			customer, exists, err := customerService.Update(domain.ContextControl{Context: context.Background()}, test.Customer)
+			assert.Equal(t, test.ExpectedResult, customer)
+			assert.Equal(t, test.ExpectedExists, exists)
+			if test.ExpectedError == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.ExpectedError)
+			}
+			assert.Equal(t, test.ExpectedCacheDeleted, cacheDeleted)
+		})
+	}
+}
+
+func TestCustomerService_Patch(t *testing.T) {
+
+	storedCustomer := domain.CustomerDomain{
+		ID:         1,
+		Name:       "Fulano",
+		Document:   "29623057091",
+		PersonType: TypePersonIndividual,
+		AddressID:  1,
+		ContractID: 1,
+		Email:      "fulano@email.com",
+	}
+
+	var updated domain.CustomerDomain
+	customerService := CustomerService{
+		LoggerSugar: loggerSugar,
+		CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+				return storedCustomer, true, nil
+			},
+			UpdateMock: func(contextControl domain.ContextControl, customer domain.CustomerDomain) error {
+				updated = customer
+				return nil
+			},
+		},
+		CustomerDomainCacheRepository: output.CustomerDomainCacheRepositoryMock{},
+	}
+	contextControl := domain.ContextControl{Context: context.Background()}
+
+	name := "Ciclano"
+	customer, exists, err := customerService.Patch(contextControl, 1, domain.CustomerPatchDomain{Name: &name})
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, "Ciclano", customer.Name)
+	assert.Equal(t, storedCustomer.Email, customer.Email)
+	assert.Equal(t, storedCustomer.Document, customer.Document)
+	assert.Equal(t, customer, updated)
+
+	// changing only the person type still validates it against the kept document
+	personType := TypePersonLegal
+	_, exists, err = customerService.Patch(contextControl, 1, domain.CustomerPatchDomain{PersonType: &personType})
+	assert.True(t, exists)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestCustomerService_DeleteAndRestore(t *testing.T) {
+
+	// the repository mock behaves as the soft delete of the postgres adapter
+	storedCustomer := domain.CustomerDomain{ID: 1, Name: "Fulano", ContractID: 1}
+	customerRepository := output.CustomerDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+			if ID != storedCustomer.ID || storedCustomer.DateDeleted != nil {
+				return domain.CustomerDomain{}, false, nil
+			}
+			return storedCustomer, true, nil
+		},
+		GetByIDIncludingDeletedMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+			if ID != storedCustomer.ID {
+				return domain.CustomerDomain{}, false, nil
+			}
+			return storedCustomer, true, nil
+		},
+		DeleteMock: func(contextControl domain.ContextControl, ID int64, deletedAt time.Time) error {
+			storedCustomer.DateDeleted = &deletedAt
+			return nil
+		},
+		RestoreMock: func(contextControl domain.ContextControl, ID int64) error {
+			storedCustomer.DateDeleted = nil
+			return nil
+		},
+	}
+
+	cache := map[string]string{}
+	customerService := CustomerService{
+		LoggerSugar:                      loggerSugar,
+		CustomerDomainDataBaseRepository: customerRepository,
+		CustomerDomainCacheRepository: output.CustomerDomainCacheRepositoryMock{
+			GetMock: func(contextControl domain.ContextControl, key string) (string, error) {
+				return cache[key], nil
+			},
+			SetMock: func(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error {
+				cache[key] = hash
+				return nil
+			},
+			DeleteMock: func(contextControl domain.ContextControl, key string) error {
+				delete(cache, key)
+				return nil
+			},
+		},
+	}
+	contextControl := domain.ContextControl{Context: context.Background(), ContractID: 1}
+
+	_, exists, err := customerService.GetByID(contextControl, 1)
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Contains(t, cache, CustomerCacheKey.Build("1:1"))
+
+	exists, err = customerService.Delete(contextControl, 1)
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Empty(t, cache)
+
+	// a deleted customer is hidden from reads and can't be deleted again
+	_, exists, err = customerService.GetByID(contextControl, 1)
+	assert.Nil(t, err)
+	assert.False(t, exists)
+	exists, err = customerService.Delete(contextControl, 1)
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	customer, exists, err := customerService.Restore(contextControl, 1)
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Nil(t, customer.DateDeleted)
+
+	_, exists, err = customerService.GetByID(contextControl, 1)
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	// restoring a customer not deleted changes nothing
+	customer, exists, err = customerService.Restore(contextControl, 1)
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, storedCustomer, customer)
+
+	_, exists, err = customerService.Restore(contextControl, 2)
+	assert.Nil(t, err)
+	assert.False(t, exists)
+}
+
+func TestCustomerService_Delete_ChangedByAnotherRequest(t *testing.T) {
+
+	transactionManager := &output.TransactionManagerMock{}
+	var histories int
+	var invalidated bool
+	customerService := CustomerService{
+		LoggerSugar: loggerSugar,
+		CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+				return domain.CustomerDomain{ID: ID, Name: "Fulano", ContractID: 1}, true, nil
+			},
+			// another request deleted the customer between the read and the write
+			DeleteMock: func(contextControl domain.ContextControl, ID int64, deletedAt time.Time) error {
+				return domain.NewConflictError("the customer %d was deleted or restored by another request", ID)
+			},
+			SaveHistoryMock: func(contextControl domain.ContextControl, history domain.CustomerHistoryDomain) error {
+				histories++
+				return nil
+			},
+		},
+		CustomerDomainCacheRepository: output.CustomerDomainCacheRepositoryMock{
+			DeleteMock: func(contextControl domain.ContextControl, key string) error {
+				invalidated = true
+				return nil
+			},
+		},
+		TransactionManager: transactionManager,
+	}
+
+	exists, err := customerService.Delete(domain.ContextControl{Context: context.Background(), ContractID: 1}, 1)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	assert.True(t, exists)
+	assert.Zero(t, histories)
+	assert.False(t, invalidated)
+	assert.Equal(t, 1, transactionManager.RolledBack())
+}
+
+func TestCustomerService_List(t *testing.T) {
+
+	tests := []struct {
+		Name           string
+		Filter         domain.CustomerFilterDomain
+		Page           domain.PageDomain
+		ExpectedFilter domain.CustomerFilterDomain
+		ExpectedPage   domain.PageDomain
+		ExpectedError  error
+	}{
+		{
+			Name:           "WithFilters_NormalizesThem",
+			Filter:         domain.CustomerFilterDomain{Name: " Ful ", Document: "296.230.570-91", Email: "Fulano@Email.com"},
+			Page:           domain.PageDomain{Size: 10, Cursor: 3},
+			ExpectedFilter: domain.CustomerFilterDomain{Name: "ful", Document: "29623057091", Email: "fulano@email.com"},
+			ExpectedPage:   domain.PageDomain{Size: 10, Cursor: 3},
+		},
+		{
+			Name:         "WithoutPageSize_UsesTheDefault",
+			ExpectedPage: domain.PageDomain{Size: DefaultPageSize},
+		},
+		{
+			Name:          "WithPageSizeAboveTheMax_ReturnsValidationError",
+			Page:          domain.PageDomain{Size: MaxPageSize + 1},
+			ExpectedError: domain.ErrValidation,
+		},
+		{
+			Name:          "WithNegativeCursor_ReturnsValidationError",
+			Page:          domain.PageDomain{Cursor: -1},
+			ExpectedError: domain.ErrValidation,
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			var filterAsked domain.CustomerFilterDomain
+			var pageAsked domain.PageDomain
+			customerService := CustomerService{
+				LoggerSugar: loggerSugar,
+				CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+					ListMock: func(contextControl domain.ContextControl, filter domain.CustomerFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerDomain], error) {
+						filterAsked, pageAsked = filter, page
+						return domain.PageResultDomain[domain.CustomerDomain]{}, nil
+					},
+				},
+			}
+
+			_, err := customerService.List(domain.ContextControl{Context: context.Background()}, test.Filter, test.Page)
+			if test.ExpectedError == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.ExpectedError)
+			}
+			assert.Equal(t, test.ExpectedFilter, filterAsked)
+			assert.Equal(t, test.ExpectedPage, pageAsked)
+		})
+	}
+}
+
+func TestCustomerService_History(t *testing.T) {
+
+	storedCustomer := domain.CustomerDomain{
+		ID:         1,
+		Name:       "Fulano",
+		Document:   "29623057091",
+		PersonType: TypePersonIndividual,
+		AddressID:  1,
+		ContractID: 1,
+		Email:      "fulano@email.com",
+	}
+
+	var histories []domain.CustomerHistoryDomain
+	var historyInTransaction bool
+	newService := func(saveHistoryErr error) (CustomerService, *output.TransactionManagerMock) {
+		histories, historyInTransaction = nil, false
+		transactionManager := &output.TransactionManagerMock{}
+		return CustomerService{
+			LoggerSugar: loggerSugar,
+			CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+					return storedCustomer, true, nil
+				},
+				GetByIDIncludingDeletedMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+					deleted := storedCustomer
+					deletedAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+					deleted.DateDeleted = &deletedAt
+					return deleted, true, nil
+				},
+				SaveHistoryMock: func(contextControl domain.ContextControl, history domain.CustomerHistoryDomain) error {
+					histories = append(histories, history)
+					historyInTransaction = output.InTransaction(contextControl)
+					return saveHistoryErr
+				},
+			},
+			CustomerDomainCacheRepository: output.CustomerDomainCacheRepositoryMock{},
+			TransactionManager:            transactionManager,
+		}, transactionManager
+	}
+
+	contextControl := domain.ContextControl{Context: context.Background(), RequestID: "req-1", Actor: "support@petshop.com"}
+
+	t.Run("WithChangedEmail_RecordsTheActorTheRequestAndTheDiff", func(t *testing.T) {
+
+		customerService, transactionManager := newService(nil)
+		email := "Ciclano@Email.com"
+		_, _, err := customerService.Patch(contextControl, 1, domain.CustomerPatchDomain{Email: &email})
+		assert.Nil(t, err)
+
+		assert.Equal(t, []domain.CustomerHistoryDomain{{
+			CustomerID:  1,
+			Action:      domain.CustomerActionUpdated,
+			Actor:       "support@petshop.com",
+			RequestID:   "req-1",
+			Description: "customer updated",
+			Changes: map[string]domain.CustomerFieldChangeDomain{
+				"email": {Before: "fulano@email.com", After: "ciclano@email.com"},
+			},
+		}}, histories)
+		assert.True(t, historyInTransaction)
+		assert.Equal(t, 1, transactionManager.Committed())
+	})
+
+	t.Run("WithoutChange_RecordsNothing", func(t *testing.T) {
+
+		customerService, _ := newService(nil)
+		_, _, err := customerService.Update(contextControl, storedCustomer)
+		assert.Nil(t, err)
+		assert.Empty(t, histories)
+	})
+
+	t.Run("WithoutActor_RecordsTheUnknownActor", func(t *testing.T) {
+
+		customerService, _ := newService(nil)
+		_, err := customerService.Delete(domain.ContextControl{Context: context.Background()}, 1)
+		assert.Nil(t, err)
+		assert.Len(t, histories, 1)
+		assert.Equal(t, domain.CustomerActionDeleted, histories[0].Action)
+		assert.Equal(t, CustomerHistoryUnknownActor, histories[0].Actor)
+		assert.Contains(t, histories[0].Changes, "date_deleted")
+	})
+
+	t.Run("WithRestore_RecordsTheDeletionDateCleared", func(t *testing.T) {
+
+		customerService, _ := newService(nil)
+		_, _, err := customerService.Restore(contextControl, 1)
+		assert.Nil(t, err)
+		assert.Len(t, histories, 1)
+		assert.Equal(t, domain.CustomerActionRestored, histories[0].Action)
+		assert.Nil(t, histories[0].Changes["date_deleted"].After)
+	})
+
+	t.Run("WithHistoryError_RollsBackTheChange", func(t *testing.T) {
+
+		customerService, transactionManager := newService(fmt.Errorf(database.CustomerHistorySaveDBError))
+		_, err := customerService.Delete(contextControl, 1)
+		assert.EqualError(t, err, database.CustomerHistorySaveDBError)
+		assert.Equal(t, 1, transactionManager.RolledBack())
+		assert.Equal(t, 0, transactionManager.Committed())
+	})
+}
+
+func TestCustomerService_GetHistory(t *testing.T) {
+
+	customerService := CustomerService{
+		LoggerSugar: loggerSugar,
+		CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+			GetByIDIncludingDeletedMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+				return domain.CustomerDomain{ID: ID}, ID == 1, nil
+			},
+			GetHistoryMock: func(contextControl domain.ContextControl, customerID int64, page domain.PageDomain) (domain.PageResultDomain[domain.CustomerHistoryDomain], error) {
+				return domain.PageResultDomain[domain.CustomerHistoryDomain]{
+					Items:         []domain.CustomerHistoryDomain{{ID: 3, CustomerID: customerID, Action: domain.CustomerActionCreated}},
+					TotalEstimate: 1,
+				}, nil
+			},
+		},
+	}
+	contextControl := domain.ContextControl{Context: context.Background()}
+
+	history, exists, err := customerService.GetHistory(contextControl, 1, domain.PageDomain{})
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Len(t, history.Items, 1)
+
+	_, exists, err = customerService.GetHistory(contextControl, 2, domain.PageDomain{})
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	_, _, err = customerService.GetHistory(contextControl, 1, domain.PageDomain{Size: -1})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/petshop-system/petshop-api/application/utils"
+	"go.uber.org/zap"
+)
+
+type EmployeeService struct {
+	LoggerSugar                      *zap.SugaredLogger
+	EmployeeDomainDataBaseRepository output.IEmployeeDomainDataBaseRepository
+	ContractDomainDataBaseRepository output.IContractDomainDataBaseRepository
+}
+
+const (
+	EmployeeNameIsRequired        = "employee name is required"
+	EmployeeRegisterIsRequired    = "employee register is required"
+	EmployeeDocumentIsInvalid     = "employee document is invalid: %s"
+	EmployeeContractNotFound      = "the contract with id %d wasn't found"
+	EmployeeRegisterAlreadyExists = "there is already an employee with the register %s"
+	EmployeeDocumentAlreadyExists = "there is already an employee with the document %s"
+)
+
+// Create validates the employee and stores it, active, in the contract of the request.
+func (service *EmployeeService) Create(contextControl domain.ContextControl, employee domain.EmployeeDomain) (domain.EmployeeDomain, error) {
+
+	employee = normalizeEmployee(employee)
+	if err := service.ValidateEmployee(employee); err != nil {
+		return domain.EmployeeDomain{}, err
+	}
+
+	employee.ContractID = contextControl.ScopedContractID(employee.ContractID)
+	_, exists, err := service.ContractDomainDataBaseRepository.GetByID(contextControl, employee.ContractID)
+	if err != nil {
+		return domain.EmployeeDomain{}, err
+	}
+	if !exists {
+		return domain.EmployeeDomain{}, domain.NewValidationError(EmployeeContractNotFound, employee.ContractID)
+	}
+
+	if err = service.checkUniqueKeys(contextControl, employee); err != nil {
+		return domain.EmployeeDomain{}, err
+	}
+
+	employee.Active = true
+	return service.EmployeeDomainDataBaseRepository.Save(contextControl, employee)
+}
+
+// Update changes the name, register and document of a known employee.
+// The contract, the creation date and the activation are kept.
+func (service *EmployeeService) Update(contextControl domain.ContextControl, employee domain.EmployeeDomain) (domain.EmployeeDomain, bool, error) {
+
+	current, exists, err := service.EmployeeDomainDataBaseRepository.GetByID(contextControl, employee.ID)
+	if err != nil || !exists {
+		return domain.EmployeeDomain{}, false, err
+	}
+
+	employee = normalizeEmployee(employee)
+	if err = service.ValidateEmployee(employee); err != nil {
+		return domain.EmployeeDomain{}, true, err
+	}
+
+	if err = service.checkUniqueKeys(contextControl, employee); err != nil {
+		return domain.EmployeeDomain{}, true, err
+	}
+
+	if err = service.EmployeeDomainDataBaseRepository.Update(contextControl, employee); err != nil {
+		return domain.EmployeeDomain{}, true, err
+	}
+
+	current.Name = employee.Name
+	current.Register = employee.Register
+	current.Document = employee.Document
+
+	return current, true, nil
+}
+
+// SetActive activates or deactivates a known employee. Inactive employees can't receive
+// new attention times nor new schedules.
+func (service *EmployeeService) SetActive(contextControl domain.ContextControl, ID int64, active bool) (domain.EmployeeDomain, bool, error) {
+
+	employee, exists, err := service.EmployeeDomainDataBaseRepository.GetByID(contextControl, ID)
+	if err != nil || !exists {
+		return domain.EmployeeDomain{}, false, err
+	}
+
+	if employee.Active == active {
+		return employee, true, nil
+	}
+
+	if err = service.EmployeeDomainDataBaseRepository.SetActive(contextControl, ID, active); err != nil {
+		return domain.EmployeeDomain{}, true, err
+	}
+
+	employee.Active = active
+	return employee, true, nil
+}
+
+func (service *EmployeeService) GetByID(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+	return service.EmployeeDomainDataBaseRepository.GetByID(contextControl, ID)
+}
+
+func (service *EmployeeService) GetAll(contextControl domain.ContextControl) ([]domain.EmployeeDomain, error) {
+	return service.EmployeeDomainDataBaseRepository.GetAll(contextControl)
+}
+
+// ValidateEmployee checks the name, the register and the CPF of the employee.
+func (service *EmployeeService) ValidateEmployee(employee domain.EmployeeDomain) error {
+
+	if len(employee.Name) == 0 {
+		return domain.NewValidationError(EmployeeNameIsRequired)
+	}
+
+	if len(employee.Register) == 0 {
+		return domain.NewValidationError(EmployeeRegisterIsRequired)
+	}
+
+	if err := utils.ValidateCpf(employee.Document); err != nil {
+		return domain.NewValidationError(EmployeeDocumentIsInvalid, err.Error())
+	}
+
+	return nil
+}
+
+// checkUniqueKeys reports a conflict when another employee already uses the register or the document.
+// The unique indexes still guard against concurrent requests.
+func (service *EmployeeService) checkUniqueKeys(contextControl domain.ContextControl, employee domain.EmployeeDomain) error {
+
+	byRegister, exists, err := service.EmployeeDomainDataBaseRepository.GetByRegister(contextControl, employee.Register)
+	if err != nil {
+		return err
+	}
+	if exists && byRegister.ID != employee.ID {
+		return domain.NewConflictError(EmployeeRegisterAlreadyExists, employee.Register)
+	}
+
+	byDocument, exists, err := service.EmployeeDomainDataBaseRepository.GetByDocument(contextControl, employee.Document)
+	if err != nil {
+		return err
+	}
+	if exists && byDocument.ID != employee.ID {
+		return domain.NewConflictError(EmployeeDocumentAlreadyExists, employee.Document)
+	}
+
+	return nil
+}
+
+func normalizeEmployee(employee domain.EmployeeDomain) domain.EmployeeDomain {
+	employee.Name = strings.TrimSpace(employee.Name)
+	employee.Register = strings.ToUpper(strings.TrimSpace(employee.Register))
+	employee.Document = utils.RemoveNonAlphaNumericCharacters(employee.Document)
+	return employee
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmployeeService_Create(t *testing.T) {
+
+	validEmployee := domain.EmployeeDomain{
+		Name:     " Fulana da Silva ",
+		Register: "func-0010",
+		Document: "636.099.310-43",
+	}
+
+	savedEmployee := domain.EmployeeDomain{
+		ID:         10,
+		Name:       "Fulana da Silva",
+		Register:   "FUNC-0010",
+		Document:   "63609931043",
+		Active:     true,
+		ContractID: 1,
+	}
+
+	existingContract := output.ContractDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error) {
+			return domain.ContractDomain{ID: ID}, true, nil
+		},
+	}
+
+	tests := []struct {
+		Name                             string
+		Employee                         domain.EmployeeDomain
+		EmployeeDomainDataBaseRepository output.IEmployeeDomainDataBaseRepository
+		ContractDomainDataBaseRepository output.IContractDomainDataBaseRepository
+		ExpectedResult                   domain.EmployeeDomain
+		ExpectedError                    error
+	}{
+		{
+			Name:     "WithValidEmployee_SavesItActiveInTheContractOfTheRequest",
+			Employee: validEmployee,
+			EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{
+				SaveMock: func(contextControl domain.ContextControl, employee domain.EmployeeDomain) (domain.EmployeeDomain, error) {
+					assert.Equal(t, "FUNC-0010", employee.Register)
+					assert.Equal(t, "63609931043", employee.Document)
+					assert.Equal(t, int64(1), employee.ContractID)
+					assert.True(t, employee.Active)
+					return savedEmployee, nil
+				},
+			},
+			ContractDomainDataBaseRepository: existingContract,
+			ExpectedResult:                   savedEmployee,
+		},
+		{
+			Name: "WithInvalidCpf_ReturnsValidationError",
+			Employee: func() domain.EmployeeDomain {
+				employee := validEmployee
+				employee.Document = "111.111.111-11"
+				return employee
+			}(),
+			EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{},
+			ContractDomainDataBaseRepository: existingContract,
+			ExpectedError:                    domain.ErrValidation,
+		},
+		{
+			Name: "WithoutRegister_ReturnsValidationError",
+			Employee: func() domain.EmployeeDomain {
+				employee := validEmployee
+				employee.Register = " "
+				return employee
+			}(),
+			EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{},
+			ContractDomainDataBaseRepository: existingContract,
+			ExpectedError:                    domain.ErrValidation,
+		},
+		{
+			Name:                             "WithUnknownContract_ReturnsValidationError",
+			Employee:                         validEmployee,
+			EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{},
+			ContractDomainDataBaseRepository: output.ContractDomainDataBaseRepositoryMock{},
+			ExpectedError:                    domain.ErrValidation,
+		},
+		{
+			Name:     "WithRegisterOfAnotherEmployee_ReturnsConflict",
+			Employee: validEmployee,
+			EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{
+				GetByRegisterMock: func(contextControl domain.ContextControl, register string) (domain.EmployeeDomain, bool, error) {
+					return domain.EmployeeDomain{ID: 2, Register: register}, true, nil
+				},
+			},
+			ContractDomainDataBaseRepository: existingContract,
+			ExpectedError:                    domain.ErrConflict,
+		},
+		{
+			Name:     "WithDocumentOfAnotherEmployee_ReturnsConflict",
+			Employee: validEmployee,
+			EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{
+				GetByDocumentMock: func(contextControl domain.ContextControl, document string) (domain.EmployeeDomain, bool, error) {
+					return domain.EmployeeDomain{ID: 2, Document: document}, true, nil
+				},
+			},
+			ContractDomainDataBaseRepository: existingContract,
+			ExpectedError:                    domain.ErrConflict,
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			employeeService := EmployeeService{
+				LoggerSugar:                      loggerSugar,
+				EmployeeDomainDataBaseRepository: test.EmployeeDomainDataBaseRepository,
+				ContractDomainDataBaseRepository: test.ContractDomainDataBaseRepository,
+			}
+
+			contextControl := domain.ContextControl{Context: context.Background(), ContractID: 1}
+			employee, err := employeeService.Create(contextControl, test.Employee)
+			assert.Equal(t, test.ExpectedResult, employee)
+			if test.ExpectedError == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.ExpectedError)
+			}
+		})
+	}
+}
+
+func TestEmployeeService_SetActive(t *testing.T) {
+
+	tests := []struct {
+		Name           string
+		Current        domain.EmployeeDomain
+		Exists         bool
+		Active         bool
+		ExpectedUpdate bool
+		ExpectedExists bool
+	}{
+		{
+			Name:           "WithActiveEmployee_Deactivates",
+			Current:        domain.EmployeeDomain{ID: 1, Active: true},
+			Exists:         true,
+			Active:         false,
+			ExpectedUpdate: true,
+			ExpectedExists: true,
+		},
+		{
+			Name:           "WithAlreadyActiveEmployee_KeepsIt",
+			Current:        domain.EmployeeDomain{ID: 1, Active: true},
+			Exists:         true,
+			Active:         true,
+			ExpectedUpdate: false,
+			ExpectedExists: true,
+		},
+		{
+			Name:           "WithUnknownEmployee_ReturnsNotFound",
+			Exists:         false,
+			Active:         true,
+			ExpectedUpdate: false,
+			ExpectedExists: false,
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			updated := false
+			employeeService := EmployeeService{
+				LoggerSugar: loggerSugar,
+				EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{
+					GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+						return test.Current, test.Exists, nil
+					},
+					SetActiveMock: func(contextControl domain.ContextControl, ID int64, active bool) error {
+						updated = true
+						return nil
+					},
+				},
+			}
+
+			employee, exists, err := employeeService.SetActive(domain.ContextControl{Context: context.Background()}, 1, test.Active)
+			assert.Nil(t, err)
+			assert.Equal(t, test.ExpectedExists, exists)
+			assert.Equal(t, test.ExpectedUpdate, updated)
+			if exists {
+				assert.Equal(t, test.Active, employee.Active)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+)
+
+// EventOutboxIsRequired fails the changes of a service built without an outbox, whose events
+// would be lost otherwise.
+const EventOutboxIsRequired = "the service has no outbox to store its events"
+
+// saveWithEvents runs save in a transaction and stores the events it returns in the outbox, in
+// the same transaction. The outbox relay publishes them once committed, so a change is never
+// announced when rolled back nor left unannounced when committed. Services built without a
+// transaction manager run save alone; without an outbox they fail instead of losing the events.
+func saveWithEvents(transactionManager output.ITransactionManager, outbox output.IOutboxDomainDataBaseRepository,
+	contextControl domain.ContextControl, save func(txControl domain.ContextControl) ([]domain.EventDomain, error)) error {
+
+	if outbox == nil {
+		return errors.New(EventOutboxIsRequired)
+	}
+
+	return withinTransaction(transactionManager, contextControl, func(txControl domain.ContextControl) error {
+		events, err := save(txControl)
+		if err != nil {
+			return err
+		}
+		return outbox.Save(txControl, events...)
+	})
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/stretchr/testify/assert"
+)
+
+// outboxCapture is an outbox keeping the stored events in events.
+func outboxCapture(events *[]domain.EventDomain) output.OutboxDomainDataBaseRepositoryMock {
+	return output.OutboxDomainDataBaseRepositoryMock{
+		SaveMock: func(contextControl domain.ContextControl, stored ...domain.EventDomain) error {
+			*events = append(*events, stored...)
+			return nil
+		},
+	}
+}
+
+func TestCustomerService_Create_StoresCustomerCreated(t *testing.T) {
+
+	newCustomerService := func(outbox output.IOutboxDomainDataBaseRepository) *CustomerService {
+		return &CustomerService{
+			LoggerSugar: loggerSugar,
+			CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+				SaveMock: func(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, error) {
+					customer.ID = 5
+					return customer, nil
+				},
+			},
+			CustomerDomainCacheRepository: output.CustomerDomainCacheRepositoryMock{},
+			ContractDomainDataBaseRepository: output.ContractDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error) {
+					return domain.ContractDomain{ID: ID}, true, nil
+				},
+			},
+			TransactionManager:             &output.TransactionManagerMock{},
+			OutboxDomainDataBaseRepository: outbox,
+		}
+	}
+
+	customer := domain.CustomerDomain{Name: "Fulano", Document: "296.230.570-91", PersonType: TypePersonIndividual,
+		Email: "fulano@email.com", AddressID: 1}
+	contextControl := domain.ContextControl{Context: context.Background(), RequestID: "host/abc-000001", ContractID: 1}
+
+	t.Run("WithSavedCustomer_StoresTheEventInTheOutbox", func(t *testing.T) {
+
+		var events []domain.EventDomain
+		_, err := newCustomerService(outboxCapture(&events)).Create(contextControl, customer)
+		assert.Nil(t, err)
+
+		assert.Len(t, events, 1)
+		assert.Equal(t, domain.EventCustomerCreated, events[0].Type)
+		assert.Equal(t, domain.CustomerEventVersion, events[0].Version)
+		assert.Equal(t, "5", events[0].Key)
+		assert.Equal(t, "host/abc-000001", events[0].CorrelationID)
+		assert.NotEmpty(t, events[0].ID)
+		assert.Equal(t, domain.CustomerEvent{ID: 5, Name: "Fulano", Email: "fulano@email.com",
+			PersonType: TypePersonIndividual, ContractID: 1}, events[0].Payload)
+	})
+
+	t.Run("WithoutOutbox_FailsTheCreation", func(t *testing.T) {
+
+		_, err := newCustomerService(nil).Create(contextControl, customer)
+		assert.EqualError(t, err, EventOutboxIsRequired)
+	})
+
+	t.Run("WithOutboxError_RollsBackTheCustomer", func(t *testing.T) {
+
+		transactionManager := &output.TransactionManagerMock{}
+		customerService := newCustomerService(output.OutboxDomainDataBaseRepositoryMock{
+			SaveMock: func(contextControl domain.ContextControl, events ...domain.EventDomain) error {
+				return errors.New("connection refused")
+			},
+		})
+		customerService.TransactionManager = transactionManager
+
+		_, err := customerService.Create(contextControl, customer)
+		assert.EqualError(t, err, "connection refused")
+		assert.Equal(t, 1, transactionManager.RolledBack())
+		assert.Zero(t, transactionManager.Committed())
+	})
+
+	t.Run("SavesTheCustomerAndTheEventInTheSameTransaction", func(t *testing.T) {
+
+		var savedInTransaction, storedInTransaction bool
+		transactionManager := &output.TransactionManagerMock{}
+
+		customerService := newCustomerService(output.OutboxDomainDataBaseRepositoryMock{
+			SaveMock: func(contextControl domain.ContextControl, events ...domain.EventDomain) error {
+				storedInTransaction = output.InTransaction(contextControl)
+				return nil
+			},
+		})
+		customerService.TransactionManager = transactionManager
+		customerService.CustomerDomainDataBaseRepository = output.CustomerDomainDataBaseRepositoryMock{
+			SaveMock: func(contextControl domain.ContextControl, customer domain.CustomerDomain) (domain.CustomerDomain, error) {
+				savedInTransaction = output.InTransaction(contextControl)
+				return customer, nil
+			},
+		}
+
+		_, err := customerService.Create(contextControl, customer)
+		assert.Nil(t, err)
+		assert.True(t, savedInTransaction)
+		assert.True(t, storedInTransaction)
+		assert.Equal(t, 1, transactionManager.Committed())
+	})
+}
+
+func TestPetService_Create_StoresPetCreated(t *testing.T) {
+
+	birthday := time.Date(2016, time.December, 12, 0, 0, 0, 0, time.UTC)
+	var events []domain.EventDomain
+
+	petService := &PetService{
+		LoggerSugar: loggerSugar,
+		PetDomainDataBaseRepository: output.PetDomainDataBaseRepositoryMock{
+			SaveMock: func(contextControl domain.ContextControl, pet domain.PetDomain) (domain.PetDomain, error) {
+				pet.ID = 3
+				return pet, nil
+			},
+		},
+		PetDomainCacheRepository: output.PetDomainCacheRepositoryMock{},
+		CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+				return domain.CustomerDomain{ID: ID, ContractID: 1}, true, nil
+			},
+		},
+		BreedDomainDataBaseRepository: output.BreedDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.BreedDomain, bool, error) {
+				return domain.BreedDomain{ID: ID}, true, nil
+			},
+		},
+		TransactionManager:             &output.TransactionManagerMock{},
+		OutboxDomainDataBaseRepository: outboxCapture(&events),
+	}
+
+	_, err := petService.Create(domain.ContextControl{Context: context.Background(), RequestID: "host/abc-000002"},
+		domain.PetDomain{Name: "Rex", DateBirthday: birthday, CustomerID: 1, BreedID: 2})
+	assert.Nil(t, err)
+
+	assert.Len(t, events, 1)
+	assert.Equal(t, domain.EventPetCreated, events[0].Type)
+	assert.Equal(t, "3", events[0].Key)
+	assert.Equal(t, "host/abc-000002", events[0].CorrelationID)
+	assert.Equal(t, domain.PetEvent{ID: 3, Name: "Rex", DateBirthday: birthday, CustomerID: 1, BreedID: 2, ContractID: 1},
+		events[0].Payload)
+}
+
+func TestScheduleService_StoresTheTransitions(t *testing.T) {
+
+	booking := time.Now().AddDate(0, 0, 1).Format(ScheduleBookingLayout)
+	bookedAt, _ := time.Parse(ScheduleBookingLayout, booking)
+	var events []domain.EventDomain
+
+	var stored domain.ScheduleDomain
+	scheduleService := ScheduleService{
+		LoggerSugar: loggerSugar,
+		ScheduleDomainDataBaseRepository: output.ScheduleDomainDataBaseRepositoryMock{
+			SaveMock: func(contextControl domain.ContextControl, schedule domain.ScheduleDomain, history domain.ScheduleHistoryDomain) (domain.ScheduleDomain, error) {
+				schedule.ID = 10
+				stored = schedule
+				return schedule, nil
+			},
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ScheduleDomain, bool, error) {
+				return stored, stored.ID == ID, nil
+			},
+		},
+		PetDomainDataBaseRepository: output.PetDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.PetDomain, bool, error) {
+				return domain.PetDomain{ID: ID, ContractID: 1}, true, nil
+			},
+		},
+		AttentionTimeDomainDataBaseRepository: output.AttentionTimeDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.AttentionTimeDomain, bool, error) {
+				return domain.AttentionTimeDomain{ID: ID, Active: true, ServiceID: 2, ContractID: 1, EmployeeID: 1}, true, nil
+			},
+		},
+		EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+				return domain.EmployeeDomain{ID: ID, Active: true, ContractID: 1}, true, nil
+			},
+		},
+		ServiceDomainDataBaseRepository: output.ServiceDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error) {
+				return domain.ServiceDomain{ID: ID, Price: 5599, Active: true, ContractID: 1}, true, nil
+			},
+		},
+		TransactionManager:             &output.TransactionManagerMock{},
+		OutboxDomainDataBaseRepository: outboxCapture(&events),
+	}
+
+	err := scheduleService.CreateFromMessage(domain.ContextControl{Context: context.Background(), RequestID: "schedule/0/7"},
+		domain.ScheduleMessage{Booking: booking, PetId: 1, ServiceEmployeeAttentionId: 2, Actor: "siclano"})
+	assert.Nil(t, err)
+
+	_, _, err = scheduleService.ApplyCommand(domain.ContextControl{Context: context.Background(), RequestID: "host/abc-000003"},
+		domain.ScheduleCommand{ScheduleID: 10, Action: domain.ScheduleActionConfirm, Actor: "fulana"})
+	assert.Nil(t, err)
+
+	assert.Len(t, events, 2)
+
+	assert.Equal(t, domain.EventScheduleRequested, events[0].Type)
+	assert.Equal(t, "schedule/0/7", events[0].CorrelationID)
+	assert.Equal(t, domain.ScheduleEvent{ID: 10, Status: "requested", BookedAt: booking, Price: 5599, PetID: 1,
+		AttentionTimeID: 2, Actor: "siclano", Number: FormatScheduleNumber(bookedAt, 0)}, events[0].Payload)
+
+	assert.Equal(t, domain.EventScheduleConfirmed, events[1].Type)
+	assert.Equal(t, domain.ScheduleEventVersion, events[1].Version)
+	assert.Equal(t, "host/abc-000003", events[1].CorrelationID)
+	assert.Equal(t, "confirmed", events[1].Payload.(domain.ScheduleEvent).Status)
+	assert.Equal(t, "fulana", events[1].Payload.(domain.ScheduleEvent).Actor)
+
+	// the events of a schedule share its key, so they keep their order
+	assert.Equal(t, "10", events[0].Key)
+	assert.Equal(t, events[0].Key, events[1].Key)
+}
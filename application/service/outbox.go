@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"go.uber.org/zap"
+)
+
+// OutboxRelayRequestID correlates the logs of the relay, which doesn't serve any request.
+const OutboxRelayRequestID = "outbox-relay"
+
+const (
+	OutboxRelayErrorToPublish = "error to publish the outbox event, it will be retried"
+	OutboxRelayParked         = "the outbox event failed too many times and was parked"
+	OutboxRelayErrorToRelay   = "error to relay the outbox events"
+	OutboxRelayErrorToClean   = "error to clean the published outbox events"
+	OutboxRelayLockedByOther  = "the outbox is being relayed by another instance"
+	OutboxRelaySuccessToClean = "published outbox events cleaned"
+)
+
+// OutboxRelayService publishes the events stored in the outbox and prunes the published ones.
+// The events of an aggregate are published in the order they were stored: while one of them
+// waits for a retry, the following ones of the same key wait too. An event failing MaxAttempts
+// times is parked, releasing its key; zero retries it forever. ClaimTimeout is how long a claimed
+// batch has to be published before another relay takes it again.
+type OutboxRelayService struct {
+	LoggerSugar                    *zap.SugaredLogger
+	OutboxDomainDataBaseRepository output.IOutboxDomainDataBaseRepository
+	TransactionManager             output.ITransactionManager
+	EventPublisher                 output.IEventPublisher
+	BatchSize                      int
+	PollInterval                   time.Duration
+	ClaimTimeout                   time.Duration
+	InitialBackoff                 time.Duration
+	MaxBackoff                     time.Duration
+	MaxAttempts                    int
+	Retention                      time.Duration
+	CleanupInterval                time.Duration
+}
+
+// Run relays the outbox every PollInterval and cleans it every CleanupInterval until ctx is done.
+func (service OutboxRelayService) Run(ctx context.Context) {
+
+	pollTicker := time.NewTicker(service.PollInterval)
+	defer pollTicker.Stop()
+
+	cleanupTicker := time.NewTicker(service.CleanupInterval)
+	defer cleanupTicker.Stop()
+
+	contextControl := domain.ContextControl{Context: ctx, RequestID: OutboxRelayRequestID}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-pollTicker.C:
+			if _, err := service.RelayPending(contextControl); err != nil && ctx.Err() == nil {
+				service.LoggerSugar.Errorw(OutboxRelayErrorToRelay, "error", err.Error())
+			}
+		case <-cleanupTicker.C:
+			if _, err := service.Cleanup(contextControl); err != nil && ctx.Err() == nil {
+				service.LoggerSugar.Errorw(OutboxRelayErrorToClean, "error", err.Error())
+			}
+		}
+	}
+}
+
+// RelayPending publishes a batch of pending events and returns how many were published. The batch
+// is claimed holding the outbox lock, so other instances skip it meanwhile, and published after
+// the lock is released, so a slow broker holds neither the lock nor a connection. A failed event
+// is retried after a backoff that doubles at each attempt up to MaxBackoff.
+func (service OutboxRelayService) RelayPending(contextControl domain.ContextControl) (int, error) {
+
+	now := time.Now()
+
+	var pending []domain.OutboxDomain
+	err := service.TransactionManager.WithinTransaction(contextControl, func(txControl domain.ContextControl) error {
+
+		locked, err := service.OutboxDomainDataBaseRepository.TryLock(txControl)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			service.LoggerSugar.Debugw(OutboxRelayLockedByOther)
+			return nil
+		}
+
+		pending, err = service.OutboxDomainDataBaseRepository.ClaimPending(txControl, now,
+			now.Add(service.ClaimTimeout), service.BatchSize)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	blockedKeys := make(map[string]bool)
+	for _, outbox := range pending {
+
+		// the following events of a failed one are claimed again once it is published or parked
+		if blockedKeys[outbox.Event.Key] {
+			continue
+		}
+
+		if errPublish := service.EventPublisher.Publish(contextControl, outbox.Event); errPublish != nil {
+			blockedKeys[outbox.Event.Key] = true
+			if err = service.failed(contextControl, outbox, errPublish); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		if err = service.OutboxDomainDataBaseRepository.MarkPublished(contextControl, outbox.ID, time.Now()); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// failed schedules the retry of an event whose publication failed, or parks it when it reached
+// MaxAttempts.
+func (service OutboxRelayService) failed(contextControl domain.ContextControl, outbox domain.OutboxDomain, errPublish error) error {
+
+	now := time.Now()
+	attempts := outbox.Attempts + 1
+
+	if service.MaxAttempts > 0 && attempts >= service.MaxAttempts {
+		service.LoggerSugar.Errorw(OutboxRelayParked, "outbox_id", outbox.ID, "event_type", outbox.Event.Type,
+			"event_id", outbox.Event.ID, "attempts", attempts, "error", errPublish.Error())
+		return service.OutboxDomainDataBaseRepository.Park(contextControl, outbox.ID, attempts, now, errPublish.Error())
+	}
+
+	service.LoggerSugar.Warnw(OutboxRelayErrorToPublish, "outbox_id", outbox.ID,
+		"event_type", outbox.Event.Type, "attempts", attempts, "error", errPublish.Error())
+	return service.OutboxDomainDataBaseRepository.MarkFailed(contextControl, outbox.ID, attempts,
+		now.Add(service.Backoff(attempts)), errPublish.Error())
+}
+
+// Backoff returns how long to wait before retrying an event that failed the given number of times.
+func (service OutboxRelayService) Backoff(attempts int) time.Duration {
+
+	backoff := service.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if service.MaxBackoff > 0 && backoff >= service.MaxBackoff {
+			return service.MaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+// Cleanup deletes the events published more than Retention ago, a batch at a time, and returns
+// how many were deleted.
+func (service OutboxRelayService) Cleanup(contextControl domain.ContextControl) (int64, error) {
+
+	before := time.Now().Add(-service.Retention)
+
+	var cleaned int64
+	for {
+		deleted, err := service.OutboxDomainDataBaseRepository.DeletePublishedBefore(contextControl, before, service.BatchSize)
+		if err != nil {
+			return cleaned, err
+		}
+		cleaned += deleted
+
+		if deleted == 0 || deleted < int64(service.BatchSize) || contextControl.Context.Err() != nil {
+			break
+		}
+	}
+
+	if cleaned > 0 {
+		service.LoggerSugar.Infow(OutboxRelaySuccessToClean, "deleted", cleaned, "before", before)
+	}
+
+	return cleaned, nil
+}
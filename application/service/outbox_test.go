@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/petshop-system/petshop-api/adapter/output/event"
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func newOutboxRow(ID int64, key string) domain.OutboxDomain {
+	return domain.OutboxDomain{
+		ID: ID,
+		Event: domain.EventDomain{ID: "event-" + key, Type: domain.EventScheduleConfirmed, Version: 1, Key: key,
+			Payload: json.RawMessage(`{"id":1}`)},
+	}
+}
+
+// failingPublisher fails the events of the key and publishes the others.
+type failingPublisher struct {
+	*event.InMemoryEventPublisher
+	key string
+}
+
+func (publisher failingPublisher) Publish(contextControl domain.ContextControl, events ...domain.EventDomain) error {
+	for _, e := range events {
+		if e.Key == publisher.key {
+			return errors.New("broker not available")
+		}
+	}
+	return publisher.InMemoryEventPublisher.Publish(contextControl, events...)
+}
+
+func TestOutboxRelayService_RelayPending(t *testing.T) {
+
+	contextControl := domain.ContextControl{Context: context.Background(), RequestID: OutboxRelayRequestID}
+
+	type failure struct {
+		ID       int64
+		Attempts int
+		Backoff  time.Duration
+	}
+
+	retried := func(ID int64, key string, attempts int) domain.OutboxDomain {
+		row := newOutboxRow(ID, key)
+		row.Attempts = attempts
+		return row
+	}
+
+	tests := []struct {
+		Name              string
+		Locked            bool
+		Pending           []domain.OutboxDomain
+		FailingKey        string
+		ExpectedPublished []int64
+		ExpectedFailed    []failure
+		ExpectedParked    []failure
+	}{
+		{
+			Name:              "WithPendingEvents_PublishesInOrder",
+			Locked:            true,
+			Pending:           []domain.OutboxDomain{newOutboxRow(1, "10"), newOutboxRow(2, "11"), newOutboxRow(3, "10")},
+			ExpectedPublished: []int64{1, 2, 3},
+		},
+		{
+			Name:              "WithFailure_HoldsTheFollowingEventsOfTheKey",
+			Locked:            true,
+			Pending:           []domain.OutboxDomain{newOutboxRow(1, "10"), newOutboxRow(2, "11"), newOutboxRow(3, "10")},
+			FailingKey:        "10",
+			ExpectedPublished: []int64{2},
+			ExpectedFailed:    []failure{{ID: 1, Attempts: 1, Backoff: time.Second}},
+		},
+		{
+			Name:           "WithRetriedFailure_DoublesTheBackoff",
+			Locked:         true,
+			Pending:        []domain.OutboxDomain{retried(1, "10", 2)},
+			FailingKey:     "10",
+			ExpectedFailed: []failure{{ID: 1, Attempts: 3, Backoff: 4 * time.Second}},
+		},
+		{
+			Name:              "WithMaxAttemptsReached_ParksTheEventAndGoesOn",
+			Locked:            true,
+			Pending:           []domain.OutboxDomain{retried(1, "10", 4), newOutboxRow(2, "11")},
+			FailingKey:        "10",
+			ExpectedPublished: []int64{2},
+			ExpectedParked:    []failure{{ID: 1, Attempts: 5}},
+		},
+		{
+			Name:    "WithLockHeldByAnotherRelay_PublishesNothing",
+			Pending: []domain.OutboxDomain{newOutboxRow(1, "10")},
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			inMemory := event.NewInMemoryEventPublisher()
+			var published []int64
+			var failed, parked []failure
+			var now time.Time
+
+			relay := OutboxRelayService{
+				LoggerSugar: loggerSugar,
+				OutboxDomainDataBaseRepository: output.OutboxDomainDataBaseRepositoryMock{
+					TryLockMock: func(contextControl domain.ContextControl) (bool, error) {
+						assert.True(t, output.InTransaction(contextControl))
+						return test.Locked, nil
+					},
+					ClaimPendingMock: func(contextControl domain.ContextControl, claimNow, claimUntil time.Time, limit int) ([]domain.OutboxDomain, error) {
+						assert.True(t, output.InTransaction(contextControl))
+						assert.Equal(t, 30*time.Second, claimUntil.Sub(claimNow))
+						assert.Equal(t, 100, limit)
+						now = claimNow
+						return test.Pending, nil
+					},
+					MarkPublishedMock: func(contextControl domain.ContextControl, ID int64, publishedAt time.Time) error {
+						// the batch is published after the claim released the lock
+						assert.False(t, output.InTransaction(contextControl))
+						published = append(published, ID)
+						return nil
+					},
+					MarkFailedMock: func(contextControl domain.ContextControl, ID int64, attempts int, nextAttemptAt time.Time, lastError string) error {
+						assert.Equal(t, "broker not available", lastError)
+						failed = append(failed, failure{ID: ID, Attempts: attempts,
+							Backoff: nextAttemptAt.Sub(now).Round(time.Second)})
+						return nil
+					},
+					ParkMock: func(contextControl domain.ContextControl, ID int64, attempts int, parkedAt time.Time, lastError string) error {
+						assert.Equal(t, "broker not available", lastError)
+						parked = append(parked, failure{ID: ID, Attempts: attempts})
+						return nil
+					},
+				},
+				TransactionManager: &output.TransactionManagerMock{},
+				EventPublisher:     failingPublisher{InMemoryEventPublisher: inMemory, key: test.FailingKey},
+				BatchSize:          100,
+				ClaimTimeout:       30 * time.Second,
+				InitialBackoff:     time.Second,
+				MaxBackoff:         time.Minute,
+				MaxAttempts:        5,
+			}
+
+			count, err := relay.RelayPending(contextControl)
+			assert.Nil(t, err)
+			assert.Equal(t, len(test.ExpectedPublished), count)
+			assert.Equal(t, test.ExpectedPublished, published)
+			assert.Equal(t, test.ExpectedFailed, failed)
+			assert.Equal(t, test.ExpectedParked, parked)
+			assert.Len(t, inMemory.Events(), len(test.ExpectedPublished))
+		})
+	}
+}
+
+func TestOutboxRelayService_Backoff(t *testing.T) {
+
+	relay := OutboxRelayService{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	assert.Equal(t, time.Second, relay.Backoff(1))
+	assert.Equal(t, 2*time.Second, relay.Backoff(2))
+	assert.Equal(t, 4*time.Second, relay.Backoff(3))
+	assert.Equal(t, 5*time.Second, relay.Backoff(4))
+	assert.Equal(t, 5*time.Second, relay.Backoff(10))
+}
+
+func TestOutboxRelayService_Cleanup(t *testing.T) {
+
+	remaining := int64(250)
+	var limits []int
+
+	relay := OutboxRelayService{
+		LoggerSugar: loggerSugar,
+		OutboxDomainDataBaseRepository: output.OutboxDomainDataBaseRepositoryMock{
+			DeletePublishedBeforeMock: func(contextControl domain.ContextControl, before time.Time, limit int) (int64, error) {
+				assert.WithinDuration(t, time.Now().Add(-time.Hour), before, time.Second)
+				limits = append(limits, limit)
+				deleted := min(remaining, int64(limit))
+				remaining -= deleted
+				return deleted, nil
+			},
+		},
+		BatchSize: 100,
+		Retention: time.Hour,
+	}
+
+	cleaned, err := relay.Cleanup(domain.ContextControl{Context: context.Background()})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(250), cleaned)
+	assert.Equal(t, []int{100, 100, 100}, limits)
+}
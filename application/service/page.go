@@ -0,0 +1,31 @@
+package service
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+const (
+	PageSizeIsInvalid   = "page size must be between 1 and %d"
+	PageCursorIsInvalid = "page cursor must not be negative"
+)
+
+// ValidatePage checks the page asked for a list, a missing size being the default one.
+func ValidatePage(page domain.PageDomain) (domain.PageDomain, error) {
+
+	if page.Size == 0 {
+		page.Size = DefaultPageSize
+	}
+
+	if page.Size < 0 || page.Size > MaxPageSize {
+		return domain.PageDomain{}, domain.NewValidationError(PageSizeIsInvalid, MaxPageSize)
+	}
+
+	if page.Cursor < 0 {
+		return domain.PageDomain{}, domain.NewValidationError(PageCursorIsInvalid)
+	}
+
+	return page, nil
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"go.uber.org/zap"
+)
+
+type PetService struct {
+	LoggerSugar                      *zap.SugaredLogger
+	PetDomainDataBaseRepository      output.IPetDomainDataBaseRepository
+	PetDomainCacheRepository         output.IPetDomainCacheRepository
+	CustomerDomainDataBaseRepository output.ICustomerDomainDataBaseRepository
+	BreedDomainDataBaseRepository    output.IBreedDomainDataBaseRepository
+	TransactionManager               output.ITransactionManager
+	OutboxDomainDataBaseRepository   output.IOutboxDomainDataBaseRepository
+}
+
+var PetCacheTTL = 10 * time.Minute
+
+const (
+	PetErrorToSaveInCache    = "error to save pet in cache"
+	PetErrorToGetByIDInCache = "error to get pet in cache"
+	PetNameIsRequired        = "pet name is required"
+	PetBirthdayInTheFuture   = "pet birthday can't be in the future"
+	PetBreedNotFound         = "the breed with id %d wasn't found"
+	PetCustomerNotFound      = "the customer with id %d wasn't found"
+	PetContractMismatch      = "the pet contract must be the same of its customer"
+)
+
+func (service *PetService) Create(contextControl domain.ContextControl, pet domain.PetDomain) (domain.PetDomain, error) {
+
+	if err := service.ValidatePet(pet); err != nil {
+		return domain.PetDomain{}, err
+	}
+
+	_, exists, err := service.BreedDomainDataBaseRepository.GetByID(contextControl, pet.BreedID)
+	if err != nil {
+		return domain.PetDomain{}, err
+	}
+	if !exists {
+		return domain.PetDomain{}, fmt.Errorf(PetBreedNotFound, pet.BreedID)
+	}
+
+	customer, exists, err := service.CustomerDomainDataBaseRepository.GetByID(contextControl, pet.CustomerID)
+	if err != nil {
+		return domain.PetDomain{}, err
+	}
+	if !exists {
+		return domain.PetDomain{}, fmt.Errorf(PetCustomerNotFound, pet.CustomerID)
+	}
+
+	pet.ContractID = contextControl.ScopedContractID(pet.ContractID)
+	if pet.ContractID == 0 {
+		pet.ContractID = customer.ContractID
+	} else if pet.ContractID != customer.ContractID {
+		return domain.PetDomain{}, errors.New(PetContractMismatch)
+	}
+
+	var save domain.PetDomain
+	if err = saveWithEvents(service.TransactionManager, service.OutboxDomainDataBaseRepository, contextControl,
+		func(txControl domain.ContextControl) ([]domain.EventDomain, error) {
+			var err error
+			if save, err = service.PetDomainDataBaseRepository.Save(txControl, pet); err != nil {
+				return nil, err
+			}
+			return []domain.EventDomain{
+				domain.NewEvent(txControl, domain.EventPetCreated, domain.PetEventVersion,
+					strconv.FormatInt(save.ID, 10), domain.PetEvent{
+						ID:           save.ID,
+						Name:         save.Name,
+						DateBirthday: save.DateBirthday,
+						CustomerID:   save.CustomerID,
+						BreedID:      save.BreedID,
+						ContractID:   save.ContractID,
+					}),
+			}, nil
+		}); err != nil {
+		return domain.PetDomain{}, err
+	}
+
+	hash, err := json.Marshal(save)
+	if err != nil {
+		service.LoggerSugar.Warnw("failed to marshal pet for cache", "pet_id", save.ID, "error", err)
+	}
+	if err = service.PetDomainCacheRepository.Set(contextControl,
+		PetCacheKey.BuildScopedID(contextControl, save.ID),
+		string(hash), PetCacheTTL); err != nil {
+		service.LoggerSugar.Infow(PetErrorToSaveInCache, "pet_id", save.ID)
+	}
+
+	return save, nil
+}
+
+func (service *PetService) GetByID(contextControl domain.ContextControl, ID int64) (domain.PetDomain, bool, error) {
+
+	cacheKey := PetCacheKey.BuildScopedID(contextControl, ID)
+	if hash, err := service.PetDomainCacheRepository.Get(contextControl, cacheKey); err == nil && len(hash) > 0 {
+		var pet domain.PetDomain
+		if err = json.Unmarshal([]byte(hash), &pet); err == nil {
+			return pet, true, nil
+		}
+		service.LoggerSugar.Warnw(PetErrorToGetByIDInCache, "pet_id", ID, "error", err)
+	}
+
+	pet, exists, err := service.PetDomainDataBaseRepository.GetByID(contextControl, ID)
+	if err != nil {
+		return domain.PetDomain{}, false, err
+	}
+
+	if !exists {
+		return domain.PetDomain{}, false, nil
+	}
+
+	hash, err := json.Marshal(pet)
+	if err != nil {
+		service.LoggerSugar.Warnw("failed to marshal pet for cache", "pet_id", pet.ID, "error", err)
+	}
+
+	if err = service.PetDomainCacheRepository.Set(contextControl, cacheKey,
+		string(hash), PetCacheTTL); err != nil {
+		service.LoggerSugar.Infow(PetErrorToSaveInCache, "pet_id", pet.ID)
+	}
+
+	return pet, true, nil
+}
+
+func (service *PetService) GetByCustomerID(contextControl domain.ContextControl, customerID int64) ([]domain.PetDomain, error) {
+	return service.PetDomainDataBaseRepository.GetByCustomerID(contextControl, customerID)
+}
+
+// ValidatePet checks the fields that can be verified without reaching any repository.
+func (service *PetService) ValidatePet(pet domain.PetDomain) error {
+
+	if len(strings.TrimSpace(pet.Name)) == 0 {
+		return errors.New(PetNameIsRequired)
+	}
+
+	if pet.DateBirthday.After(time.Now()) {
+		return errors.New(PetBirthdayInTheFuture)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package service
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type PetMock struct {
+	CreateMock          func(contextControl domain.ContextControl, pet domain.PetDomain) (domain.PetDomain, error)
+	GetByIDMock         func(contextControl domain.ContextControl, ID int64) (domain.PetDomain, bool, error)
+	GetByCustomerIDMock func(contextControl domain.ContextControl, customerID int64) ([]domain.PetDomain, error)
+}
+
+func (c PetMock) Create(contextControl domain.ContextControl, pet domain.PetDomain) (domain.PetDomain, error) {
+	if c.CreateMock != nil {
+		return c.CreateMock(contextControl, pet)
+	}
+	return domain.PetDomain{}, nil
+}
+
+func (c PetMock) GetByID(contextControl domain.ContextControl, ID int64) (domain.PetDomain, bool, error) {
+	if c.GetByIDMock != nil {
+		return c.GetByIDMock(contextControl, ID)
+	}
+	return domain.PetDomain{}, false, nil
+}
+
+func (c PetMock) GetByCustomerID(contextControl domain.ContextControl, customerID int64) ([]domain.PetDomain, error) {
+	if c.GetByCustomerIDMock != nil {
+		return c.GetByCustomerIDMock(contextControl, customerID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/petshop-system/petshop-api/adapter/output/database"
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPetService_Create(t *testing.T) {
+
+	birthday := time.Date(2016, time.December, 12, 0, 0, 0, 0, time.UTC)
+
+	breedFound := output.BreedDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.BreedDomain, bool, error) {
+			return domain.BreedDomain{ID: ID, Name: "Pastor Alemao", SpeciesID: 1}, true, nil
+		},
+	}
+
+	customerFound := output.CustomerDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+			return domain.CustomerDomain{ID: ID, Name: "Fulano", ContractID: 1}, true, nil
+		},
+	}
+
+	petSaved := output.PetDomainDataBaseRepositoryMock{
+		SaveMock: func(contextControl domain.ContextControl, pet domain.PetDomain) (domain.PetDomain, error) {
+			pet.ID = 1
+			return pet, nil
+		},
+	}
+
+	tests := []struct {
+		Name                             string
+		Pet                              domain.PetDomain
+		PetDomainDataBaseRepository      output.IPetDomainDataBaseRepository
+		CustomerDomainDataBaseRepository output.ICustomerDomainDataBaseRepository
+		BreedDomainDataBaseRepository    output.IBreedDomainDataBaseRepository
+		ExpectedResult                   domain.PetDomain
+		ExpectedError                    error
+	}{
+		{
+			Name:                             "WithValidPet_SavesWithCustomerContract",
+			Pet:                              domain.PetDomain{Name: "Rex", DateBirthday: birthday, CustomerID: 1, BreedID: 1},
+			PetDomainDataBaseRepository:      petSaved,
+			CustomerDomainDataBaseRepository: customerFound,
+			BreedDomainDataBaseRepository:    breedFound,
+			ExpectedResult:                   domain.PetDomain{ID: 1, Name: "Rex", DateBirthday: birthday, CustomerID: 1, BreedID: 1, ContractID: 1},
+			ExpectedError:                    nil,
+		},
+		{
+			Name:                             "WithoutName_ReturnsValidationError",
+			Pet:                              domain.PetDomain{Name: " ", CustomerID: 1, BreedID: 1},
+			PetDomainDataBaseRepository:      petSaved,
+			CustomerDomainDataBaseRepository: customerFound,
+			BreedDomainDataBaseRepository:    breedFound,
+			ExpectedResult:                   domain.PetDomain{},
+			ExpectedError:                    fmt.Errorf(PetNameIsRequired),
+		},
+		{
+			Name:                             "WithUnknownBreed_ReturnsBreedNotFound",
+			Pet:                              domain.PetDomain{Name: "Rex", CustomerID: 1, BreedID: 99},
+			PetDomainDataBaseRepository:      petSaved,
+			CustomerDomainDataBaseRepository: customerFound,
+			BreedDomainDataBaseRepository:    output.BreedDomainDataBaseRepositoryMock{},
+			ExpectedResult:                   domain.PetDomain{},
+			ExpectedError:                    fmt.Errorf(PetBreedNotFound, 99),
+		},
+		{
+			Name:                             "WithUnknownCustomer_ReturnsCustomerNotFound",
+			Pet:                              domain.PetDomain{Name: "Rex", CustomerID: 99, BreedID: 1},
+			PetDomainDataBaseRepository:      petSaved,
+			CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{},
+			BreedDomainDataBaseRepository:    breedFound,
+			ExpectedResult:                   domain.PetDomain{},
+			ExpectedError:                    fmt.Errorf(PetCustomerNotFound, 99),
+		},
+		{
+			Name:                             "WithAnotherContract_ReturnsContractMismatch",
+			Pet:                              domain.PetDomain{Name: "Rex", CustomerID: 1, BreedID: 1, ContractID: 2},
+			PetDomainDataBaseRepository:      petSaved,
+			CustomerDomainDataBaseRepository: customerFound,
+			BreedDomainDataBaseRepository:    breedFound,
+			ExpectedResult:                   domain.PetDomain{},
+			ExpectedError:                    fmt.Errorf(PetContractMismatch),
+		},
+		{
+			Name: "WithDatabaseError_ReturnsSaveError",
+			Pet:  domain.PetDomain{Name: "Rex", CustomerID: 1, BreedID: 1},
+			PetDomainDataBaseRepository: output.PetDomainDataBaseRepositoryMock{
+				SaveMock: func(contextControl domain.ContextControl, pet domain.PetDomain) (domain.PetDomain, error) {
+					return domain.PetDomain{}, fmt.Errorf(database.PetSaveDBError)
+				},
+			},
+			CustomerDomainDataBaseRepository: customerFound,
+			BreedDomainDataBaseRepository:    breedFound,
+			ExpectedResult:                   domain.PetDomain{},
+			ExpectedError:                    fmt.Errorf(database.PetSaveDBError),
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			petService := PetService{
+				LoggerSugar:                      loggerSugar,
+				OutboxDomainDataBaseRepository:   output.OutboxDomainDataBaseRepositoryMock{},
+				PetDomainDataBaseRepository:      test.PetDomainDataBaseRepository,
+				PetDomainCacheRepository:         output.PetDomainCacheRepositoryMock{},
+				CustomerDomainDataBaseRepository: test.CustomerDomainDataBaseRepository,
+				BreedDomainDataBaseRepository:    test.BreedDomainDataBaseRepository,
+			}
+
+			contextControl := domain.ContextControl{
+				Context: context.Background(),
+			}
+
+			pet, err := petService.Create(contextControl, test.Pet)
+			assert.Equal(t, test.ExpectedResult, pet)
+			assert.Equal(t, test.ExpectedError, err)
+
+		})
+	}
+}
+
+func TestPetService_Create_CrossTenant(t *testing.T) {
+
+	petService := PetService{
+		LoggerSugar:                    loggerSugar,
+		OutboxDomainDataBaseRepository: output.OutboxDomainDataBaseRepositoryMock{},
+		PetDomainDataBaseRepository: output.PetDomainDataBaseRepositoryMock{
+			SaveMock: func(contextControl domain.ContextControl, pet domain.PetDomain) (domain.PetDomain, error) {
+				return pet, nil
+			},
+		},
+		PetDomainCacheRepository: output.PetDomainCacheRepositoryMock{},
+		BreedDomainDataBaseRepository: output.BreedDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.BreedDomain, bool, error) {
+				return domain.BreedDomain{ID: ID}, true, nil
+			},
+		},
+		CustomerDomainDataBaseRepository: output.CustomerDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+				// customer 1 belongs to contract 1, scoped reads of other contracts don't see it
+				if contextControl.ContractID != 1 {
+					return domain.CustomerDomain{}, false, nil
+				}
+				return domain.CustomerDomain{ID: ID, ContractID: 1}, true, nil
+			},
+		},
+	}
+
+	pet := domain.PetDomain{Name: "Rex", CustomerID: 1, BreedID: 1, ContractID: 1}
+
+	_, err := petService.Create(domain.ContextControl{Context: context.Background(), ContractID: 2}, pet)
+	assert.EqualError(t, err, fmt.Sprintf(PetCustomerNotFound, 1))
+
+	saved, err := petService.Create(domain.ContextControl{Context: context.Background(), ContractID: 1}, pet)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), saved.ContractID)
+}
@@ -3,8 +3,6 @@ package service
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
-	"strconv"
 	"time"
 
 	"github.com/petshop-system/petshop-api/application/domain"
@@ -14,20 +12,38 @@ import (
 )
 
 type PhoneService struct {
-	LoggerSugar                   *zap.SugaredLogger
-	PhoneDomainDataBaseRepository output.IPhoneDomainDataBaseRepository
-	PhoneDomainCacheRepository    output.IPhoneDomainCacheRepository
+	LoggerSugar                      *zap.SugaredLogger
+	PhoneDomainDataBaseRepository    output.IPhoneDomainDataBaseRepository
+	PhoneDomainCacheRepository       output.IPhoneDomainCacheRepository
+	ContractDomainDataBaseRepository output.IContractDomainDataBaseRepository
+	CustomerDomainDataBaseRepository output.ICustomerDomainDataBaseRepository
+	EmployeeDomainDataBaseRepository output.IEmployeeDomainDataBaseRepository
+	TransactionManager               output.ITransactionManager
 }
 
 var PhoneCacheTTL = 10 * time.Minute
 
 const (
-	PhoneCacheKeyTypeID = "ID"
-	LandLinePhone       = "landline_phone"
-	MobilePhone         = "mobile_phone"
+	LandLinePhone = "landline_phone"
+	MobilePhone   = "mobile_phone"
 )
 
+// Owners of a phone, matching the check constraint of phone_user.user_type.
 const (
+	PhoneUserTypeContract = "contract"
+	PhoneUserTypeCustomer = "customer"
+	PhoneUserTypeEmployee = "employee"
+)
+
+const (
+	PhoneUserInvalidType            = "invalid type of phone owner %q, it must be contract, customer or employee"
+	PhoneUserIsRequired             = "phone owner is required"
+	PhoneUserOwnerNotFound          = "the %s with id %d wasn't found"
+	PhoneUserPhoneNotFound          = "the phone with id %d wasn't found"
+	PhoneUserLinkedToAnother        = "the phone %d is already linked to another owner"
+	PhoneUserSuccessToAttach        = "phone attached to its owner"
+	PhoneUserSuccessToDetach        = "phone detached from its owner"
+	PhoneUserSuccessToPrimary       = "phone set as the primary of its owner"
 	PhoneErrorToSaveInCache         = "error to save phone in cache"
 	PhoneErrorToGetByIDInCache      = "error to get phone by id in cache"
 	ErrorInvalidMobilePhoneLength   = "invalid Mobile Phone length error"
@@ -35,10 +51,6 @@ const (
 	InvalidTypeOfPhone              = "invalid type of phone"
 )
 
-func (service *PhoneService) getCacheKey(cacheKeyType, value string) string {
-	return fmt.Sprintf("%s.%s", cacheKeyType, value)
-}
-
 func (service *PhoneService) Create(contextControl domain.ContextControl, phone domain.PhoneDomain) (domain.PhoneDomain, error) {
 
 	err := service.ValidatePhone(phone)
@@ -56,20 +68,42 @@ func (service *PhoneService) Create(contextControl domain.ContextControl, phone
 		service.LoggerSugar.Warnw("failed to marshal phone for cache", "phone_id", save.ID, "error", err)
 	}
 
-	if err = service.PhoneDomainCacheRepository.Set(contextControl, service.getCacheKey(PhoneCacheKeyTypeID, strconv.FormatInt(phone.ID, 10)),
+	if err = service.PhoneDomainCacheRepository.Set(contextControl, PhoneCacheKey.BuildScopedID(contextControl, save.ID),
 		string(hash), PhoneCacheTTL); err != nil {
-		service.LoggerSugar.Infow(PhoneErrorToSaveInCache, "phone_id", phone.ID)
+		service.LoggerSugar.Infow(PhoneErrorToSaveInCache, "phone_id", save.ID)
 	}
 	return save, nil
 }
 
 func (service *PhoneService) GetByID(contextControl domain.ContextControl, ID int64) (domain.PhoneDomain, bool, error) {
+
+	counter := getCacheCounter(PhoneCacheStatsName)
+	cacheKey := PhoneCacheKey.BuildScopedID(contextControl, ID)
+	if hash, err := service.PhoneDomainCacheRepository.Get(contextControl, cacheKey); err == nil && len(hash) > 0 {
+		if hash == CacheNotFoundValue {
+			counter.Hit()
+			return domain.PhoneDomain{}, false, nil
+		}
+
+		var phone domain.PhoneDomain
+		if err = json.Unmarshal([]byte(hash), &phone); err == nil {
+			counter.Hit()
+			return phone, true, nil
+		}
+		service.LoggerSugar.Warnw(PhoneErrorToGetByIDInCache, "phone_id", ID, "error", err)
+	}
+	counter.Miss()
+
 	phone, exists, err := service.PhoneDomainDataBaseRepository.GetByID(contextControl, ID)
 	if err != nil {
 		return domain.PhoneDomain{}, exists, err
 	}
 
 	if !exists {
+		if err = service.PhoneDomainCacheRepository.Set(contextControl, cacheKey,
+			CacheNotFoundValue, NotFoundCacheTTL); err != nil {
+			service.LoggerSugar.Infow(PhoneErrorToSaveInCache, "phone_id", ID)
+		}
 		return domain.PhoneDomain{}, exists, nil
 	}
 
@@ -78,10 +112,9 @@ func (service *PhoneService) GetByID(contextControl domain.ContextControl, ID in
 		service.LoggerSugar.Warnw("failed to marshal phone for cache", "phone_id", phone.ID, "error", err)
 	}
 
-	if err = service.PhoneDomainCacheRepository.Set(contextControl,
-		service.getCacheKey(AddressCacheKeyTypeID, strconv.FormatInt(phone.ID, 10)),
+	if err = service.PhoneDomainCacheRepository.Set(contextControl, cacheKey,
 		string(hash), PhoneCacheTTL); err != nil {
-		service.LoggerSugar.Infow(PhoneErrorToGetByIDInCache, "phone_id", phone.ID)
+		service.LoggerSugar.Infow(PhoneErrorToSaveInCache, "phone_id", phone.ID)
 	}
 	return phone, exists, nil
 }
@@ -115,3 +148,192 @@ func (service *PhoneService) ValidatePhone(phone domain.PhoneDomain) error {
 	}
 	return nil
 }
+
+// Attach links the phone to its owner. The first phone of an owner, or one attached as primary,
+// becomes its primary contact number. Attaching a phone to its own owner again changes nothing.
+// It returns false when the phone doesn't exist.
+func (service *PhoneService) Attach(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) (domain.PhoneUserDomain, bool, error) {
+
+	if err := service.ValidatePhoneUser(phoneUser); err != nil {
+		return domain.PhoneUserDomain{}, false, err
+	}
+
+	phone, exists, err := service.PhoneDomainDataBaseRepository.GetByID(contextControl, phoneUser.PhoneID)
+	if err != nil || !exists {
+		return domain.PhoneUserDomain{}, false, err
+	}
+
+	if exists, err = service.ownerExists(contextControl, phoneUser.UserType, phoneUser.UserID); err != nil {
+		return domain.PhoneUserDomain{}, true, err
+	} else if !exists {
+		return domain.PhoneUserDomain{}, true,
+			domain.NewValidationError(PhoneUserOwnerNotFound, phoneUser.UserType, phoneUser.UserID)
+	}
+
+	current, linked, err := service.PhoneDomainDataBaseRepository.GetUserByPhoneID(contextControl, phoneUser.PhoneID)
+	if err != nil {
+		return domain.PhoneUserDomain{}, true, err
+	}
+	if linked {
+		if current.UserType != phoneUser.UserType || current.UserID != phoneUser.UserID {
+			return domain.PhoneUserDomain{}, true, domain.NewConflictError(PhoneUserLinkedToAnother, phoneUser.PhoneID)
+		}
+		if !phoneUser.Primary || current.Primary {
+			current.Phone = phone
+			return current, true, nil
+		}
+	}
+
+	var saved domain.PhoneUserDomain
+	if err = withinTransaction(service.TransactionManager, contextControl, func(txControl domain.ContextControl) error {
+
+		ownerPhones, err := service.PhoneDomainDataBaseRepository.GetUsersByOwner(txControl, phoneUser.UserType, phoneUser.UserID)
+		if err != nil {
+			return err
+		}
+
+		// the primary flag is only set by SetPrimaryUser, which clears the previous one
+		saved = current
+		if !linked {
+			link := phoneUser
+			link.Primary = false
+			if saved, err = service.PhoneDomainDataBaseRepository.SaveUser(txControl, link); err != nil {
+				return err
+			}
+		}
+
+		if phoneUser.Primary || len(ownerPhones) == 0 {
+			saved.Primary = true
+			return service.PhoneDomainDataBaseRepository.SetPrimaryUser(txControl, saved)
+		}
+		return nil
+	}); err != nil {
+		return domain.PhoneUserDomain{}, true, err
+	}
+
+	service.LoggerSugar.Infow(PhoneUserSuccessToAttach, "phone_id", saved.PhoneID, "user_type", saved.UserType,
+		"user_id", saved.UserID, "primary", saved.Primary)
+
+	saved.Phone = phone
+	return saved, true, nil
+}
+
+// Detach unlinks the phone from its owner, returning false when the phone isn't linked to any.
+func (service *PhoneService) Detach(contextControl domain.ContextControl, phoneID int64) (bool, error) {
+
+	current, linked, err := service.PhoneDomainDataBaseRepository.GetUserByPhoneID(contextControl, phoneID)
+	if err != nil || !linked {
+		return false, err
+	}
+
+	if err = service.PhoneDomainDataBaseRepository.DeleteUser(contextControl, phoneID); err != nil {
+		return true, err
+	}
+
+	service.LoggerSugar.Infow(PhoneUserSuccessToDetach, "phone_id", phoneID, "user_type", current.UserType,
+		"user_id", current.UserID)
+
+	return true, nil
+}
+
+// GetByOwner lists the phones of the owner, the primary one first. It returns false when the
+// owner doesn't exist.
+func (service *PhoneService) GetByOwner(contextControl domain.ContextControl, userType string, userID int64) ([]domain.PhoneUserDomain, bool, error) {
+
+	if err := service.ValidatePhoneUser(domain.PhoneUserDomain{UserType: userType, UserID: userID}); err != nil {
+		return nil, false, err
+	}
+
+	exists, err := service.ownerExists(contextControl, userType, userID)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+
+	phones, err := service.PhoneDomainDataBaseRepository.GetUsersByOwner(contextControl, userType, userID)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return phones, true, nil
+}
+
+// SetPrimary makes the phone the primary contact number of its owner, returning false when the
+// phone isn't linked to any.
+func (service *PhoneService) SetPrimary(contextControl domain.ContextControl, phoneID int64) (domain.PhoneUserDomain, bool, error) {
+
+	current, linked, err := service.PhoneDomainDataBaseRepository.GetUserByPhoneID(contextControl, phoneID)
+	if err != nil || !linked {
+		return domain.PhoneUserDomain{}, false, err
+	}
+
+	phone, _, err := service.PhoneDomainDataBaseRepository.GetByID(contextControl, phoneID)
+	if err != nil {
+		return domain.PhoneUserDomain{}, true, err
+	}
+	current.Phone = phone
+
+	if current.Primary {
+		return current, true, nil
+	}
+
+	if err = service.PhoneDomainDataBaseRepository.SetPrimaryUser(contextControl, current); err != nil {
+		return domain.PhoneUserDomain{}, true, err
+	}
+
+	service.LoggerSugar.Infow(PhoneUserSuccessToPrimary, "phone_id", phoneID, "user_type", current.UserType,
+		"user_id", current.UserID)
+
+	current.Primary = true
+	return current, true, nil
+}
+
+// ValidatePhoneUser checks the owner of a link, enforcing the check constraint of phone_user.
+func (service *PhoneService) ValidatePhoneUser(phoneUser domain.PhoneUserDomain) error {
+
+	switch phoneUser.UserType {
+	case PhoneUserTypeContract, PhoneUserTypeCustomer, PhoneUserTypeEmployee:
+	default:
+		return domain.NewValidationError(PhoneUserInvalidType, phoneUser.UserType)
+	}
+
+	if phoneUser.UserID <= 0 {
+		return domain.NewValidationError(PhoneUserIsRequired)
+	}
+
+	return nil
+}
+
+// ownerExists tells whether the owner is visible to the contract of the request; a contract
+// only sees itself.
+func (service *PhoneService) ownerExists(contextControl domain.ContextControl, userType string, userID int64) (bool, error) {
+
+	var exists bool
+	var err error
+
+	switch userType {
+	case PhoneUserTypeContract:
+		if contextControl.ContractID != 0 && contextControl.ContractID != userID {
+			return false, nil
+		}
+		_, exists, err = service.ContractDomainDataBaseRepository.GetByID(contextControl, userID)
+	case PhoneUserTypeCustomer:
+		_, exists, err = service.CustomerDomainDataBaseRepository.GetByID(contextControl, userID)
+	case PhoneUserTypeEmployee:
+		_, exists, err = service.EmployeeDomainDataBaseRepository.GetByID(contextControl, userID)
+	}
+
+	return exists, err
+}
+
+// List returns a page of the phones matching the filter, the number regardless of punctuation.
+func (service *PhoneService) List(contextControl domain.ContextControl, filter domain.PhoneFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.PhoneDomain], error) {
+
+	page, err := ValidatePage(page)
+	if err != nil {
+		return domain.PageResultDomain[domain.PhoneDomain]{}, err
+	}
+
+	filter.Number = utils.RemoveNonAlphaNumericCharacters(filter.Number)
+
+	return service.PhoneDomainDataBaseRepository.List(contextControl, filter, page)
+}
@@ -3,8 +3,13 @@ package service
 import "github.com/petshop-system/petshop-api/application/domain"
 
 type PhoneMock struct {
-	CreateMock  func(contextControl domain.ContextControl, phone domain.PhoneDomain) (domain.PhoneDomain, error)
-	GetByIDMock func(ID int64) (domain.PhoneDomain, error)
+	CreateMock     func(contextControl domain.ContextControl, phone domain.PhoneDomain) (domain.PhoneDomain, error)
+	GetByIDMock    func(ID int64) (domain.PhoneDomain, error)
+	AttachMock     func(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) (domain.PhoneUserDomain, bool, error)
+	DetachMock     func(contextControl domain.ContextControl, phoneID int64) (bool, error)
+	GetByOwnerMock func(contextControl domain.ContextControl, userType string, userID int64) ([]domain.PhoneUserDomain, bool, error)
+	SetPrimaryMock func(contextControl domain.ContextControl, phoneID int64) (domain.PhoneUserDomain, bool, error)
+	ListMock       func(contextControl domain.ContextControl, filter domain.PhoneFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.PhoneDomain], error)
 }
 
 func (c PhoneMock) Create(contextControl domain.ContextControl, phone domain.PhoneDomain) (domain.PhoneDomain, error) {
@@ -20,3 +25,38 @@ func (c PhoneMock) GetByID(ID int64) (domain.PhoneDomain, error) {
 	}
 	return domain.PhoneDomain{}, nil
 }
+
+func (c PhoneMock) Attach(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) (domain.PhoneUserDomain, bool, error) {
+	if c.AttachMock != nil {
+		return c.AttachMock(contextControl, phoneUser)
+	}
+	return domain.PhoneUserDomain{}, false, nil
+}
+
+func (c PhoneMock) Detach(contextControl domain.ContextControl, phoneID int64) (bool, error) {
+	if c.DetachMock != nil {
+		return c.DetachMock(contextControl, phoneID)
+	}
+	return false, nil
+}
+
+func (c PhoneMock) GetByOwner(contextControl domain.ContextControl, userType string, userID int64) ([]domain.PhoneUserDomain, bool, error) {
+	if c.GetByOwnerMock != nil {
+		return c.GetByOwnerMock(contextControl, userType, userID)
+	}
+	return nil, false, nil
+}
+
+func (c PhoneMock) SetPrimary(contextControl domain.ContextControl, phoneID int64) (domain.PhoneUserDomain, bool, error) {
+	if c.SetPrimaryMock != nil {
+		return c.SetPrimaryMock(contextControl, phoneID)
+	}
+	return domain.PhoneUserDomain{}, false, nil
+}
+
+func (c PhoneMock) List(contextControl domain.ContextControl, filter domain.PhoneFilterDomain, page domain.PageDomain) (domain.PageResultDomain[domain.PhoneDomain], error) {
+	if c.ListMock != nil {
+		return c.ListMock(contextControl, filter, page)
+	}
+	return domain.PageResultDomain[domain.PhoneDomain]{}, nil
+}
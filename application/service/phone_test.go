@@ -226,3 +226,285 @@ func TestPhoneService_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestPhoneService_GetByID(t *testing.T) {
+
+	phone := domain.PhoneDomain{ID: 1, Number: "999999999", CodeArea: "32", PhoneType: MobilePhone}
+
+	t.Run("WithMissingID_CachesTheNotFound", func(t *testing.T) {
+
+		var cachedValue string
+		var cachedTTL time.Duration
+		phoneService := PhoneService{
+			LoggerSugar: loggerSugar,
+			PhoneDomainDataBaseRepository: output.PhoneDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.PhoneDomain, bool, error) {
+					return domain.PhoneDomain{}, false, nil
+				},
+			},
+			PhoneDomainCacheRepository: output.PhoneDomainCacheRepositoryMock{
+				SetMock: func(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error {
+					cachedValue, cachedTTL = hash, expirationTime
+					return nil
+				},
+			},
+		}
+
+		result, exists, err := phoneService.GetByID(domain.ContextControl{Context: context.Background()}, 1)
+		assert.Nil(t, err)
+		assert.False(t, exists)
+		assert.Equal(t, domain.PhoneDomain{}, result)
+		assert.Equal(t, CacheNotFoundValue, cachedValue)
+		assert.Equal(t, NotFoundCacheTTL, cachedTTL)
+	})
+
+	t.Run("WithPhoneInCache_CountsAHit", func(t *testing.T) {
+
+		phoneService := PhoneService{
+			LoggerSugar: loggerSugar,
+			PhoneDomainDataBaseRepository: output.PhoneDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.PhoneDomain, bool, error) {
+					return domain.PhoneDomain{}, false, fmt.Errorf("database must not be queried")
+				},
+			},
+			PhoneDomainCacheRepository: output.PhoneDomainCacheRepositoryMock{
+				GetMock: func(contextControl domain.ContextControl, key string) (string, error) {
+					return `{"ID":1,"Number":"999999999","CodeArea":"32","PhoneType":"mobile_phone"}`, nil
+				},
+			},
+		}
+
+		hitsBefore := CacheStatsService{}.GetCacheStats()[PhoneCacheStatsName].Hits
+		result, exists, err := phoneService.GetByID(domain.ContextControl{Context: context.Background()}, 1)
+		assert.Nil(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, phone, result)
+		assert.Equal(t, hitsBefore+1, CacheStatsService{}.GetCacheStats()[PhoneCacheStatsName].Hits)
+	})
+}
+
+func TestPhoneService_Create_CachesUnderTheSavedID(t *testing.T) {
+
+	var cacheKey string
+	phoneService := PhoneService{
+		LoggerSugar: loggerSugar,
+		PhoneDomainDataBaseRepository: output.PhoneDomainDataBaseRepositoryMock{
+			SaveMock: func(contextControl domain.ContextControl, phone domain.PhoneDomain) (domain.PhoneDomain, error) {
+				phone.ID = 7
+				return phone, nil
+			},
+		},
+		PhoneDomainCacheRepository: output.PhoneDomainCacheRepositoryMock{
+			SetMock: func(contextControl domain.ContextControl, key string, hash string, expirationTime time.Duration) error {
+				cacheKey = key
+				return nil
+			},
+		},
+	}
+
+	_, err := phoneService.Create(domain.ContextControl{Context: context.Background()},
+		domain.PhoneDomain{Number: "99999-9999", CodeArea: "32", PhoneType: MobilePhone})
+	assert.Nil(t, err)
+	assert.Equal(t, PhoneCacheKey.BuildID(7), cacheKey)
+}
+
+func TestPhoneService_Attach(t *testing.T) {
+
+	phone := domain.PhoneDomain{ID: 4, Number: "999999999", CodeArea: "32", PhoneType: MobilePhone}
+	customerRepository := output.CustomerDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.CustomerDomain, bool, error) {
+			return domain.CustomerDomain{ID: ID}, ID == 1, nil
+		},
+	}
+
+	tests := []struct {
+		Name            string
+		PhoneUser       domain.PhoneUserDomain
+		Current         *domain.PhoneUserDomain
+		OwnerPhones     []domain.PhoneUserDomain
+		ExpectedResult  domain.PhoneUserDomain
+		ExpectedExists  bool
+		ExpectedError   error
+		ExpectedSaved   bool
+		ExpectedPrimary bool
+	}{
+		{
+			Name:            "WithOwnerWithoutPhones_AttachesAsPrimary",
+			PhoneUser:       domain.PhoneUserDomain{PhoneID: 4, UserID: 1, UserType: PhoneUserTypeCustomer},
+			ExpectedResult:  domain.PhoneUserDomain{ID: 9, PhoneID: 4, UserID: 1, UserType: PhoneUserTypeCustomer, Primary: true, Phone: phone},
+			ExpectedExists:  true,
+			ExpectedSaved:   true,
+			ExpectedPrimary: true,
+		},
+		{
+			Name:           "WithOwnerWithPhones_AttachesAsSecondary",
+			PhoneUser:      domain.PhoneUserDomain{PhoneID: 4, UserID: 1, UserType: PhoneUserTypeCustomer},
+			OwnerPhones:    []domain.PhoneUserDomain{{PhoneID: 2, UserID: 1, UserType: PhoneUserTypeCustomer, Primary: true}},
+			ExpectedResult: domain.PhoneUserDomain{ID: 9, PhoneID: 4, UserID: 1, UserType: PhoneUserTypeCustomer, Phone: phone},
+			ExpectedExists: true,
+			ExpectedSaved:  true,
+		},
+		{
+			Name:            "WithPrimaryRequested_ReplacesThePrimary",
+			PhoneUser:       domain.PhoneUserDomain{PhoneID: 4, UserID: 1, UserType: PhoneUserTypeCustomer, Primary: true},
+			OwnerPhones:     []domain.PhoneUserDomain{{PhoneID: 2, UserID: 1, UserType: PhoneUserTypeCustomer, Primary: true}},
+			ExpectedResult:  domain.PhoneUserDomain{ID: 9, PhoneID: 4, UserID: 1, UserType: PhoneUserTypeCustomer, Primary: true, Phone: phone},
+			ExpectedExists:  true,
+			ExpectedSaved:   true,
+			ExpectedPrimary: true,
+		},
+		{
+			Name:           "WithPhoneOfTheSameOwner_ChangesNothing",
+			PhoneUser:      domain.PhoneUserDomain{PhoneID: 4, UserID: 1, UserType: PhoneUserTypeCustomer},
+			Current:        &domain.PhoneUserDomain{ID: 7, PhoneID: 4, UserID: 1, UserType: PhoneUserTypeCustomer},
+			ExpectedResult: domain.PhoneUserDomain{ID: 7, PhoneID: 4, UserID: 1, UserType: PhoneUserTypeCustomer, Phone: phone},
+			ExpectedExists: true,
+		},
+		{
+			Name:           "WithPhoneOfAnotherOwner_ReturnsConflict",
+			PhoneUser:      domain.PhoneUserDomain{PhoneID: 4, UserID: 1, UserType: PhoneUserTypeCustomer},
+			Current:        &domain.PhoneUserDomain{ID: 7, PhoneID: 4, UserID: 3, UserType: PhoneUserTypeEmployee},
+			ExpectedExists: true,
+			ExpectedError:  domain.NewConflictError(PhoneUserLinkedToAnother, 4),
+		},
+		{
+			Name:           "WithUnknownOwner_ReturnsValidationError",
+			PhoneUser:      domain.PhoneUserDomain{PhoneID: 4, UserID: 2, UserType: PhoneUserTypeCustomer},
+			ExpectedExists: true,
+			ExpectedError:  domain.NewValidationError(PhoneUserOwnerNotFound, PhoneUserTypeCustomer, 2),
+		},
+		{
+			Name:          "WithInvalidOwnerType_ReturnsValidationError",
+			PhoneUser:     domain.PhoneUserDomain{PhoneID: 4, UserID: 1, UserType: "supplier"},
+			ExpectedError: domain.NewValidationError(PhoneUserInvalidType, "supplier"),
+		},
+		{
+			Name:      "WithUnknownPhone_ReturnsNotExists",
+			PhoneUser: domain.PhoneUserDomain{PhoneID: 5, UserID: 1, UserType: PhoneUserTypeCustomer},
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			var saved, primary bool
+			transactionManager := &output.TransactionManagerMock{}
+
+			phoneService := PhoneService{
+				LoggerSugar: loggerSugar,
+				PhoneDomainDataBaseRepository: output.PhoneDomainDataBaseRepositoryMock{
+					GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.PhoneDomain, bool, error) {
+						return phone, ID == phone.ID, nil
+					},
+					GetUserByPhoneIDMock: func(contextControl domain.ContextControl, phoneID int64) (domain.PhoneUserDomain, bool, error) {
+						if test.Current == nil {
+							return domain.PhoneUserDomain{}, false, nil
+						}
+						return *test.Current, true, nil
+					},
+					GetUsersByOwnerMock: func(contextControl domain.ContextControl, userType string, userID int64) ([]domain.PhoneUserDomain, error) {
+						return test.OwnerPhones, nil
+					},
+					SaveUserMock: func(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) (domain.PhoneUserDomain, error) {
+						assert.True(t, output.InTransaction(contextControl))
+						assert.False(t, phoneUser.Primary)
+						saved = true
+						phoneUser.ID = 9
+						return phoneUser, nil
+					},
+					SetPrimaryUserMock: func(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) error {
+						assert.True(t, output.InTransaction(contextControl))
+						primary = true
+						return nil
+					},
+				},
+				CustomerDomainDataBaseRepository: customerRepository,
+				TransactionManager:               transactionManager,
+			}
+
+			result, exists, err := phoneService.Attach(domain.ContextControl{Context: context.Background()}, test.PhoneUser)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedExists, exists)
+			assert.Equal(t, test.ExpectedResult, result)
+			assert.Equal(t, test.ExpectedSaved, saved)
+			assert.Equal(t, test.ExpectedPrimary, primary)
+		})
+	}
+}
+
+func TestPhoneService_GetByOwner(t *testing.T) {
+
+	contractRepository := output.ContractDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error) {
+			return domain.ContractDomain{ID: ID}, true, nil
+		},
+	}
+
+	tests := []struct {
+		Name           string
+		ContractID     int64
+		UserType       string
+		UserID         int64
+		ExpectedExists bool
+		ExpectedError  error
+	}{
+		{Name: "WithOwnContract_ListsItsPhones", ContractID: 1, UserType: PhoneUserTypeContract, UserID: 1, ExpectedExists: true},
+		{Name: "WithAnotherContract_ReturnsNotExists", ContractID: 1, UserType: PhoneUserTypeContract, UserID: 2},
+		{Name: "WithoutOwnerID_ReturnsValidationError", ContractID: 1, UserType: PhoneUserTypeContract,
+			ExpectedError: domain.NewValidationError(PhoneUserIsRequired)},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			phoneService := PhoneService{
+				LoggerSugar: loggerSugar,
+				PhoneDomainDataBaseRepository: output.PhoneDomainDataBaseRepositoryMock{
+					GetUsersByOwnerMock: func(contextControl domain.ContextControl, userType string, userID int64) ([]domain.PhoneUserDomain, error) {
+						return []domain.PhoneUserDomain{{PhoneID: 1, UserID: userID, UserType: userType, Primary: true}}, nil
+					},
+				},
+				ContractDomainDataBaseRepository: contractRepository,
+			}
+
+			phones, exists, err := phoneService.GetByOwner(domain.ContextControl{Context: context.Background(), ContractID: test.ContractID},
+				test.UserType, test.UserID)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedExists, exists)
+			assert.Equal(t, test.ExpectedExists, len(phones) == 1)
+		})
+	}
+}
+
+func TestPhoneService_SetPrimary(t *testing.T) {
+
+	var replaced domain.PhoneUserDomain
+	phoneService := PhoneService{
+		LoggerSugar: loggerSugar,
+		PhoneDomainDataBaseRepository: output.PhoneDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.PhoneDomain, bool, error) {
+				return domain.PhoneDomain{ID: ID}, true, nil
+			},
+			GetUserByPhoneIDMock: func(contextControl domain.ContextControl, phoneID int64) (domain.PhoneUserDomain, bool, error) {
+				return domain.PhoneUserDomain{ID: 7, PhoneID: phoneID, UserID: 1, UserType: PhoneUserTypeEmployee}, phoneID == 4, nil
+			},
+			SetPrimaryUserMock: func(contextControl domain.ContextControl, phoneUser domain.PhoneUserDomain) error {
+				replaced = phoneUser
+				return nil
+			},
+		},
+	}
+
+	result, linked, err := phoneService.SetPrimary(domain.ContextControl{Context: context.Background()}, 4)
+	assert.Nil(t, err)
+	assert.True(t, linked)
+	assert.True(t, result.Primary)
+	assert.Equal(t, domain.PhoneDomain{ID: 4}, result.Phone)
+	assert.Equal(t, int64(1), replaced.UserID)
+
+	_, linked, err = phoneService.SetPrimary(domain.ContextControl{Context: context.Background()}, 5)
+	assert.Nil(t, err)
+	assert.False(t, linked)
+}
@@ -1,22 +1,487 @@
 package service
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
 	"go.uber.org/zap"
 )
 
 type ScheduleService struct {
-	LoggerSugar *zap.SugaredLogger
+	LoggerSugar                           *zap.SugaredLogger
+	ScheduleDomainDataBaseRepository      output.IScheduleDomainDataBaseRepository
+	PetDomainDataBaseRepository           output.IPetDomainDataBaseRepository
+	AttentionTimeDomainDataBaseRepository output.IAttentionTimeDomainDataBaseRepository
+	ServiceDomainDataBaseRepository       output.IServiceDomainDataBaseRepository
+	EmployeeDomainDataBaseRepository      output.IEmployeeDomainDataBaseRepository
+	TransactionManager                    output.ITransactionManager
+	OutboxDomainDataBaseRepository        output.IOutboxDomainDataBaseRepository
+}
+
+// ScheduleBookingLayout is the layout of the booking date sent by the schedule channel.
+const ScheduleBookingLayout = "2006-01-02"
+
+// ScheduleMessageDefaultActor is recorded as the author of the bookings whose message has no actor.
+const ScheduleMessageDefaultActor = "schedule-channel"
+
+const (
+	ScheduleSuccessToCreate         = "schedule created with success"
+	ScheduleAlreadyCreated          = "schedule already created for this booking"
+	ScheduleInvalidBooking          = "the booking %q must follow the layout YYYY-MM-DD"
+	ScheduleBookingInThePast        = "the booking %s is in the past"
+	SchedulePetIsRequired           = "pet is required"
+	ScheduleAttentionTimeIsRequired = "service employee attention is required"
+	SchedulePetNotFound             = "the pet with id %d wasn't found"
+	ScheduleAttentionTimeNotFound   = "the service employee attention with id %d wasn't found"
+	ScheduleAttentionTimeInactive   = "the service employee attention with id %d isn't active"
+	ScheduleEmployeeNotFound        = "the employee with id %d wasn't found"
+	ScheduleEmployeeInactive        = "the employee with id %d isn't active"
+	ScheduleServiceNotFound         = "the service with id %d wasn't found"
+	ScheduleServiceInactive         = "the service with id %d isn't active"
+	SchedulePetFromAnotherContract  = "the pet %d doesn't belong to the contract of the attention %d"
+	ScheduleSlotTaken               = "the service employee attention %d is already booked on %s"
+	ScheduleEmployeeBusy            = "the employee %d is already booked on %s at the time of the service employee attention %d"
+	ScheduleSuccessToTransition     = "schedule status changed with success"
+	ScheduleIsRequired              = "schedule is required"
+	ScheduleActionInvalid           = "the action %q isn't a schedule action"
+	ScheduleActorIsRequired         = "the actor of the action is required"
+	ScheduleTransitionNotAllowed    = "the action %s isn't allowed for the schedule %d, which is %s"
+	ScheduleNotHappenedYet          = "the schedule %d is booked on %s and can't be marked before it"
+	ScheduleRescheduleSameSlot      = "the schedule %d is already booked on this attention time and date"
+	ScheduleRescheduleOtherService  = "the service employee attention %d isn't of the service of the schedule %d"
+)
+
+// scheduleMonthAbbreviations follows the pt-BR abbreviations used by the schedule number, e.g. 2023dez10.000001.
+var scheduleMonthAbbreviations = [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"}
+
+// FormatScheduleNumber builds the public schedule number from the booking date and a sequence value.
+func FormatScheduleNumber(bookedAt time.Time, sequence int64) string {
+	return fmt.Sprintf("%d%s%02d.%06d", bookedAt.Year(), scheduleMonthAbbreviations[bookedAt.Month()-1],
+		bookedAt.Day(), sequence)
 }
 
 func (ss ScheduleService) CreateFromMessage(contextControl domain.ContextControl, scheduleMessage domain.ScheduleMessage) error {
 
-	msg, _ := json.Marshal(scheduleMessage)
+	bookedAt, err := ss.ValidateMessage(scheduleMessage)
+	if err != nil {
+		return err
+	}
+
+	petID := int64(scheduleMessage.PetId)
+	attentionTimeID := int64(scheduleMessage.ServiceEmployeeAttentionId)
+
+	// a message may be delivered more than once, the booking identity tells whether it was already handled
+	existing, exists, err := ss.ScheduleDomainDataBaseRepository.GetByBooking(contextControl, petID, attentionTimeID, bookedAt)
+	if err != nil {
+		return err
+	}
+	if exists {
+		ss.LoggerSugar.Infow(ScheduleAlreadyCreated, "schedule_id", existing.ID, "number", existing.Number)
+		return nil
+	}
+
+	pet, exists, err := ss.PetDomainDataBaseRepository.GetByID(contextControl, petID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.NewValidationError(SchedulePetNotFound, petID)
+	}
+
+	attentionTime, err := ss.activeAttentionTime(contextControl, attentionTimeID)
+	if err != nil {
+		return err
+	}
+	if pet.ContractID != attentionTime.ContractID {
+		return domain.NewValidationError(SchedulePetFromAnotherContract, petID, attentionTimeID)
+	}
+
+	petshopService, exists, err := ss.ServiceDomainDataBaseRepository.GetByID(contextControl, attentionTime.ServiceID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.NewValidationError(ScheduleServiceNotFound, attentionTime.ServiceID)
+	}
+	if !petshopService.Active {
+		return domain.NewValidationError(ScheduleServiceInactive, attentionTime.ServiceID)
+	}
+
+	// the schedule keeps the catalog price in effect at booking time and the record it came from;
+	// services created before the price history existed fall back to their current price
+	price, priceHistoryID := petshopService.Price, int64(0)
+	currentPrice, exists, err := ss.ServiceDomainDataBaseRepository.GetCurrentPrice(contextControl, attentionTime.ServiceID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		price, priceHistoryID = currentPrice.Price, currentPrice.ID
+	}
+
+	// a booking of another pet already holds the attention time on that date
+	if _, taken, err := ss.ScheduleDomainDataBaseRepository.GetBySlot(contextControl, attentionTimeID, bookedAt); err != nil {
+		return err
+	} else if taken {
+		return domain.NewSlotTakenError(ScheduleSlotTaken, attentionTimeID, scheduleMessage.Booking)
+	}
+
+	sequence, err := ss.ScheduleDomainDataBaseRepository.NextNumberSequence(contextControl)
+	if err != nil {
+		return err
+	}
+
+	actor := scheduleMessage.Actor
+	if len(strings.TrimSpace(actor)) == 0 {
+		actor = ScheduleMessageDefaultActor
+	}
+
+	history := domain.ScheduleHistoryDomain{
+		Action:   domain.ScheduleActionRequest,
+		ToStatus: domain.ScheduleStatusRequested,
+		Actor:    actor,
+	}
+
+	var schedule domain.ScheduleDomain
+	err = saveWithEvents(ss.TransactionManager, ss.OutboxDomainDataBaseRepository, contextControl,
+		func(txControl domain.ContextControl) ([]domain.EventDomain, error) {
+			if err := ss.employeeFree(txControl, attentionTime, bookedAt, 0); err != nil {
+				return nil, err
+			}
+			var err error
+			if schedule, err = ss.ScheduleDomainDataBaseRepository.Save(txControl, domain.ScheduleDomain{
+				Number:          FormatScheduleNumber(bookedAt, sequence),
+				Status:          domain.ScheduleStatusRequested,
+				BookedAt:        bookedAt,
+				Price:           price,
+				PriceHistoryID:  priceHistoryID,
+				PetID:           petID,
+				AttentionTimeID: attentionTimeID,
+			}, history); err != nil {
+				return nil, err
+			}
+			return []domain.EventDomain{newScheduleEvent(txControl, schedule, history)}, nil
+		})
+	if errors.Is(err, domain.ErrConflict) {
+		// another consumer booked the slot or the employee between the lookup and the insert, either
+		// handling the same message again or a booking of another pet, which wins the slot
+		existing, exists, errGet := ss.ScheduleDomainDataBaseRepository.GetByBooking(contextControl, petID, attentionTimeID, bookedAt)
+		if errGet != nil {
+			return errGet
+		}
+		if exists {
+			ss.LoggerSugar.Infow(ScheduleAlreadyCreated, "schedule_id", existing.ID, "number", existing.Number)
+			return nil
+		}
+		if errors.Is(err, domain.ErrSlotTaken) {
+			return err
+		}
+		return domain.NewSlotTakenError(ScheduleSlotTaken, attentionTimeID, scheduleMessage.Booking)
+	}
+	if err != nil {
+		return err
+	}
+
+	ss.LoggerSugar.Infow(ScheduleSuccessToCreate, "schedule_id", schedule.ID, "number", schedule.Number)
+
+	return nil
+}
+
+// ValidateMessage checks the message fields and returns the parsed booking date.
+func (ss ScheduleService) ValidateMessage(scheduleMessage domain.ScheduleMessage) (time.Time, error) {
+
+	if scheduleMessage.PetId <= 0 {
+		return time.Time{}, domain.NewValidationError(SchedulePetIsRequired)
+	}
+
+	if scheduleMessage.ServiceEmployeeAttentionId <= 0 {
+		return time.Time{}, domain.NewValidationError(ScheduleAttentionTimeIsRequired)
+	}
+
+	return parseBooking(scheduleMessage.Booking)
+}
+
+// parseBooking reads a booking date, which can't be in the past.
+func parseBooking(booking string) (time.Time, error) {
+
+	bookedAt, err := time.Parse(ScheduleBookingLayout, booking)
+	if err != nil {
+		return time.Time{}, domain.NewValidationError(ScheduleInvalidBooking, booking)
+	}
+
+	if bookedAt.Before(today()) {
+		return time.Time{}, domain.NewValidationError(ScheduleBookingInThePast, booking)
+	}
+
+	return bookedAt, nil
+}
+
+// activeAttentionTime returns the attention time when both it and its employee are active.
+func (ss ScheduleService) activeAttentionTime(contextControl domain.ContextControl, attentionTimeID int64) (domain.AttentionTimeDomain, error) {
+
+	attentionTime, exists, err := ss.AttentionTimeDomainDataBaseRepository.GetByID(contextControl, attentionTimeID)
+	if err != nil {
+		return domain.AttentionTimeDomain{}, err
+	}
+	if !exists {
+		return domain.AttentionTimeDomain{}, domain.NewValidationError(ScheduleAttentionTimeNotFound, attentionTimeID)
+	}
+	if !attentionTime.Active {
+		return domain.AttentionTimeDomain{}, domain.NewValidationError(ScheduleAttentionTimeInactive, attentionTimeID)
+	}
+
+	employee, exists, err := ss.EmployeeDomainDataBaseRepository.GetByID(contextControl, attentionTime.EmployeeID)
+	if err != nil {
+		return domain.AttentionTimeDomain{}, err
+	}
+	if !exists {
+		return domain.AttentionTimeDomain{}, domain.NewValidationError(ScheduleEmployeeNotFound, attentionTime.EmployeeID)
+	}
+	if !employee.Active {
+		return domain.AttentionTimeDomain{}, domain.NewValidationError(ScheduleEmployeeInactive, attentionTime.EmployeeID)
+	}
+
+	return attentionTime, nil
+}
+
+// employeeFree checks, in the booking transaction, that no booking of the employee on the date
+// overlaps the attention time, whichever service it is for, as the availability lists it. The
+// schedule being rescheduled doesn't count. The employee stays locked until the transaction ends,
+// so a concurrent booking of an overlapping attention time waits for this one to be saved.
+func (ss ScheduleService) employeeFree(txControl domain.ContextControl, attentionTime domain.AttentionTimeDomain,
+	bookedAt time.Time, scheduleID int64) error {
+
+	if err := ss.ScheduleDomainDataBaseRepository.LockEmployee(txControl, attentionTime.EmployeeID); err != nil {
+		return err
+	}
+
+	attentionTimes, err := ss.AttentionTimeDomainDataBaseRepository.GetByEmployeeID(txControl, attentionTime.EmployeeID)
+	if err != nil {
+		return err
+	}
+	attentionTimes = append(attentionTimes, attentionTime)
+
+	attentionTimeIDs := make([]int64, 0, len(attentionTimes))
+	serviceDurations := make(map[int64]int)
+	for _, other := range attentionTimes {
 
-	ss.LoggerSugar.Infow("######## Conseguiu buscar", "message", string(msg))
+		attentionTimeIDs = append(attentionTimeIDs, other.ID)
+		if _, loaded := serviceDurations[other.ServiceID]; loaded {
+			continue
+		}
+
+		petshopService, exists, err := ss.ServiceDomainDataBaseRepository.GetByID(txControl, other.ServiceID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			serviceDurations[petshopService.ID] = petshopService.DurationMinutes
+		}
+	}
+
+	schedules, err := ss.ScheduleDomainDataBaseRepository.GetActiveByAttentionTimes(txControl, attentionTimeIDs, bookedAt, bookedAt)
+	if err != nil {
+		return err
+	}
+
+	others := make([]domain.ScheduleDomain, 0, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.ID != scheduleID {
+			others = append(others, schedule)
+		}
+	}
+
+	date := bookedAt.Format(ScheduleBookingLayout)
+	busy := busyByEmployee(attentionTimes, serviceDurations, others)[attentionTime.EmployeeID][date]
+	if len(busy) == 0 {
+		return nil
+	}
+
+	booking, err := busyIntervalOf(attentionTime, serviceDurations[attentionTime.ServiceID])
+	if err != nil {
+		return err
+	}
+	if overlapsAny(booking.start, booking.end, busy) {
+		return domain.NewSlotTakenError(ScheduleEmployeeBusy, attentionTime.EmployeeID, date, attentionTime.ID)
+	}
 
 	return nil
+}
+
+// ApplyCommand moves the schedule through its lifecycle by the action of the command and records
+// the transition with its actor. An action the current status doesn't allow is a conflict.
+func (ss ScheduleService) ApplyCommand(contextControl domain.ContextControl, command domain.ScheduleCommand) (domain.ScheduleDomain, bool, error) {
+
+	if err := ss.ValidateCommand(command); err != nil {
+		return domain.ScheduleDomain{}, false, err
+	}
+
+	schedule, exists, err := ss.ScheduleDomainDataBaseRepository.GetByID(contextControl, command.ScheduleID)
+	if err != nil || !exists {
+		return domain.ScheduleDomain{}, false, err
+	}
+
+	next, allowed := command.Action.Next(schedule.Status)
+	if !allowed {
+		return domain.ScheduleDomain{}, true,
+			domain.NewConflictError(ScheduleTransitionNotAllowed, command.Action, schedule.ID, schedule.Status)
+	}
+
+	updated := schedule
+	updated.Status = next
+	history := domain.ScheduleHistoryDomain{
+		ScheduleID: schedule.ID,
+		Action:     command.Action,
+		FromStatus: schedule.Status,
+		ToStatus:   next,
+		Actor:      command.Actor,
+		Reason:     command.Reason,
+	}
+
+	var attentionTime domain.AttentionTimeDomain
+	switch command.Action {
+	case domain.ScheduleActionComplete, domain.ScheduleActionNoShow:
+		if today().Before(schedule.BookedAt) {
+			return domain.ScheduleDomain{}, true, domain.NewValidationError(ScheduleNotHappenedYet,
+				schedule.ID, schedule.BookedAt.Format(ScheduleBookingLayout))
+		}
+	case domain.ScheduleActionDecline:
+		declinedAt := time.Now()
+		updated.DateDeclined = &declinedAt
+	case domain.ScheduleActionReschedule:
+		updated.BookedAt, attentionTime, err = ss.rescheduleSlot(contextControl, schedule, command)
+		if err != nil {
+			return domain.ScheduleDomain{}, true, err
+		}
+		updated.AttentionTimeID = attentionTime.ID
+		previousBookedAt := schedule.BookedAt
+		history.PreviousBookedAt = &previousBookedAt
+		history.PreviousAttentionTimeID = schedule.AttentionTimeID
+	}
+
+	if err = saveWithEvents(ss.TransactionManager, ss.OutboxDomainDataBaseRepository, contextControl,
+		func(txControl domain.ContextControl) ([]domain.EventDomain, error) {
+			if command.Action == domain.ScheduleActionReschedule {
+				if err := ss.employeeFree(txControl, attentionTime, updated.BookedAt, schedule.ID); err != nil {
+					return nil, err
+				}
+			}
+			if err := ss.ScheduleDomainDataBaseRepository.Transition(txControl, updated, schedule.Status, history); err != nil {
+				return nil, err
+			}
+			return []domain.EventDomain{newScheduleEvent(txControl, updated, history)}, nil
+		}); err != nil {
+		return domain.ScheduleDomain{}, true, err
+	}
+
+	ss.LoggerSugar.Infow(ScheduleSuccessToTransition, "schedule_id", schedule.ID, "action", command.Action,
+		"from", schedule.Status, "to", next, "actor", command.Actor)
+
+	return updated, true, nil
+}
+
+// newScheduleEvent announces the transition recorded by the history, keyed by the schedule so
+// the events of a schedule keep their order.
+func newScheduleEvent(contextControl domain.ContextControl, schedule domain.ScheduleDomain,
+	history domain.ScheduleHistoryDomain) domain.EventDomain {
+
+	payload := domain.ScheduleEvent{
+		ID:                      schedule.ID,
+		Number:                  schedule.Number,
+		Status:                  string(schedule.Status),
+		BookedAt:                schedule.BookedAt.Format(ScheduleBookingLayout),
+		Price:                   schedule.Price,
+		PetID:                   schedule.PetID,
+		AttentionTimeID:         schedule.AttentionTimeID,
+		Actor:                   history.Actor,
+		Reason:                  history.Reason,
+		PreviousAttentionTimeID: history.PreviousAttentionTimeID,
+	}
+	if history.PreviousBookedAt != nil {
+		payload.PreviousBookedAt = history.PreviousBookedAt.Format(ScheduleBookingLayout)
+	}
+
+	return domain.NewEvent(contextControl, history.Action.EventType(), domain.ScheduleEventVersion,
+		strconv.FormatInt(schedule.ID, 10), payload)
+}
+
+// rescheduleSlot checks the new slot of a reschedule: a free and active attention time of the
+// service already booked, so the price of the schedule still applies.
+func (ss ScheduleService) rescheduleSlot(contextControl domain.ContextControl, schedule domain.ScheduleDomain,
+	command domain.ScheduleCommand) (time.Time, domain.AttentionTimeDomain, error) {
+
+	if command.AttentionTimeID <= 0 {
+		return time.Time{}, domain.AttentionTimeDomain{}, domain.NewValidationError(ScheduleAttentionTimeIsRequired)
+	}
+
+	bookedAt, err := parseBooking(command.Booking)
+	if err != nil {
+		return time.Time{}, domain.AttentionTimeDomain{}, err
+	}
+
+	if bookedAt.Equal(schedule.BookedAt) && command.AttentionTimeID == schedule.AttentionTimeID {
+		return time.Time{}, domain.AttentionTimeDomain{}, domain.NewValidationError(ScheduleRescheduleSameSlot, schedule.ID)
+	}
+
+	current, _, err := ss.AttentionTimeDomainDataBaseRepository.GetByID(contextControl, schedule.AttentionTimeID)
+	if err != nil {
+		return time.Time{}, domain.AttentionTimeDomain{}, err
+	}
+
+	attentionTime, err := ss.activeAttentionTime(contextControl, command.AttentionTimeID)
+	if err != nil {
+		return time.Time{}, domain.AttentionTimeDomain{}, err
+	}
+	if attentionTime.ServiceID != current.ServiceID {
+		return time.Time{}, domain.AttentionTimeDomain{}, domain.NewValidationError(ScheduleRescheduleOtherService, command.AttentionTimeID, schedule.ID)
+	}
+
+	if holder, taken, err := ss.ScheduleDomainDataBaseRepository.GetBySlot(contextControl, command.AttentionTimeID, bookedAt); err != nil {
+		return time.Time{}, domain.AttentionTimeDomain{}, err
+	} else if taken && holder.ID != schedule.ID {
+		return time.Time{}, domain.AttentionTimeDomain{}, domain.NewSlotTakenError(ScheduleSlotTaken, command.AttentionTimeID, command.Booking)
+	}
+
+	return bookedAt, attentionTime, nil
+}
+
+// ValidateCommand checks the schedule, the action and the actor of the command.
+func (ss ScheduleService) ValidateCommand(command domain.ScheduleCommand) error {
+
+	if command.ScheduleID <= 0 {
+		return domain.NewValidationError(ScheduleIsRequired)
+	}
+
+	if !command.Action.IsKnown() {
+		return domain.NewValidationError(ScheduleActionInvalid, command.Action)
+	}
+
+	if len(strings.TrimSpace(command.Actor)) == 0 {
+		return domain.NewValidationError(ScheduleActorIsRequired)
+	}
+
+	return nil
+}
+
+func (ss ScheduleService) GetByID(contextControl domain.ContextControl, ID int64) (domain.ScheduleDomain, bool, error) {
+	return ss.ScheduleDomainDataBaseRepository.GetByID(contextControl, ID)
+}
+
+// GetHistory lists the transitions of a known schedule, oldest first.
+func (ss ScheduleService) GetHistory(contextControl domain.ContextControl, ID int64) ([]domain.ScheduleHistoryDomain, bool, error) {
+
+	if _, exists, err := ss.ScheduleDomainDataBaseRepository.GetByID(contextControl, ID); err != nil || !exists {
+		return nil, false, err
+	}
+
+	histories, err := ss.ScheduleDomainDataBaseRepository.GetHistory(contextControl, ID)
+	if err != nil {
+		return nil, true, err
+	}
 
+	return histories, true, nil
 }
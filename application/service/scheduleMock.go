@@ -0,0 +1,38 @@
+package service
+
+import "github.com/petshop-system/petshop-api/application/domain"
+
+type ScheduleMock struct {
+	CreateFromMessageMock func(contextControl domain.ContextControl, message domain.ScheduleMessage) error
+	ApplyCommandMock      func(contextControl domain.ContextControl, command domain.ScheduleCommand) (domain.ScheduleDomain, bool, error)
+	GetByIDMock           func(contextControl domain.ContextControl, ID int64) (domain.ScheduleDomain, bool, error)
+	GetHistoryMock        func(contextControl domain.ContextControl, ID int64) ([]domain.ScheduleHistoryDomain, bool, error)
+}
+
+func (c ScheduleMock) CreateFromMessage(contextControl domain.ContextControl, message domain.ScheduleMessage) error {
+	if c.CreateFromMessageMock != nil {
+		return c.CreateFromMessageMock(contextControl, message)
+	}
+	return nil
+}
+
+func (c ScheduleMock) ApplyCommand(contextControl domain.ContextControl, command domain.ScheduleCommand) (domain.ScheduleDomain, bool, error) {
+	if c.ApplyCommandMock != nil {
+		return c.ApplyCommandMock(contextControl, command)
+	}
+	return domain.ScheduleDomain{}, false, nil
+}
+
+func (c ScheduleMock) GetByID(contextControl domain.ContextControl, ID int64) (domain.ScheduleDomain, bool, error) {
+	if c.GetByIDMock != nil {
+		return c.GetByIDMock(contextControl, ID)
+	}
+	return domain.ScheduleDomain{}, false, nil
+}
+
+func (c ScheduleMock) GetHistory(contextControl domain.ContextControl, ID int64) ([]domain.ScheduleHistoryDomain, bool, error) {
+	if c.GetHistoryMock != nil {
+		return c.GetHistoryMock(contextControl, ID)
+	}
+	return nil, false, nil
+}
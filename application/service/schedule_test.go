@@ -0,0 +1,717 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatScheduleNumber(t *testing.T) {
+	bookedAt := time.Date(2023, time.December, 10, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2023dez10.000001", FormatScheduleNumber(bookedAt, 1))
+	assert.Equal(t, "2024fev03.123456", FormatScheduleNumber(time.Date(2024, time.February, 3, 0, 0, 0, 0, time.UTC), 123456))
+}
+
+func TestScheduleService_CreateFromMessage(t *testing.T) {
+
+	booking := time.Now().AddDate(0, 0, 1).Format(ScheduleBookingLayout)
+	bookedAt, _ := time.Parse(ScheduleBookingLayout, booking)
+
+	petFound := output.PetDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.PetDomain, bool, error) {
+			return domain.PetDomain{ID: ID, Name: "Rex", ContractID: 1}, true, nil
+		},
+	}
+
+	attentionTimeActive := output.AttentionTimeDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.AttentionTimeDomain, bool, error) {
+			return domain.AttentionTimeDomain{ID: ID, InitialTime: "9:00", Active: true, ServiceID: 2, ContractID: 1, EmployeeID: 1}, true, nil
+		},
+	}
+
+	serviceActive := output.ServiceDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error) {
+			return domain.ServiceDomain{ID: ID, Name: "BANHO", Price: 5599, Active: true, ContractID: 1}, true, nil
+		},
+		GetCurrentPriceMock: func(contextControl domain.ContextControl, serviceID int64) (domain.ServicePriceHistoryDomain, bool, error) {
+			return domain.ServicePriceHistoryDomain{ID: 4, ServiceID: serviceID, Price: 5599, ContractID: 1}, true, nil
+		},
+	}
+
+	employeeActive := output.EmployeeDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+			return domain.EmployeeDomain{ID: ID, Name: "Fulana", Active: true, ContractID: 1}, true, nil
+		},
+	}
+
+	tests := []struct {
+		Name                                  string
+		Message                               domain.ScheduleMessage
+		PetDomainDataBaseRepository           output.IPetDomainDataBaseRepository
+		AttentionTimeDomainDataBaseRepository output.IAttentionTimeDomainDataBaseRepository
+		ServiceDomainDataBaseRepository       output.IServiceDomainDataBaseRepository
+		EmployeeDomainDataBaseRepository      output.IEmployeeDomainDataBaseRepository
+		ExpectedSaved                         *domain.ScheduleDomain
+		ExpectedError                         error
+	}{
+		{
+			Name:                                  "WithValidMessage_SavesScheduleWithCurrentCatalogPrice",
+			Message:                               domain.ScheduleMessage{Booking: booking, PetId: 1, ServiceEmployeeAttentionId: 2},
+			PetDomainDataBaseRepository:           petFound,
+			AttentionTimeDomainDataBaseRepository: attentionTimeActive,
+			ServiceDomainDataBaseRepository:       serviceActive,
+			EmployeeDomainDataBaseRepository:      employeeActive,
+			ExpectedSaved: &domain.ScheduleDomain{
+				Number:          FormatScheduleNumber(bookedAt, 7),
+				Status:          domain.ScheduleStatusRequested,
+				BookedAt:        bookedAt,
+				Price:           5599,
+				PriceHistoryID:  4,
+				PetID:           1,
+				AttentionTimeID: 2,
+			},
+			ExpectedError: nil,
+		},
+		{
+			Name:                                  "WithoutPriceHistory_SavesScheduleWithServicePrice",
+			Message:                               domain.ScheduleMessage{Booking: booking, PetId: 1, ServiceEmployeeAttentionId: 2},
+			PetDomainDataBaseRepository:           petFound,
+			AttentionTimeDomainDataBaseRepository: attentionTimeActive,
+			ServiceDomainDataBaseRepository: output.ServiceDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error) {
+					return domain.ServiceDomain{ID: ID, Name: "TOSA", Price: 5065, Active: true, ContractID: 1}, true, nil
+				},
+			},
+			EmployeeDomainDataBaseRepository: employeeActive,
+			ExpectedSaved: &domain.ScheduleDomain{
+				Number:          FormatScheduleNumber(bookedAt, 7),
+				Status:          domain.ScheduleStatusRequested,
+				BookedAt:        bookedAt,
+				Price:           5065,
+				PetID:           1,
+				AttentionTimeID: 2,
+			},
+			ExpectedError: nil,
+		},
+		{
+			Name:                                  "WithInvalidBooking_ReturnsValidationError",
+			Message:                               domain.ScheduleMessage{Booking: "10/12/2023", PetId: 1, ServiceEmployeeAttentionId: 2},
+			PetDomainDataBaseRepository:           petFound,
+			AttentionTimeDomainDataBaseRepository: attentionTimeActive,
+			ServiceDomainDataBaseRepository:       serviceActive,
+			EmployeeDomainDataBaseRepository:      employeeActive,
+			ExpectedError:                         domain.NewValidationError(ScheduleInvalidBooking, "10/12/2023"),
+		},
+		{
+			Name:                                  "WithUnknownPet_ReturnsValidationError",
+			Message:                               domain.ScheduleMessage{Booking: booking, PetId: 9, ServiceEmployeeAttentionId: 2},
+			PetDomainDataBaseRepository:           output.PetDomainDataBaseRepositoryMock{},
+			AttentionTimeDomainDataBaseRepository: attentionTimeActive,
+			ServiceDomainDataBaseRepository:       serviceActive,
+			EmployeeDomainDataBaseRepository:      employeeActive,
+			ExpectedError:                         domain.NewValidationError(SchedulePetNotFound, int64(9)),
+		},
+		{
+			Name:                        "WithInactiveAttention_ReturnsValidationError",
+			Message:                     domain.ScheduleMessage{Booking: booking, PetId: 1, ServiceEmployeeAttentionId: 7},
+			PetDomainDataBaseRepository: petFound,
+			AttentionTimeDomainDataBaseRepository: output.AttentionTimeDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.AttentionTimeDomain, bool, error) {
+					return domain.AttentionTimeDomain{ID: ID, Active: false, ServiceID: 3, ContractID: 1}, true, nil
+				},
+			},
+			ServiceDomainDataBaseRepository:  serviceActive,
+			EmployeeDomainDataBaseRepository: employeeActive,
+			ExpectedError:                    domain.NewValidationError(ScheduleAttentionTimeInactive, int64(7)),
+		},
+		{
+			Name:                                  "WithUnknownAttention_ReturnsValidationError",
+			Message:                               domain.ScheduleMessage{Booking: booking, PetId: 1, ServiceEmployeeAttentionId: 99},
+			PetDomainDataBaseRepository:           petFound,
+			AttentionTimeDomainDataBaseRepository: output.AttentionTimeDomainDataBaseRepositoryMock{},
+			ServiceDomainDataBaseRepository:       serviceActive,
+			EmployeeDomainDataBaseRepository:      employeeActive,
+			ExpectedError:                         domain.NewValidationError(ScheduleAttentionTimeNotFound, int64(99)),
+		},
+		{
+			Name:                                  "WithInactiveEmployee_ReturnsValidationError",
+			Message:                               domain.ScheduleMessage{Booking: booking, PetId: 1, ServiceEmployeeAttentionId: 2},
+			PetDomainDataBaseRepository:           petFound,
+			AttentionTimeDomainDataBaseRepository: attentionTimeActive,
+			ServiceDomainDataBaseRepository:       serviceActive,
+			EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+					return domain.EmployeeDomain{ID: ID, Active: false, ContractID: 1}, true, nil
+				},
+			},
+			ExpectedError: domain.NewValidationError(ScheduleEmployeeInactive, int64(1)),
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			var saved *domain.ScheduleDomain
+			var savedHistory domain.ScheduleHistoryDomain
+			scheduleService := ScheduleService{
+				LoggerSugar:                    loggerSugar,
+				OutboxDomainDataBaseRepository: output.OutboxDomainDataBaseRepositoryMock{},
+				ScheduleDomainDataBaseRepository: output.ScheduleDomainDataBaseRepositoryMock{
+					SaveMock: func(contextControl domain.ContextControl, schedule domain.ScheduleDomain, history domain.ScheduleHistoryDomain) (domain.ScheduleDomain, error) {
+						saved = &schedule
+						savedHistory = history
+						return domain.ScheduleDomain{ID: 1, Number: schedule.Number}, nil
+					},
+					NextNumberSequenceMock: func(contextControl domain.ContextControl) (int64, error) {
+						return 7, nil
+					},
+				},
+				PetDomainDataBaseRepository:           test.PetDomainDataBaseRepository,
+				AttentionTimeDomainDataBaseRepository: test.AttentionTimeDomainDataBaseRepository,
+				ServiceDomainDataBaseRepository:       test.ServiceDomainDataBaseRepository,
+				EmployeeDomainDataBaseRepository:      test.EmployeeDomainDataBaseRepository,
+			}
+
+			err := scheduleService.CreateFromMessage(domain.ContextControl{Context: context.Background()}, test.Message)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedSaved, saved)
+			if test.ExpectedSaved != nil {
+				assert.Equal(t, domain.ScheduleHistoryDomain{
+					Action:   domain.ScheduleActionRequest,
+					ToStatus: domain.ScheduleStatusRequested,
+					Actor:    ScheduleMessageDefaultActor,
+				}, savedHistory)
+			}
+		})
+	}
+}
+
+func TestScheduleService_CreateFromMessage_RedeliveredBooking(t *testing.T) {
+
+	booking := time.Now().AddDate(0, 0, 1).Format(ScheduleBookingLayout)
+	message := domain.ScheduleMessage{Booking: booking, PetId: 1, ServiceEmployeeAttentionId: 2}
+
+	scheduleService := ScheduleService{
+		LoggerSugar:                    loggerSugar,
+		OutboxDomainDataBaseRepository: output.OutboxDomainDataBaseRepositoryMock{},
+		PetDomainDataBaseRepository: output.PetDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.PetDomain, bool, error) {
+				return domain.PetDomain{ID: ID, ContractID: 1}, true, nil
+			},
+		},
+		AttentionTimeDomainDataBaseRepository: output.AttentionTimeDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.AttentionTimeDomain, bool, error) {
+				return domain.AttentionTimeDomain{ID: ID, Active: true, ServiceID: 2, ContractID: 1}, true, nil
+			},
+		},
+		ServiceDomainDataBaseRepository: output.ServiceDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error) {
+				return domain.ServiceDomain{ID: ID, Price: 5599, Active: true, ContractID: 1}, true, nil
+			},
+		},
+		EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+				return domain.EmployeeDomain{ID: ID, Active: true, ContractID: 1}, true, nil
+			},
+		},
+	}
+
+	t.Run("WithBookingAlreadySaved_SkipsTheInsert", func(t *testing.T) {
+
+		saves := 0
+		scheduleService.ScheduleDomainDataBaseRepository = output.ScheduleDomainDataBaseRepositoryMock{
+			GetByBookingMock: func(contextControl domain.ContextControl, petID, attentionTimeID int64, bookedAt time.Time) (domain.ScheduleDomain, bool, error) {
+				return domain.ScheduleDomain{ID: 10, PetID: petID, AttentionTimeID: attentionTimeID, BookedAt: bookedAt}, true, nil
+			},
+			SaveMock: func(contextControl domain.ContextControl, schedule domain.ScheduleDomain, history domain.ScheduleHistoryDomain) (domain.ScheduleDomain, error) {
+				saves++
+				return schedule, nil
+			},
+		}
+
+		err := scheduleService.CreateFromMessage(domain.ContextControl{Context: context.Background()}, message)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, saves)
+	})
+
+	t.Run("WithConcurrentInsertOfTheSameBooking_IgnoresTheConflict", func(t *testing.T) {
+
+		lookups := 0
+		scheduleService.ScheduleDomainDataBaseRepository = output.ScheduleDomainDataBaseRepositoryMock{
+			GetByBookingMock: func(contextControl domain.ContextControl, petID, attentionTimeID int64, bookedAt time.Time) (domain.ScheduleDomain, bool, error) {
+				lookups++
+				return domain.ScheduleDomain{ID: 10}, lookups > 1, nil
+			},
+			SaveMock: func(contextControl domain.ContextControl, schedule domain.ScheduleDomain, history domain.ScheduleHistoryDomain) (domain.ScheduleDomain, error) {
+				return domain.ScheduleDomain{}, domain.NewConflictError("there is already a schedule for this booking")
+			},
+		}
+
+		err := scheduleService.CreateFromMessage(domain.ContextControl{Context: context.Background()}, message)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, lookups)
+	})
+}
+
+// inMemorySchedules enforces one schedule per attention time and date like the unique index does.
+type inMemorySchedules struct {
+	output.ScheduleDomainDataBaseRepositoryMock
+	mutex sync.Mutex
+	slots map[string]domain.ScheduleDomain
+}
+
+func newInMemorySchedules() *inMemorySchedules {
+
+	schedules := &inMemorySchedules{slots: make(map[string]domain.ScheduleDomain)}
+	slotKey := func(attentionTimeID int64, bookedAt time.Time) string {
+		return fmt.Sprintf("%d:%s", attentionTimeID, bookedAt.Format(ScheduleBookingLayout))
+	}
+
+	schedules.GetBySlotMock = func(contextControl domain.ContextControl, attentionTimeID int64, bookedAt time.Time) (domain.ScheduleDomain, bool, error) {
+		schedules.mutex.Lock()
+		defer schedules.mutex.Unlock()
+		schedule, exists := schedules.slots[slotKey(attentionTimeID, bookedAt)]
+		return schedule, exists, nil
+	}
+	schedules.GetByBookingMock = func(contextControl domain.ContextControl, petID, attentionTimeID int64, bookedAt time.Time) (domain.ScheduleDomain, bool, error) {
+		schedule, exists, err := schedules.GetBySlotMock(contextControl, attentionTimeID, bookedAt)
+		return schedule, exists && schedule.PetID == petID, err
+	}
+	schedules.SaveMock = func(contextControl domain.ContextControl, schedule domain.ScheduleDomain, history domain.ScheduleHistoryDomain) (domain.ScheduleDomain, error) {
+		schedules.mutex.Lock()
+		defer schedules.mutex.Unlock()
+		key := slotKey(schedule.AttentionTimeID, schedule.BookedAt)
+		if _, exists := schedules.slots[key]; exists {
+			return domain.ScheduleDomain{}, domain.NewConflictError("there is already a schedule for this attention time and date")
+		}
+		schedule.ID = int64(len(schedules.slots) + 1)
+		schedules.slots[key] = schedule
+		return schedule, nil
+	}
+	schedules.GetActiveByAttentionTimesMock = func(contextControl domain.ContextControl, attentionTimeIDs []int64, from, to time.Time) ([]domain.ScheduleDomain, error) {
+		schedules.mutex.Lock()
+		defer schedules.mutex.Unlock()
+		var active []domain.ScheduleDomain
+		for _, schedule := range schedules.slots {
+			if slices.Contains(attentionTimeIDs, schedule.AttentionTimeID) &&
+				!schedule.BookedAt.Before(from) && !schedule.BookedAt.After(to) {
+				active = append(active, schedule)
+			}
+		}
+		return active, nil
+	}
+
+	return schedules
+}
+
+func TestScheduleService_CreateFromMessage_SlotTaken(t *testing.T) {
+
+	booking := time.Now().AddDate(0, 0, 1).Format(ScheduleBookingLayout)
+
+	newScheduleService := func(schedules output.IScheduleDomainDataBaseRepository) ScheduleService {
+		return ScheduleService{
+			LoggerSugar:                      loggerSugar,
+			OutboxDomainDataBaseRepository:   output.OutboxDomainDataBaseRepositoryMock{},
+			ScheduleDomainDataBaseRepository: schedules,
+			PetDomainDataBaseRepository: output.PetDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.PetDomain, bool, error) {
+					return domain.PetDomain{ID: ID, ContractID: 1}, true, nil
+				},
+			},
+			AttentionTimeDomainDataBaseRepository: output.AttentionTimeDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.AttentionTimeDomain, bool, error) {
+					return domain.AttentionTimeDomain{ID: ID, Active: true, ServiceID: 2, ContractID: 1, EmployeeID: 1}, true, nil
+				},
+			},
+			ServiceDomainDataBaseRepository: output.ServiceDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error) {
+					return domain.ServiceDomain{ID: ID, Price: 5599, Active: true, ContractID: 1}, true, nil
+				},
+			},
+			EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+					return domain.EmployeeDomain{ID: ID, Active: true, ContractID: 1}, true, nil
+				},
+			},
+		}
+	}
+
+	t.Run("WithSlotHeldByAnotherPet_ReturnsSlotTaken", func(t *testing.T) {
+
+		schedules := newInMemorySchedules()
+		scheduleService := newScheduleService(schedules)
+
+		err := scheduleService.CreateFromMessage(domain.ContextControl{Context: context.Background()},
+			domain.ScheduleMessage{Booking: booking, PetId: 1, ServiceEmployeeAttentionId: 2})
+		assert.Nil(t, err)
+
+		err = scheduleService.CreateFromMessage(domain.ContextControl{Context: context.Background()},
+			domain.ScheduleMessage{Booking: booking, PetId: 2, ServiceEmployeeAttentionId: 2})
+		assert.ErrorIs(t, err, domain.ErrSlotTaken)
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		assert.Len(t, schedules.slots, 1)
+	})
+
+	t.Run("WithConcurrentBookingsOfTheSameSlot_OnlyOneWins", func(t *testing.T) {
+
+		schedules := newInMemorySchedules()
+		scheduleService := newScheduleService(schedules)
+
+		const consumers = 10
+		errs := make([]error, consumers)
+		var wg sync.WaitGroup
+		for i := range consumers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = scheduleService.CreateFromMessage(domain.ContextControl{Context: context.Background()},
+					domain.ScheduleMessage{Booking: booking, PetId: i + 1, ServiceEmployeeAttentionId: 2})
+			}()
+		}
+		wg.Wait()
+
+		winners := 0
+		for _, err := range errs {
+			if err == nil {
+				winners++
+				continue
+			}
+			assert.ErrorIs(t, err, domain.ErrSlotTaken)
+		}
+		assert.Equal(t, 1, winners)
+		assert.Len(t, schedules.slots, 1)
+	})
+}
+
+func TestScheduleService_EmployeeBusy(t *testing.T) {
+
+	booking := time.Now().AddDate(0, 0, 1).Format(ScheduleBookingLayout)
+
+	// the employee 1 bathes (service 2, 60 minutes) at 09:00 on the attention time 1 and clips
+	// (service 3, 30 minutes) at 09:00 on the attention time 2 and at 10:00 on the attention time 3
+	employeeAttentionTimes := []domain.AttentionTimeDomain{
+		{ID: 1, InitialTime: "09:00", FinalTime: "12:00", Active: true, ServiceID: 2, EmployeeID: 1, ContractID: 1},
+		{ID: 2, InitialTime: "09:00", FinalTime: "12:00", Active: true, ServiceID: 3, EmployeeID: 1, ContractID: 1},
+		{ID: 3, InitialTime: "10:00", FinalTime: "12:00", Active: true, ServiceID: 3, EmployeeID: 1, ContractID: 1},
+	}
+
+	newScheduleService := func(schedules *inMemorySchedules, locked *[]int64) ScheduleService {
+		schedules.LockEmployeeMock = func(contextControl domain.ContextControl, employeeID int64) error {
+			assert.True(t, output.InTransaction(contextControl))
+			*locked = append(*locked, employeeID)
+			return nil
+		}
+		schedules.GetByIDMock = func(contextControl domain.ContextControl, ID int64) (domain.ScheduleDomain, bool, error) {
+			for _, schedule := range schedules.slots {
+				if schedule.ID == ID {
+					return schedule, true, nil
+				}
+			}
+			return domain.ScheduleDomain{}, false, nil
+		}
+		return ScheduleService{
+			LoggerSugar:                      loggerSugar,
+			TransactionManager:               &output.TransactionManagerMock{},
+			OutboxDomainDataBaseRepository:   output.OutboxDomainDataBaseRepositoryMock{},
+			ScheduleDomainDataBaseRepository: schedules,
+			PetDomainDataBaseRepository: output.PetDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.PetDomain, bool, error) {
+					return domain.PetDomain{ID: ID, ContractID: 1}, true, nil
+				},
+			},
+			AttentionTimeDomainDataBaseRepository: output.AttentionTimeDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.AttentionTimeDomain, bool, error) {
+					return employeeAttentionTimes[ID-1], true, nil
+				},
+				GetByEmployeeIDMock: func(contextControl domain.ContextControl, employeeID int64) ([]domain.AttentionTimeDomain, error) {
+					return employeeAttentionTimes, nil
+				},
+			},
+			ServiceDomainDataBaseRepository: output.ServiceDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error) {
+					duration := 60
+					if ID == 3 {
+						duration = 30
+					}
+					return domain.ServiceDomain{ID: ID, Price: 5599, DurationMinutes: duration, Active: true, ContractID: 1}, true, nil
+				},
+			},
+			EmployeeDomainDataBaseRepository: output.EmployeeDomainDataBaseRepositoryMock{
+				GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+					return domain.EmployeeDomain{ID: ID, Active: true, ContractID: 1}, true, nil
+				},
+			},
+		}
+	}
+
+	t.Run("WithOverlappingBookingOfAnotherService_ReturnsSlotTaken", func(t *testing.T) {
+
+		var locked []int64
+		schedules := newInMemorySchedules()
+		scheduleService := newScheduleService(schedules, &locked)
+
+		err := scheduleService.CreateFromMessage(domain.ContextControl{Context: context.Background()},
+			domain.ScheduleMessage{Booking: booking, PetId: 1, ServiceEmployeeAttentionId: 1})
+		assert.Nil(t, err)
+
+		err = scheduleService.CreateFromMessage(domain.ContextControl{Context: context.Background()},
+			domain.ScheduleMessage{Booking: booking, PetId: 2, ServiceEmployeeAttentionId: 2})
+		assert.Equal(t, domain.NewSlotTakenError(ScheduleEmployeeBusy, 1, booking, 2), err)
+		assert.Len(t, schedules.slots, 1)
+		assert.Equal(t, []int64{1, 1}, locked)
+	})
+
+	t.Run("WithBookingAfterTheEndOfTheService_CreatesTheSchedule", func(t *testing.T) {
+
+		var locked []int64
+		schedules := newInMemorySchedules()
+		scheduleService := newScheduleService(schedules, &locked)
+
+		err := scheduleService.CreateFromMessage(domain.ContextControl{Context: context.Background()},
+			domain.ScheduleMessage{Booking: booking, PetId: 1, ServiceEmployeeAttentionId: 1})
+		assert.Nil(t, err)
+
+		err = scheduleService.CreateFromMessage(domain.ContextControl{Context: context.Background()},
+			domain.ScheduleMessage{Booking: booking, PetId: 2, ServiceEmployeeAttentionId: 3})
+		assert.Nil(t, err)
+		assert.Len(t, schedules.slots, 2)
+	})
+
+	t.Run("WithRescheduleOverlappingAnotherBooking_ReturnsSlotTaken", func(t *testing.T) {
+
+		var locked []int64
+		schedules := newInMemorySchedules()
+		scheduleService := newScheduleService(schedules, &locked)
+
+		// the clip at 10:00 moves to 09:00, where the bath of the employee already is
+		err := scheduleService.CreateFromMessage(domain.ContextControl{Context: context.Background()},
+			domain.ScheduleMessage{Booking: booking, PetId: 1, ServiceEmployeeAttentionId: 1})
+		assert.Nil(t, err)
+		err = scheduleService.CreateFromMessage(domain.ContextControl{Context: context.Background()},
+			domain.ScheduleMessage{Booking: booking, PetId: 2, ServiceEmployeeAttentionId: 3})
+		assert.Nil(t, err)
+
+		_, exists, err := scheduleService.ApplyCommand(domain.ContextControl{Context: context.Background()},
+			domain.ScheduleCommand{ScheduleID: 2, Action: domain.ScheduleActionReschedule, Actor: "siclano",
+				Booking: booking, AttentionTimeID: 2})
+		assert.True(t, exists)
+		assert.Equal(t, domain.NewSlotTakenError(ScheduleEmployeeBusy, 1, booking, 2), err)
+	})
+}
+
+func TestScheduleService_ApplyCommand(t *testing.T) {
+
+	tomorrow := today().AddDate(0, 0, 1)
+	yesterday := today().AddDate(0, 0, -1)
+	nextWeek := today().AddDate(0, 0, 7)
+
+	// attention times 2 and 3 are of the service 2, the attention time 5 of the service 9
+	attentionTimes := output.AttentionTimeDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.AttentionTimeDomain, bool, error) {
+			serviceID := int64(2)
+			if ID == 5 {
+				serviceID = 9
+			}
+			return domain.AttentionTimeDomain{ID: ID, Active: true, ServiceID: serviceID, ContractID: 1, EmployeeID: 1}, true, nil
+		},
+	}
+
+	employees := output.EmployeeDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.EmployeeDomain, bool, error) {
+			return domain.EmployeeDomain{ID: ID, Active: true, ContractID: 1}, true, nil
+		},
+	}
+
+	schedule := func(status domain.ScheduleStatus, bookedAt time.Time) *domain.ScheduleDomain {
+		return &domain.ScheduleDomain{ID: 10, Number: "2030dez10.000001", Status: status, BookedAt: bookedAt,
+			Price: 5599, PetID: 1, AttentionTimeID: 2}
+	}
+
+	tests := []struct {
+		Name               string
+		Schedule           *domain.ScheduleDomain
+		Command            domain.ScheduleCommand
+		TransitionError    error
+		ExpectedExists     bool
+		ExpectedStatus     domain.ScheduleStatus
+		ExpectedBookedAt   time.Time
+		ExpectedAttention  int64
+		ExpectedTransition bool
+		ExpectedError      error
+	}{
+		{
+			Name:               "ConfirmRequested_ConfirmsTheSchedule",
+			Schedule:           schedule(domain.ScheduleStatusRequested, tomorrow),
+			Command:            domain.ScheduleCommand{ScheduleID: 10, Action: domain.ScheduleActionConfirm, Actor: "fulana"},
+			ExpectedExists:     true,
+			ExpectedStatus:     domain.ScheduleStatusConfirmed,
+			ExpectedBookedAt:   tomorrow,
+			ExpectedAttention:  2,
+			ExpectedTransition: true,
+		},
+		{
+			Name:           "ConfirmCompleted_ReturnsConflictError",
+			Schedule:       schedule(domain.ScheduleStatusCompleted, yesterday),
+			Command:        domain.ScheduleCommand{ScheduleID: 10, Action: domain.ScheduleActionConfirm, Actor: "fulana"},
+			ExpectedExists: true,
+			ExpectedError: domain.NewConflictError(ScheduleTransitionNotAllowed, domain.ScheduleActionConfirm, int64(10),
+				domain.ScheduleStatusCompleted),
+		},
+		{
+			Name:               "CompleteConfirmedOnItsDate_CompletesTheSchedule",
+			Schedule:           schedule(domain.ScheduleStatusConfirmed, yesterday),
+			Command:            domain.ScheduleCommand{ScheduleID: 10, Action: domain.ScheduleActionComplete, Actor: "fulana"},
+			ExpectedExists:     true,
+			ExpectedStatus:     domain.ScheduleStatusCompleted,
+			ExpectedBookedAt:   yesterday,
+			ExpectedAttention:  2,
+			ExpectedTransition: true,
+		},
+		{
+			Name:           "NoShowBeforeTheDate_ReturnsValidationError",
+			Schedule:       schedule(domain.ScheduleStatusConfirmed, tomorrow),
+			Command:        domain.ScheduleCommand{ScheduleID: 10, Action: domain.ScheduleActionNoShow, Actor: "fulana"},
+			ExpectedExists: true,
+			ExpectedError: domain.NewValidationError(ScheduleNotHappenedYet, int64(10),
+				tomorrow.Format(ScheduleBookingLayout)),
+		},
+		{
+			Name:     "RescheduleToAFreeSlot_MovesTheScheduleBackToRequested",
+			Schedule: schedule(domain.ScheduleStatusConfirmed, tomorrow),
+			Command: domain.ScheduleCommand{ScheduleID: 10, Action: domain.ScheduleActionReschedule, Actor: "siclano",
+				Booking: nextWeek.Format(ScheduleBookingLayout), AttentionTimeID: 3},
+			ExpectedExists:     true,
+			ExpectedStatus:     domain.ScheduleStatusRequested,
+			ExpectedBookedAt:   nextWeek,
+			ExpectedAttention:  3,
+			ExpectedTransition: true,
+		},
+		{
+			Name:     "RescheduleToATakenSlot_ReturnsSlotTakenError",
+			Schedule: schedule(domain.ScheduleStatusRequested, tomorrow),
+			Command: domain.ScheduleCommand{ScheduleID: 10, Action: domain.ScheduleActionReschedule, Actor: "siclano",
+				Booking: tomorrow.Format(ScheduleBookingLayout), AttentionTimeID: 3},
+			ExpectedExists: true,
+			ExpectedError:  domain.NewSlotTakenError(ScheduleSlotTaken, int64(3), tomorrow.Format(ScheduleBookingLayout)),
+		},
+		{
+			Name:     "RescheduleToAnotherService_ReturnsValidationError",
+			Schedule: schedule(domain.ScheduleStatusRequested, tomorrow),
+			Command: domain.ScheduleCommand{ScheduleID: 10, Action: domain.ScheduleActionReschedule, Actor: "siclano",
+				Booking: nextWeek.Format(ScheduleBookingLayout), AttentionTimeID: 5},
+			ExpectedExists: true,
+			ExpectedError:  domain.NewValidationError(ScheduleRescheduleOtherService, int64(5), int64(10)),
+		},
+		{
+			Name:            "WithConcurrentTransition_ReturnsTheConflict",
+			Schedule:        schedule(domain.ScheduleStatusRequested, tomorrow),
+			Command:         domain.ScheduleCommand{ScheduleID: 10, Action: domain.ScheduleActionCancel, Actor: "siclano"},
+			TransitionError: domain.NewConflictError("the schedule 10 is no longer requested"),
+			ExpectedExists:  true,
+			ExpectedError:   domain.NewConflictError("the schedule 10 is no longer requested"),
+		},
+		{
+			Name:    "WithUnknownSchedule_ReturnsNotFound",
+			Command: domain.ScheduleCommand{ScheduleID: 99, Action: domain.ScheduleActionCancel, Actor: "siclano"},
+		},
+		{
+			Name:          "WithoutActor_ReturnsValidationError",
+			Schedule:      schedule(domain.ScheduleStatusRequested, tomorrow),
+			Command:       domain.ScheduleCommand{ScheduleID: 10, Action: domain.ScheduleActionCancel, Actor: " "},
+			ExpectedError: domain.NewValidationError(ScheduleActorIsRequired),
+		},
+		{
+			Name:          "WithUnknownAction_ReturnsValidationError",
+			Schedule:      schedule(domain.ScheduleStatusRequested, tomorrow),
+			Command:       domain.ScheduleCommand{ScheduleID: 10, Action: domain.ScheduleActionRequest, Actor: "siclano"},
+			ExpectedError: domain.NewValidationError(ScheduleActionInvalid, domain.ScheduleActionRequest),
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			var transitioned *domain.ScheduleDomain
+			var history domain.ScheduleHistoryDomain
+			scheduleService := ScheduleService{
+				LoggerSugar:                    loggerSugar,
+				OutboxDomainDataBaseRepository: output.OutboxDomainDataBaseRepositoryMock{},
+				ScheduleDomainDataBaseRepository: output.ScheduleDomainDataBaseRepositoryMock{
+					GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ScheduleDomain, bool, error) {
+						if test.Schedule == nil || test.Schedule.ID != ID {
+							return domain.ScheduleDomain{}, false, nil
+						}
+						return *test.Schedule, true, nil
+					},
+					GetBySlotMock: func(contextControl domain.ContextControl, attentionTimeID int64, bookedAt time.Time) (domain.ScheduleDomain, bool, error) {
+						if attentionTimeID == 3 && bookedAt.Equal(tomorrow) {
+							return domain.ScheduleDomain{ID: 11, AttentionTimeID: 3, BookedAt: tomorrow}, true, nil
+						}
+						return domain.ScheduleDomain{}, false, nil
+					},
+					TransitionMock: func(contextControl domain.ContextControl, schedule domain.ScheduleDomain, from domain.ScheduleStatus, transition domain.ScheduleHistoryDomain) error {
+						assert.Equal(t, test.Schedule.Status, from)
+						transitioned, history = &schedule, transition
+						return test.TransitionError
+					},
+				},
+				AttentionTimeDomainDataBaseRepository: attentionTimes,
+				ServiceDomainDataBaseRepository:       output.ServiceDomainDataBaseRepositoryMock{},
+				EmployeeDomainDataBaseRepository:      employees,
+			}
+
+			schedule, exists, err := scheduleService.ApplyCommand(domain.ContextControl{Context: context.Background()}, test.Command)
+			assert.Equal(t, test.ExpectedError, err)
+			assert.Equal(t, test.ExpectedExists, exists)
+
+			if !test.ExpectedTransition {
+				return
+			}
+
+			assert.Equal(t, test.ExpectedStatus, schedule.Status)
+			assert.Equal(t, test.ExpectedBookedAt, schedule.BookedAt)
+			assert.Equal(t, test.ExpectedAttention, schedule.AttentionTimeID)
+			assert.Equal(t, &schedule, transitioned)
+			assert.Equal(t, test.Command.Action, history.Action)
+			assert.Equal(t, test.Schedule.Status, history.FromStatus)
+			assert.Equal(t, test.ExpectedStatus, history.ToStatus)
+			assert.Equal(t, test.Command.Actor, history.Actor)
+
+			if test.Command.Action == domain.ScheduleActionReschedule {
+				assert.Equal(t, &test.Schedule.BookedAt, history.PreviousBookedAt)
+				assert.Equal(t, test.Schedule.AttentionTimeID, history.PreviousAttentionTimeID)
+			} else {
+				assert.Nil(t, history.PreviousBookedAt)
+			}
+		})
+	}
+}
+
+func TestScheduleService_ApplyCommand_Decline(t *testing.T) {
+
+	scheduleService := ScheduleService{
+		LoggerSugar:                    loggerSugar,
+		OutboxDomainDataBaseRepository: output.OutboxDomainDataBaseRepositoryMock{},
+		ScheduleDomainDataBaseRepository: output.ScheduleDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ScheduleDomain, bool, error) {
+				return domain.ScheduleDomain{ID: ID, Status: domain.ScheduleStatusRequested, BookedAt: today()}, true, nil
+			},
+		},
+	}
+
+	schedule, exists, err := scheduleService.ApplyCommand(domain.ContextControl{Context: context.Background()},
+		domain.ScheduleCommand{ScheduleID: 10, Action: domain.ScheduleActionDecline, Actor: "fulana", Reason: "no vet today"})
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, domain.ScheduleStatusDeclined, schedule.Status)
+	assert.NotNil(t, schedule.DateDeclined)
+}
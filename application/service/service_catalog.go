@@ -0,0 +1,149 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"go.uber.org/zap"
+)
+
+// ServiceCatalogService manages the services (grooming, bath, vaccines...) a contract offers.
+type ServiceCatalogService struct {
+	LoggerSugar                      *zap.SugaredLogger
+	ServiceDomainDataBaseRepository  output.IServiceDomainDataBaseRepository
+	ContractDomainDataBaseRepository output.IContractDomainDataBaseRepository
+}
+
+// DefaultServiceDurationMinutes is the duration given to a service created or updated without one.
+const DefaultServiceDurationMinutes = 60
+
+const (
+	ServiceNameIsRequired        = "service name is required"
+	ServiceDescriptionIsRequired = "service description is required"
+	ServicePriceIsNegative       = "service price %s can't be negative"
+	ServiceDurationIsInvalid     = "service duration %d must be positive"
+	ServiceContractNotFound      = "the contract with id %d wasn't found"
+)
+
+// Create validates the service and stores it, active, with its first price history record.
+func (service *ServiceCatalogService) Create(contextControl domain.ContextControl, petshopService domain.ServiceDomain) (domain.ServiceDomain, error) {
+
+	petshopService = normalizeService(petshopService)
+	if err := service.ValidateService(petshopService); err != nil {
+		return domain.ServiceDomain{}, err
+	}
+
+	petshopService.ContractID = contextControl.ScopedContractID(petshopService.ContractID)
+	_, exists, err := service.ContractDomainDataBaseRepository.GetByID(contextControl, petshopService.ContractID)
+	if err != nil {
+		return domain.ServiceDomain{}, err
+	}
+	if !exists {
+		return domain.ServiceDomain{}, domain.NewValidationError(ServiceContractNotFound, petshopService.ContractID)
+	}
+
+	petshopService.Active = true
+	return service.ServiceDomainDataBaseRepository.Save(contextControl, petshopService)
+}
+
+// Update changes the name, description, duration and price of a known service. A new price is
+// recorded in the price history, the schedules already created keep the previous one.
+func (service *ServiceCatalogService) Update(contextControl domain.ContextControl, petshopService domain.ServiceDomain) (domain.ServiceDomain, bool, error) {
+
+	current, exists, err := service.ServiceDomainDataBaseRepository.GetByID(contextControl, petshopService.ID)
+	if err != nil || !exists {
+		return domain.ServiceDomain{}, false, err
+	}
+
+	petshopService = normalizeService(petshopService)
+	if err = service.ValidateService(petshopService); err != nil {
+		return domain.ServiceDomain{}, true, err
+	}
+
+	if err = service.ServiceDomainDataBaseRepository.Update(contextControl, petshopService); err != nil {
+		return domain.ServiceDomain{}, true, err
+	}
+
+	current.Name = petshopService.Name
+	current.Description = petshopService.Description
+	current.Price = petshopService.Price
+	current.DurationMinutes = petshopService.DurationMinutes
+
+	return current, true, nil
+}
+
+// SetActive activates or deactivates a known service. Inactive services can't be scheduled.
+func (service *ServiceCatalogService) SetActive(contextControl domain.ContextControl, ID int64, active bool) (domain.ServiceDomain, bool, error) {
+
+	petshopService, exists, err := service.ServiceDomainDataBaseRepository.GetByID(contextControl, ID)
+	if err != nil || !exists {
+		return domain.ServiceDomain{}, false, err
+	}
+
+	if petshopService.Active == active {
+		return petshopService, true, nil
+	}
+
+	if err = service.ServiceDomainDataBaseRepository.SetActive(contextControl, ID, active); err != nil {
+		return domain.ServiceDomain{}, true, err
+	}
+
+	petshopService.Active = active
+	return petshopService, true, nil
+}
+
+func (service *ServiceCatalogService) GetByID(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error) {
+	return service.ServiceDomainDataBaseRepository.GetByID(contextControl, ID)
+}
+
+func (service *ServiceCatalogService) GetAll(contextControl domain.ContextControl) ([]domain.ServiceDomain, error) {
+	return service.ServiceDomainDataBaseRepository.GetAll(contextControl)
+}
+
+// GetPriceHistory lists the prices of a known service, the latest first.
+func (service *ServiceCatalogService) GetPriceHistory(contextControl domain.ContextControl, serviceID int64) ([]domain.ServicePriceHistoryDomain, bool, error) {
+
+	_, exists, err := service.ServiceDomainDataBaseRepository.GetByID(contextControl, serviceID)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+
+	priceHistory, err := service.ServiceDomainDataBaseRepository.GetPriceHistory(contextControl, serviceID)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return priceHistory, true, nil
+}
+
+// ValidateService checks the name, the description, the price and the duration of the service.
+func (service *ServiceCatalogService) ValidateService(petshopService domain.ServiceDomain) error {
+
+	if len(petshopService.Name) == 0 {
+		return domain.NewValidationError(ServiceNameIsRequired)
+	}
+
+	if len(petshopService.Description) == 0 {
+		return domain.NewValidationError(ServiceDescriptionIsRequired)
+	}
+
+	if petshopService.Price < 0 {
+		return domain.NewValidationError(ServicePriceIsNegative, petshopService.Price)
+	}
+
+	if petshopService.DurationMinutes <= 0 {
+		return domain.NewValidationError(ServiceDurationIsInvalid, petshopService.DurationMinutes)
+	}
+
+	return nil
+}
+
+func normalizeService(petshopService domain.ServiceDomain) domain.ServiceDomain {
+	petshopService.Name = strings.ToUpper(strings.TrimSpace(petshopService.Name))
+	petshopService.Description = strings.TrimSpace(petshopService.Description)
+	if petshopService.DurationMinutes == 0 {
+		petshopService.DurationMinutes = DefaultServiceDurationMinutes
+	}
+	return petshopService
+}
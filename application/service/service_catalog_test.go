@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceCatalogService_Create(t *testing.T) {
+
+	validService := domain.ServiceDomain{
+		Name:        " banho ",
+		Price:       5599,
+		Description: "Banho com sais minerais e água morna.",
+	}
+
+	savedService := domain.ServiceDomain{
+		ID:              2,
+		Name:            "BANHO",
+		Price:           5599,
+		Active:          true,
+		Description:     "Banho com sais minerais e água morna.",
+		DurationMinutes: DefaultServiceDurationMinutes,
+		ContractID:      1,
+	}
+
+	existingContract := output.ContractDomainDataBaseRepositoryMock{
+		GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ContractDomain, bool, error) {
+			return domain.ContractDomain{ID: ID}, true, nil
+		},
+	}
+
+	tests := []struct {
+		Name                             string
+		Service                          domain.ServiceDomain
+		ContractDomainDataBaseRepository output.IContractDomainDataBaseRepository
+		ExpectedResult                   domain.ServiceDomain
+		ExpectedError                    error
+	}{
+		{
+			Name:                             "WithValidService_SavesItActiveInTheContractOfTheRequest",
+			Service:                          validService,
+			ContractDomainDataBaseRepository: existingContract,
+			ExpectedResult:                   savedService,
+		},
+		{
+			Name: "WithNegativePrice_ReturnsValidationError",
+			Service: func() domain.ServiceDomain {
+				petshopService := validService
+				petshopService.Price = -1
+				return petshopService
+			}(),
+			ContractDomainDataBaseRepository: existingContract,
+			ExpectedError:                    domain.ErrValidation,
+		},
+		{
+			Name: "WithoutName_ReturnsValidationError",
+			Service: func() domain.ServiceDomain {
+				petshopService := validService
+				petshopService.Name = ""
+				return petshopService
+			}(),
+			ContractDomainDataBaseRepository: existingContract,
+			ExpectedError:                    domain.ErrValidation,
+		},
+		{
+			Name:                             "WithUnknownContract_ReturnsValidationError",
+			Service:                          validService,
+			ContractDomainDataBaseRepository: output.ContractDomainDataBaseRepositoryMock{},
+			ExpectedError:                    domain.ErrValidation,
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.Name, func(t *testing.T) {
+
+			serviceCatalogService := ServiceCatalogService{
+				LoggerSugar: loggerSugar,
+				ServiceDomainDataBaseRepository: output.ServiceDomainDataBaseRepositoryMock{
+					SaveMock: func(contextControl domain.ContextControl, petshopService domain.ServiceDomain) (domain.ServiceDomain, error) {
+						petshopService.ID = 2
+						return petshopService, nil
+					},
+				},
+				ContractDomainDataBaseRepository: test.ContractDomainDataBaseRepository,
+			}
+
+			contextControl := domain.ContextControl{Context: context.Background(), ContractID: 1}
+			petshopService, err := serviceCatalogService.Create(contextControl, test.Service)
+			assert.Equal(t, test.ExpectedResult, petshopService)
+			if test.ExpectedError == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.ExpectedError)
+			}
+		})
+	}
+}
+
+func TestServiceCatalogService_Update(t *testing.T) {
+
+	var updated *domain.ServiceDomain
+	serviceCatalogService := ServiceCatalogService{
+		LoggerSugar: loggerSugar,
+		ServiceDomainDataBaseRepository: output.ServiceDomainDataBaseRepositoryMock{
+			GetByIDMock: func(contextControl domain.ContextControl, ID int64) (domain.ServiceDomain, bool, error) {
+				if ID != 2 {
+					return domain.ServiceDomain{}, false, nil
+				}
+				return domain.ServiceDomain{ID: ID, Name: "BANHO", Price: 5599, Active: true, Description: "Banho.", DurationMinutes: 60, ContractID: 1}, true, nil
+			},
+			UpdateMock: func(contextControl domain.ContextControl, petshopService domain.ServiceDomain) error {
+				updated = &petshopService
+				return nil
+			},
+		},
+	}
+
+	t.Run("WithNewPrice_KeepsTheActivationAndTheContract", func(t *testing.T) {
+
+		petshopService, exists, err := serviceCatalogService.Update(domain.ContextControl{Context: context.Background()},
+			domain.ServiceDomain{ID: 2, Name: "Banho", Price: 6250, Description: "Banho com hidratação.", DurationMinutes: 90})
+		assert.Nil(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, domain.ServiceDomain{ID: 2, Name: "BANHO", Price: 6250, Active: true,
+			Description: "Banho com hidratação.", DurationMinutes: 90, ContractID: 1}, petshopService)
+		assert.Equal(t, domain.Money(6250), updated.Price)
+	})
+
+	t.Run("WithUnknownService_ReturnsNotFound", func(t *testing.T) {
+
+		updated = nil
+		_, exists, err := serviceCatalogService.Update(domain.ContextControl{Context: context.Background()},
+			domain.ServiceDomain{ID: 9, Name: "Banho", Price: 6250, Description: "Banho."})
+		assert.Nil(t, err)
+		assert.False(t, exists)
+		assert.Nil(t, updated)
+	})
+}
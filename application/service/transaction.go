@@ -0,0 +1,18 @@
+package service
+
+import (
+	"github.com/petshop-system/petshop-api/application/domain"
+	"github.com/petshop-system/petshop-api/application/port/output"
+)
+
+// withinTransaction runs fn in a transaction of the transactionManager, or alone when the service
+// was built without one.
+func withinTransaction(transactionManager output.ITransactionManager, contextControl domain.ContextControl,
+	fn func(txControl domain.ContextControl) error) error {
+
+	if transactionManager == nil {
+		return fn(contextControl)
+	}
+
+	return transactionManager.WithinTransaction(contextControl, fn)
+}
@@ -1,19 +1,24 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/go-chi/chi/v5"
-  "github.com/go-chi/chi/v5/middleware"
-  "github.com/kelseyhightower/envconfig"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/kelseyhightower/envconfig"
 	adpterHttpInput "github.com/petshop-system/petshop-api/adapter/input/http"
 	"github.com/petshop-system/petshop-api/adapter/input/http/handler"
 	"github.com/petshop-system/petshop-api/adapter/input/message/stream"
 	"github.com/petshop-system/petshop-api/adapter/output/cache"
 	"github.com/petshop-system/petshop-api/adapter/output/database"
+	"github.com/petshop-system/petshop-api/adapter/output/event"
+	"github.com/petshop-system/petshop-api/application/domain"
 	"github.com/petshop-system/petshop-api/application/service"
 	"github.com/petshop-system/petshop-api/configuration/environment"
 	"github.com/petshop-system/petshop-api/configuration/repository"
@@ -44,30 +49,65 @@ func init() {
 
 func main() {
 
+	// SIGTERM is sent by the orchestrator on rolling deploys, SIGINT when running locally
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
 	redisCache := cache.NewRedis(loggerSugar)
 
 	postgresConnectionDB := repository.NewPostgresDB(environment.Setting.Postgres.DBUser, environment.Setting.Postgres.DBPassword,
 		environment.Setting.Postgres.DBName, environment.Setting.Postgres.DBHost, environment.Setting.Postgres.DBPort, loggerSugar)
 
+	contractPostgresDB := database.NewContractPostgresDB(postgresConnectionDB, loggerSugar)
 	customerPostgresDB := database.NewCustomerPostgresDB(postgresConnectionDB, loggerSugar)
 	addressPostgresDB := database.NewAddressPostgresDB(postgresConnectionDB, loggerSugar)
 	phonePostgresDB := database.NewPhonePostgresDB(postgresConnectionDB, loggerSugar)
+	petPostgresDB := database.NewPetPostgresDB(postgresConnectionDB, loggerSugar)
+	breedPostgresDB := database.NewBreedPostgresDB(postgresConnectionDB, loggerSugar)
+	speciesPostgresDB := database.NewSpeciesPostgresDB(postgresConnectionDB, loggerSugar)
+	schedulePostgresDB := database.NewSchedulePostgresDB(postgresConnectionDB, loggerSugar)
+	attentionTimePostgresDB := database.NewAttentionTimePostgresDB(postgresConnectionDB, loggerSugar)
+	servicePostgresDB := database.NewServicePostgresDB(postgresConnectionDB, loggerSugar)
+	employeePostgresDB := database.NewEmployeePostgresDB(postgresConnectionDB, loggerSugar)
+	outboxPostgresDB := database.NewOutboxPostgresDB(postgresConnectionDB, loggerSugar)
+	transactionManager := database.NewTransactionManagerPostgresDB(postgresConnectionDB, loggerSugar)
+
+	eventPublisher := event.NewKafkaEventPublisher(loggerSugar, environment.Setting.Kafka.Event.BootstrapServer,
+		environment.Setting.Kafka.Event.Topic)
 
 	genericHandler := &handler.Generic{
-		LoggerSugar: loggerSugar,
+		LoggerSugar:       loggerSugar,
+		CacheStatsService: service.CacheStatsService{},
 	}
 
-	customerService := &service.CustomerService{
+	cacheKeyService := service.CacheKeyService{
+		LoggerSugar:        loggerSugar,
+		CacheKeyRepository: &redisCache,
+	}
+
+	// entries written with a previous schema version are dropped in background, the services never read them
+	go cacheKeyService.InvalidateStaleVersions(domain.ContextControl{Context: ctx}, service.CacheKeys()...)
+
+	contractService := &service.ContractService{
 		LoggerSugar:                      loggerSugar,
-		CustomerDomainDataBaseRepository: &customerPostgresDB,
-		CustomerDomainCacheRepository:    &redisCache,
+		ContractDomainDataBaseRepository: &contractPostgresDB,
+		ContractDomainCacheRepository:    &redisCache,
 	}
 
-	customerHandler := &handler.Customer{
-		CustomerService: customerService,
+	contractHandler := &handler.Contract{
+		ContractService: contractService,
 		LoggerSugar:     loggerSugar,
 	}
 
+	customerService := &service.CustomerService{
+		LoggerSugar:                      loggerSugar,
+		CustomerDomainDataBaseRepository: &customerPostgresDB,
+		CustomerDomainCacheRepository:    &redisCache,
+		ContractDomainDataBaseRepository: &contractPostgresDB,
+		TransactionManager:               &transactionManager,
+		OutboxDomainDataBaseRepository:   &outboxPostgresDB,
+	}
+
 	addressService := service.AddressService{
 		LoggerSugar:                     loggerSugar,
 		AddressDomainDataBaseRepository: &addressPostgresDB,
@@ -80,9 +120,27 @@ func main() {
 	}
 
 	phoneService := &service.PhoneService{
-		LoggerSugar:                   loggerSugar,
-		PhoneDomainDataBaseRepository: &phonePostgresDB,
-		PhoneDomainCacheRepository:    &redisCache,
+		LoggerSugar:                      loggerSugar,
+		PhoneDomainDataBaseRepository:    &phonePostgresDB,
+		PhoneDomainCacheRepository:       &redisCache,
+		ContractDomainDataBaseRepository: &contractPostgresDB,
+		CustomerDomainDataBaseRepository: &customerPostgresDB,
+		EmployeeDomainDataBaseRepository: &employeePostgresDB,
+		TransactionManager:               &transactionManager,
+	}
+
+	customerOnboardingService := &service.CustomerOnboardingService{
+		LoggerSugar:        loggerSugar,
+		CustomerService:    customerService,
+		AddressService:     addressService,
+		PhoneService:       phoneService,
+		TransactionManager: &transactionManager,
+	}
+
+	customerHandler := &handler.Customer{
+		CustomerService:           customerService,
+		CustomerOnboardingService: customerOnboardingService,
+		LoggerSugar:               loggerSugar,
 	}
 
 	phoneHandler := &handler.Phone{
@@ -90,26 +148,146 @@ func main() {
 		LoggerSugar:  loggerSugar,
 	}
 
-	scheduleService := &service.ScheduleService{
+	petService := &service.PetService{
+		LoggerSugar:                      loggerSugar,
+		PetDomainDataBaseRepository:      &petPostgresDB,
+		PetDomainCacheRepository:         &redisCache,
+		CustomerDomainDataBaseRepository: &customerPostgresDB,
+		BreedDomainDataBaseRepository:    &breedPostgresDB,
+		TransactionManager:               &transactionManager,
+		OutboxDomainDataBaseRepository:   &outboxPostgresDB,
+	}
+
+	petHandler := &handler.Pet{
+		PetService:  petService,
 		LoggerSugar: loggerSugar,
 	}
 
+	catalogService := &service.CatalogService{
+		LoggerSugar:                     loggerSugar,
+		SpeciesDomainDataBaseRepository: &speciesPostgresDB,
+		BreedDomainDataBaseRepository:   &breedPostgresDB,
+		CatalogDomainCacheRepository:    &redisCache,
+	}
+
+	catalogHandler := &handler.Catalog{
+		CatalogService: catalogService,
+		LoggerSugar:    loggerSugar,
+	}
+
+	serviceCatalogService := &service.ServiceCatalogService{
+		LoggerSugar:                      loggerSugar,
+		ServiceDomainDataBaseRepository:  &servicePostgresDB,
+		ContractDomainDataBaseRepository: &contractPostgresDB,
+	}
+
+	serviceCatalogHandler := &handler.ServiceCatalog{
+		ServiceCatalogService: serviceCatalogService,
+		LoggerSugar:           loggerSugar,
+	}
+
+	employeeService := &service.EmployeeService{
+		LoggerSugar:                      loggerSugar,
+		EmployeeDomainDataBaseRepository: &employeePostgresDB,
+		ContractDomainDataBaseRepository: &contractPostgresDB,
+	}
+
+	employeeHandler := &handler.Employee{
+		EmployeeService: employeeService,
+		LoggerSugar:     loggerSugar,
+	}
+
+	attentionTimeService := &service.AttentionTimeService{
+		LoggerSugar:                           loggerSugar,
+		AttentionTimeDomainDataBaseRepository: &attentionTimePostgresDB,
+		EmployeeDomainDataBaseRepository:      &employeePostgresDB,
+		ServiceDomainDataBaseRepository:       &servicePostgresDB,
+	}
+
+	attentionTimeHandler := &handler.AttentionTime{
+		AttentionTimeService: attentionTimeService,
+		LoggerSugar:          loggerSugar,
+	}
+
+	availabilityService := &service.AvailabilityService{
+		LoggerSugar:                           loggerSugar,
+		ServiceDomainDataBaseRepository:       &servicePostgresDB,
+		EmployeeDomainDataBaseRepository:      &employeePostgresDB,
+		AttentionTimeDomainDataBaseRepository: &attentionTimePostgresDB,
+		ScheduleDomainDataBaseRepository:      &schedulePostgresDB,
+	}
+
+	scheduleService := &service.ScheduleService{
+		LoggerSugar:                           loggerSugar,
+		ScheduleDomainDataBaseRepository:      &schedulePostgresDB,
+		PetDomainDataBaseRepository:           &petPostgresDB,
+		AttentionTimeDomainDataBaseRepository: &attentionTimePostgresDB,
+		ServiceDomainDataBaseRepository:       &servicePostgresDB,
+		EmployeeDomainDataBaseRepository:      &employeePostgresDB,
+		TransactionManager:                    &transactionManager,
+		OutboxDomainDataBaseRepository:        &outboxPostgresDB,
+	}
+
+	scheduleHandler := &handler.Schedule{
+		ScheduleService:     scheduleService,
+		AvailabilityService: availabilityService,
+		LoggerSugar:         loggerSugar,
+	}
+
 	scheduleKafkaClient := stream.NewScheduleKafkaClient(loggerSugar, scheduleService, environment.Setting.Kafka.Schedule.BootstrapServer,
 		environment.Setting.Kafka.Schedule.GroupID, environment.Setting.Kafka.Schedule.AutoOffsetReset,
-		environment.Setting.Kafka.Schedule.Topic)
+		environment.Setting.Kafka.Schedule.Topic, environment.Setting.Kafka.Schedule.DeadLetterTopic,
+		environment.Setting.Kafka.Schedule.DeclinedTopic, environment.Setting.Kafka.Schedule.CommandTopic,
+		stream.RetryPolicy{
+			MaxAttempts:    environment.Setting.Kafka.Schedule.MaxAttempts,
+			InitialBackoff: environment.Setting.Kafka.Schedule.RetryInitialBackoff,
+			MaxBackoff:     environment.Setting.Kafka.Schedule.RetryMaxBackoff,
+		})
 
-	scheduleKafkaClient.ConsumerMessages()
+	scheduleKafkaClient.ConsumerMessages(ctx)
+
+	outboxRelayService := service.OutboxRelayService{
+		LoggerSugar:                    loggerSugar,
+		OutboxDomainDataBaseRepository: &outboxPostgresDB,
+		TransactionManager:             &transactionManager,
+		EventPublisher:                 &eventPublisher,
+		BatchSize:                      environment.Setting.Outbox.BatchSize,
+		PollInterval:                   environment.Setting.Outbox.PollInterval,
+		ClaimTimeout:                   environment.Setting.Outbox.ClaimTimeout,
+		InitialBackoff:                 environment.Setting.Outbox.RetryInitialBackoff,
+		MaxBackoff:                     environment.Setting.Outbox.RetryMaxBackoff,
+		MaxAttempts:                    environment.Setting.Outbox.MaxAttempts,
+		Retention:                      environment.Setting.Outbox.Retention,
+		CleanupInterval:                environment.Setting.Outbox.CleanupInterval,
+	}
+
+	outboxRelayDone := make(chan struct{})
+	go func() {
+		defer close(outboxRelayDone)
+		outboxRelayService.Run(ctx)
+	}()
 
 	contextPath := environment.Setting.Server.Context
 	newRouter := adpterHttpInput.GetNewRouter(loggerSugar)
-	newRouter.GetChiRouter().With(middleware.RequestID).
+	newRouter.GetChiRouter().With(middleware.RequestID, handler.ContextRequest(environment.Setting.Application.ContextRequest)).
 		Route(fmt.Sprintf("/%s", contextPath), func(r chi.Router) {
 
 			r.NotFound(genericHandler.NotFound)
 			r.Group(newRouter.AddGroupHandlerHealthCheck(genericHandler))
-			r.Group(newRouter.AddGroupHandlerCustomer(customerHandler))
-			r.Group(newRouter.AddGroupHandlerAddress(addressHandler))
-			r.Group(newRouter.AddGroupHandlerPhone(phoneHandler))
+			r.Group(newRouter.AddGroupHandlerContract(contractHandler))
+			r.Group(func(r chi.Router) {
+				// customers, addresses, phones, pets, services, employees, attention times and schedules belong to a contract
+				r.Use(handler.ContractScope)
+				r.Group(newRouter.AddGroupHandlerCustomer(customerHandler))
+				r.Group(newRouter.AddGroupHandlerAddress(addressHandler))
+				r.Group(newRouter.AddGroupHandlerPhone(phoneHandler))
+				r.Group(newRouter.AddGroupHandlerPet(petHandler))
+				r.Group(newRouter.AddGroupHandlerServiceCatalog(serviceCatalogHandler))
+				r.Group(newRouter.AddGroupHandlerEmployee(employeeHandler))
+				r.Group(newRouter.AddGroupHandlerAttentionTime(attentionTimeHandler))
+				r.Group(newRouter.AddGroupHandlerSchedule(scheduleHandler))
+			})
+			r.Group(newRouter.AddGroupHandlerCatalog(catalogHandler))
 
 		})
 
@@ -124,10 +302,42 @@ func main() {
 	loggerSugar.Infow("server started", "port", serverHttp.Addr,
 		"contextPath", contextPath)
 
-	if err := serverHttp.ListenAndServe(); err != nil {
-		loggerSugar.Errorw("error to listen and starts server", "port", serverHttp.Addr,
-			"contextPath", contextPath, "err", err.Error())
-		panic(err.Error())
+	go func() {
+		if err := serverHttp.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			loggerSugar.Errorw("error to listen and starts server", "port", serverHttp.Addr,
+				"contextPath", contextPath, "err", err.Error())
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	loggerSugar.Infow("shutting down server", "timeout", environment.Setting.Server.ShutdownTimeout.String())
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), environment.Setting.Server.ShutdownTimeout)
+	defer cancel()
+
+	// stops accepting connections and waits for the in-flight requests to finish
+	if err := serverHttp.Shutdown(shutdownCtx); err != nil {
+		loggerSugar.Errorw("error to shutdown server", "port", serverHttp.Addr, "err", err.Error())
+	}
+
+	scheduleKafkaClient.Close(shutdownCtx)
+
+	// the relay stops with ctx; what it didn't publish stays in the outbox for the next start
+	select {
+	case <-outboxRelayDone:
+	case <-shutdownCtx.Done():
+	}
+	eventPublisher.Close()
+
+	if err := repository.ClosePostgresDB(postgresConnectionDB); err != nil {
+		loggerSugar.Errorw("error to close postgres db", "err", err.Error())
+	}
+
+	if err := redisCache.Close(); err != nil {
+		loggerSugar.Errorw("error to close redis", "err", err.Error())
 	}
 
+	loggerSugar.Infow("server stopped", "port", serverHttp.Addr, "contextPath", contextPath)
+	_ = loggerSugar.Sync()
 }
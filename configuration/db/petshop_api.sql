@@ -1,3 +1,6 @@
+-- trigram indexes back the search of customers by a part of the name
+create extension if not exists pg_trgm;
+
 --- New Schema
 create schema petshop_api
 
@@ -14,13 +17,29 @@ create schema petshop_api
         zip_code      VARCHAR(20) NOT NULL,
         city         VARCHAR(255) NOT NULL,
         state        VARCHAR(2) NOT NULL,
-        country      VARCHAR(100) NOT NULL
+        country      VARCHAR(100) NOT NULL,
+        -- contract (tenant) that owns the address; null for the address of a contract itself.
+        -- Not a foreign key because contract already references address.
+        fk_id_contract int
     )
 
+    create
+        index petshop_api_address_contract_index
+        on address (fk_id_contract)
+
     create
         unique index petshop_api_address_id_uindex
         on address (id)
 
+    -- filters of GET /address, the zip code compared by its digits
+    create
+        index petshop_api_address_zip_code_index
+        on address (regexp_replace(zip_code, '\D', '', 'g'), id)
+
+    create
+        index petshop_api_address_city_index
+        on address (lower(city), id)
+
     create table contract
     (
         id            serial       not null
@@ -53,6 +72,7 @@ create schema petshop_api
         person_type    varchar(255) not null,
         fk_id_address  int          not null unique,
         fk_id_contract int          not null,
+        date_deleted   timestamp, -- soft delete, hides the customer from reads until restored
         FOREIGN KEY (fk_id_contract) references contract (id),
         FOREIGN KEY (fk_id_address) references address (id),
         constraint petshop_api_customer_pkey PRIMARY KEY (id, email, document, fk_id_contract),
@@ -72,6 +92,24 @@ create schema petshop_api
         index petshop_api_customer_document_uindex
         on customer (document)
 
+    -- filters of GET /customer, which lists the customers not deleted of a contract in id order
+    create
+        index petshop_api_customer_list_index
+        on customer (fk_id_contract, id)
+        where date_deleted is null
+
+    create
+        index petshop_api_customer_name_trgm_index
+        on customer using gin (lower(name) gin_trgm_ops)
+        where date_deleted is null
+
+    create
+        index petshop_api_customer_email_lower_index
+        on customer (lower(email), id)
+        where date_deleted is null
+
+    -- audit trail of the customers: every create, update, delete and restore with who made it,
+    -- in which request and the fields changed, e.g. {"email": {"before": "a@x.com", "after": "b@x.com"}}
     create table customer_history
     (
         id serial not null
@@ -79,12 +117,19 @@ create schema petshop_api
         fk_id_customer int          not null,
         date   timestamp default timezone('BRT'::text, now()),
         description varchar(255) not null,
-        FOREIGN KEY (fk_id_customer) references customer (id)
+        action      varchar(20)  not null,
+        actor       varchar(255) not null,
+        request_id  varchar(255) not null default '',
+        changes     jsonb        not null default '{}',
+        FOREIGN KEY (fk_id_customer) references customer (id),
+        CONSTRAINT chk_customer_history_action_value
+            CHECK (action IN ('created', 'updated', 'deleted', 'restored'))
     )
 
+    -- pages of GET /customer/{id}/history
     create
         index petshop_api_customer_history_fk_id_customer_uindex
-        on customer_history (fk_id_customer)
+        on customer_history (fk_id_customer, id)
 
     create table phone
     (
@@ -92,13 +137,24 @@ create schema petshop_api
             constraint petshop_api_phone_pkey primary key,
         number       varchar(255) not null,
         code_area    varchar(255) not null,
-        phone_type   varchar(255) not null
+        phone_type   varchar(255) not null,
+        fk_id_contract int,
+        FOREIGN KEY (fk_id_contract) references contract (id)
     )
 
+    create
+        index petshop_api_phone_contract_index
+        on phone (fk_id_contract)
+
     create
         unique index petshop_api_phone_id_uindex
         on phone (id)
 
+    -- filter of GET /phone
+    create
+        index petshop_api_phone_number_index
+        on phone (number, id)
+
     create table phone_user
     (
         id          serial       not null
@@ -106,6 +162,7 @@ create schema petshop_api
         fk_id_phone int          not null unique,
         fk_id_user  int          not null,
         user_type   varchar(255) not null,
+        is_primary  boolean      not null default false,
         FOREIGN KEY (fk_id_phone) references phone (id),
         CONSTRAINT chk_phone_user_type_value
             CHECK (user_type IN ('contract', 'customer', 'employee'))
@@ -115,6 +172,12 @@ create schema petshop_api
         index petshop_api_phone_user_uindex
         on phone_user (fk_id_user)
 
+    -- a single primary contact number per owner
+    create
+        unique index petshop_api_phone_user_primary_uindex
+        on phone_user (user_type, fk_id_user)
+        where is_primary
+
     create table species
     (
         id   serial       not null
@@ -126,6 +189,10 @@ create schema petshop_api
         unique index petshop_api_species_id_uindex
         on species (id)
 
+    create
+        unique index petshop_api_species_name_uindex
+        on species (lower(name))
+
     create table breed
     (
         id            serial       not null
@@ -139,6 +206,10 @@ create schema petshop_api
         unique index petshop_api_breed_id_uindex
         on breed (id)
 
+    create
+        unique index petshop_api_breed_species_name_uindex
+        on breed (fk_id_species, lower(name))
+
     create table pet
     (
         id             serial       not null
@@ -165,13 +236,14 @@ create schema petshop_api
 
     create table service
     (
-        id             serial       not null
+        id               serial       not null
             constraint petshop_api_service_pkey primary key,
-        name           varchar(255) not null,
-        price          decimal      not null default 0,
-        active         bool         not null default true,
-        description    varchar(255) not null,
-        fk_id_contract int          not null,
+        name             varchar(255) not null,
+        price            decimal      not null default 0,
+        active           bool         not null default true,
+        description      varchar(255) not null,
+        duration_minutes int          not null default 60,
+        fk_id_contract   int          not null,
         FOREIGN KEY (fk_id_contract) references contract (id)
     )
 
@@ -179,6 +251,23 @@ create schema petshop_api
         unique index petshop_api_service_id_uindex
         on service (id)
 
+    -- every price a service had; a schedule references the one in effect when it was booked
+    create table service_price_history
+    (
+        id             serial    not null
+            constraint petshop_api_service_price_history_pkey primary key,
+        fk_id_service  int       not null,
+        price          decimal   not null,
+        date_created   timestamp not null default timezone('BRT'::text, now()),
+        fk_id_contract int       not null,
+        FOREIGN KEY (fk_id_service) references service (id),
+        FOREIGN KEY (fk_id_contract) references contract (id)
+    )
+
+    create
+        index petshop_api_service_price_history_service_index
+        on service_price_history (fk_id_service, date_created)
+
     create table employee
     (
         id             serial       not null unique,
@@ -209,7 +298,7 @@ create schema petshop_api
         id             serial       not null
             constraint petshop_api_service_employee_attention_time_pkey primary key,
         initial_time   varchar(255) not null,
---         final_time     varchar(255) not null,
+        final_time     varchar(255) not null,
         active         bool         not null default false,
         fk_id_service  int          not null,
         fk_id_contract int          not null,
@@ -223,6 +312,10 @@ create schema petshop_api
         unique index petshop_api_service_employee_attention_time_id_uindex
         on service_employee_attention_time (id)
 
+    create
+        index petshop_api_service_employee_attention_time_employee_index
+        on service_employee_attention_time (fk_id_employee, initial_time)
+
     create table schedule
     (
         id                                    serial       not null
@@ -230,19 +323,96 @@ create schema petshop_api
         date_created                          timestamp             default timezone('BRT'::text, now()),
         date_declined                         timestamp,
         number                                varchar(255) not null, -- 2023dez10.000001
+        status                                varchar(20)  not null default 'requested', -- requested, confirmed, completed, declined, cancelled, no_show
         booked_at                             date         not null,
         price                                 decimal      not null default 0,
+        fk_id_service_price_history           int,
         fk_id_pet                             int          not null,
         fk_id_service_employee_attention_time int          not null,
 --         fk_id_contract                        int          not null,
 --         FOREIGN KEY (fk_id_contract) references contract (id),
+        FOREIGN KEY (fk_id_service_price_history) references service_price_history (id),
         FOREIGN KEY (fk_id_pet) references pet (id),
         FOREIGN KEY (fk_id_service_employee_attention_time) references service_employee_attention_time (id)
     )
 
     create
         unique index petshop_api_schedule_id_uindex
-        on schedule (id);
+        on schedule (id)
+
+    -- one active schedule per attention time and date, so concurrent consumers can't double-book
+    -- a slot and redelivered messages can't duplicate a booking; declined and cancelled schedules
+    -- release the slot
+    create
+        unique index petshop_api_schedule_slot_uindex
+        on schedule (fk_id_service_employee_attention_time, booked_at)
+        where status not in ('declined', 'cancelled')
+
+    -- every transition of a schedule, who applied it and when; a reschedule keeps the previous slot
+    create table schedule_history
+    (
+        id                            serial       not null
+            constraint petshop_api_schedule_history_pkey primary key,
+        fk_id_schedule                int          not null,
+        action                        varchar(20)  not null, -- request, confirm, reschedule, decline, cancel, complete, no_show
+        from_status                   varchar(20),
+        to_status                     varchar(20)  not null,
+        actor                         varchar(255) not null,
+        reason                        varchar(255) not null default '',
+        previous_booked_at            date,
+        fk_id_previous_attention_time int,
+        date_created                  timestamp    not null default timezone('BRT'::text, now()),
+        FOREIGN KEY (fk_id_schedule) references schedule (id),
+        FOREIGN KEY (fk_id_previous_attention_time) references service_employee_attention_time (id)
+    )
+
+    create
+        index petshop_api_schedule_history_schedule_index
+        on schedule_history (fk_id_schedule, date_created)
+
+    -- feeds the sequential part of schedule.number, e.g. 2023dez10.000001
+    create sequence schedule_number_seq;
+
+    -- domain events written in the same transaction as the change they announce; the outbox
+    -- relay publishes them to kafka in id order and the cleanup prunes the published ones
+    create table outbox
+    (
+        id              bigserial    not null
+            constraint petshop_api_outbox_pkey primary key,
+        event_id        varchar(36)  not null,
+        event_type      varchar(100) not null,
+        event_version   int          not null,
+        aggregate_key   varchar(255) not null,
+        correlation_id  varchar(255) not null default '',
+        payload         jsonb        not null,
+        occurred_at     timestamp    not null,
+        date_created    timestamp    not null default timezone('BRT'::text, now()),
+        date_published  timestamp,
+        attempts        int          not null default 0,
+        last_error      text         not null default '',
+        next_attempt_at timestamp    not null default now(),
+        -- set when the relay gave up on the event after too many attempts
+        date_parked     timestamp
+    )
+
+    create
+        unique index petshop_api_outbox_event_id_uindex
+        on outbox (event_id)
+
+    create
+        index petshop_api_outbox_pending_index
+        on outbox (id)
+        where date_published is null and date_parked is null
+
+    create
+        index petshop_api_outbox_pending_key_index
+        on outbox (aggregate_key, id)
+        where date_published is null and date_parked is null
+
+    create
+        index petshop_api_outbox_published_index
+        on outbox (date_published)
+        where date_published is not null
 
 
 -- Create default inserts
@@ -257,8 +427,8 @@ VALUES ('petshop juiz de fora', 'pet_jf@gmail.com', now(), 1, '38988657000181',
 INSERT INTO petshop_api.phone (number, code_area, phone_type)
 VALUES ('912345674', '72', 'celular');
 
-INSERT INTO petshop_api.phone_user(fk_id_phone, fk_id_user, user_type)
-VALUES (1, 1, 'contract');
+INSERT INTO petshop_api.phone_user(fk_id_phone, fk_id_user, user_type, is_primary)
+VALUES (1, 1, 'contract', true);
 
 -- first customer
 INSERT INTO petshop_api.address (street, number, complement, neighborhood, zip_code, city, state, country)
@@ -270,8 +440,8 @@ VALUES ('siclano', 2, 'siclano@gmail.com', 1, '22233344409', 'individual');
 INSERT INTO petshop_api.phone (number, code_area, phone_type)
 VALUES ('912345000', '72', 'celular');
 
-INSERT INTO petshop_api.phone_user(fk_id_phone, fk_id_user, user_type)
-VALUES (2, 1, 'customer');
+INSERT INTO petshop_api.phone_user(fk_id_phone, fk_id_user, user_type, is_primary)
+VALUES (2, 1, 'customer', true);
 
 -- second customer
 
@@ -284,8 +454,8 @@ VALUES ('testando cnpj', 3, 'company@gmail.com', 1, '38988657000182', 'legal');
 INSERT INTO petshop_api.phone (number, code_area, phone_type)
 VALUES ('900045678', '72', 'celular');
 
-INSERT INTO petshop_api.phone_user(fk_id_phone, fk_id_user, user_type)
-VALUES (3, 2, 'customer');
+INSERT INTO petshop_api.phone_user(fk_id_phone, fk_id_user, user_type, is_primary)
+VALUES (3, 2, 'customer', true);
 
 -- pet control
 
@@ -308,6 +478,10 @@ VALUES ('BANHO', 55.99, true, 1, 'Banho com sais minerais e água morna.');
 INSERT INTO petshop_api.service (name, price, active, fk_id_contract, description)
 VALUES ('VACINA ANTIRRABICA', 112.70, true, 1, 'Vacina antirrabica para cachorros.');
 
+INSERT INTO petshop_api.service_price_history (fk_id_service, price, fk_id_contract)
+SELECT id, price, fk_id_contract
+FROM petshop_api.service;
+
 -- Employee
 
 INSERT INTO petshop_api.employee(name, register, fk_id_contract, document)
@@ -321,19 +495,23 @@ VALUES ('Brave Vacinador', 'FUNC-0003', 1, '05740847036');
 
 -- service employee attention time
 
-INSERT INTO petshop_api.service_employee_attention_time(active, initial_time,  fk_id_service, fk_id_contract, fk_id_employee)
-VALUES (true, '9:00', 1, 1, 1),
-       (true, '9:00', 2, 1, 1),
-       (true, '10:00', 1, 1, 1),
-       (true, '11:00', 2, 1, 1),
-       (true, '10:00', 2, 1, 2),
-       (true, '13:00', 2, 1, 2),
-       (false, '8:00', 3, 1, 3);
+INSERT INTO petshop_api.service_employee_attention_time(active, initial_time, final_time, fk_id_service, fk_id_contract, fk_id_employee)
+VALUES (true, '09:00', '10:00', 1, 1, 1),
+       (true, '09:00', '10:00', 2, 1, 1),
+       (true, '10:00', '11:00', 1, 1, 1),
+       (true, '11:00', '12:00', 2, 1, 1),
+       (true, '10:00', '11:00', 2, 1, 2),
+       (true, '13:00', '14:00', 2, 1, 2),
+       (false, '08:00', '09:00', 3, 1, 3);
 
 INSERT INTO petshop_api.schedule(date_created, number, booked_at, price, fk_id_pet, fk_id_service_employee_attention_time)
 VALUES (now(), '2024020001', now() + interval '1 day', 10.50, 1, 1),
        (now(), '2024020002', now() + interval '1 day', 100.50, 2, 4);
 
+INSERT INTO petshop_api.schedule_history(fk_id_schedule, action, to_status, actor)
+VALUES (1, 'request', 'requested', 'schedule-channel'),
+       (2, 'request', 'requested', 'schedule-channel');
+
 
 -- FUNCTIONS
 
@@ -80,13 +80,19 @@ INSERT INTO petshop_auth.access(action, description)
 VALUES ('CUSTOMER_CREATE', 'access to create a new customer'),
        ('CUSTOMER_UPDATE', 'access to update a known customer'),
        ('EMPLOYEE_CREATE', 'access to create a ner employee'),
-       ('EMPLOYEE_UPDATE', 'access to update a known employee');
+       ('EMPLOYEE_UPDATE', 'access to update a known employee'),
+       ('CATALOG_CREATE', 'access to create species and breeds'),
+       ('CONTRACT_CREATE', 'access to create a new contract'),
+       ('CONTRACT_UPDATE', 'access to update a known contract');
 
 INSERT INTO petshop_auth.profile_access(fk_profile, fk_access)
 VALUES ('ADMINISTRATOR', 'CUSTOMER_CREATE'),
        ('ADMINISTRATOR', 'CUSTOMER_UPDATE'),
        ('ADMINISTRATOR', 'EMPLOYEE_CREATE'),
        ('ADMINISTRATOR', 'EMPLOYEE_UPDATE'),
+       ('ADMINISTRATOR', 'CATALOG_CREATE'),
+       ('ADMINISTRATOR', 'CONTRACT_CREATE'),
+       ('ADMINISTRATOR', 'CONTRACT_UPDATE'),
        ('API', 'CUSTOMER_CREATE'),
        ('API', 'CUSTOMER_UPDATE'),
        ('CUSTOMER', 'CUSTOMER_CREATE'),
@@ -12,6 +12,10 @@ type setting struct {
 		Port         string        `envconfig:"PORT" default:"5001" required:"true" ignored:"false"`
 		ReadTimeout  time.Duration `envconfig:"READ_TIMEOUT" default:"10s"`
 		WriteTimeout time.Duration `envconfig:"READ_TIMEOUT" default:"10s"`
+
+		// ShutdownTimeout bounds the whole shutdown: draining HTTP requests, stopping the
+		// consumers and closing the Postgres and Redis pools.
+		ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"30s"`
 	}
 
 	Redis struct {
@@ -37,8 +41,33 @@ type setting struct {
 			GroupID         string `envconfig:"KAFKA_SCHEDULE_GROUPID" default:"kafka_schedule"`
 			AutoOffsetReset string `envconfig:"KAFKA_SCHEDULE_AUTO_OFFSET_RESET" default:"earliest"`
 			Topic           string `envconfig:"KAFKA_SCHEDULE_TOPIC" default:"schedule"`
+
+			DeadLetterTopic     string        `envconfig:"KAFKA_SCHEDULE_DEAD_LETTER_TOPIC" default:"schedule_dead_letter"`
+			DeclinedTopic       string        `envconfig:"KAFKA_SCHEDULE_DECLINED_TOPIC" default:"schedule_declined"`
+			CommandTopic        string        `envconfig:"KAFKA_SCHEDULE_COMMAND_TOPIC" default:"schedule_command"`
+			MaxAttempts         int           `envconfig:"KAFKA_SCHEDULE_MAX_ATTEMPTS" default:"5"`
+			RetryInitialBackoff time.Duration `envconfig:"KAFKA_SCHEDULE_RETRY_INITIAL_BACKOFF" default:"200ms"`
+			RetryMaxBackoff     time.Duration `envconfig:"KAFKA_SCHEDULE_RETRY_MAX_BACKOFF" default:"10s"`
+		}
+
+		// Event is where the domain events (customer.created, schedule.confirmed, ...) are published.
+		Event struct {
+			BootstrapServer string `envconfig:"KAFKA_EVENT_BOOTSTRAP_SERVER" default:"localhost:29092"`
+			Topic           string `envconfig:"KAFKA_EVENT_TOPIC" default:"petshop_events"`
 		}
 	}
+
+	// Outbox controls the relay publishing the domain events stored with the changes they announce.
+	Outbox struct {
+		PollInterval        time.Duration `envconfig:"OUTBOX_POLL_INTERVAL" default:"1s"`
+		BatchSize           int           `envconfig:"OUTBOX_BATCH_SIZE" default:"100"`
+		RetryInitialBackoff time.Duration `envconfig:"OUTBOX_RETRY_INITIAL_BACKOFF" default:"1s"`
+		RetryMaxBackoff     time.Duration `envconfig:"OUTBOX_RETRY_MAX_BACKOFF" default:"5m"`
+		MaxAttempts         int           `envconfig:"OUTBOX_MAX_ATTEMPTS" default:"20"`
+		ClaimTimeout        time.Duration `envconfig:"OUTBOX_CLAIM_TIMEOUT" default:"1m"`
+		Retention           time.Duration `envconfig:"OUTBOX_RETENTION" default:"168h"`
+		CleanupInterval     time.Duration `envconfig:"OUTBOX_CLEANUP_INTERVAL" default:"1h"`
+	}
 }
 
 var Setting setting
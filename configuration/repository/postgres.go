@@ -36,7 +36,10 @@ func connecting(conString string, loggerSugar *zap.SugaredLogger) (*gorm.DB, err
 
 	for {
 		loggerSugar.Infow("trying starts postgres db", "try", tryConnect)
-		DB, err := gorm.Open(postgres.Open(conString), &gorm.Config{})
+		DB, err := gorm.Open(postgres.Open(conString), &gorm.Config{
+			// translates driver errors such as unique violations into gorm.ErrDuplicatedKey
+			TranslateError: true,
+		})
 		if err != nil && tryConnect != 3 {
 
 			tryConnect++
@@ -52,3 +55,14 @@ func connecting(conString string, loggerSugar *zap.SugaredLogger) (*gorm.DB, err
 		return DB, err
 	}
 }
+
+// ClosePostgresDB closes the connection pool behind the gorm DB.
+func ClosePostgresDB(DB *gorm.DB) error {
+
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
+}